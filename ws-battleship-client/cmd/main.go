@@ -8,6 +8,8 @@ import (
 	"syscall"
 	"ws-battleship-client/internal/application"
 	"ws-battleship-client/internal/config"
+	"ws-battleship-client/internal/delivery/transport"
+	"ws-battleship-client/internal/domain/profile"
 	"ws-battleship-shared/pkg/logger"
 )
 
@@ -25,6 +27,16 @@ func main() {
 		panic(fmt.Sprintln("failed to create a logger", err))
 	}
 
-	app := application.NewApp(ctx, cfg, logger)
+	profile, err := profile.Load(cfg.App.ProfilePath)
+	if err != nil {
+		panic(fmt.Sprintln("failed to load a profile", err))
+	}
+
+	knownHosts, err := transport.LoadKnownHosts(cfg.App.KnownHostsPath)
+	if err != nil {
+		panic(fmt.Sprintln("failed to load known hosts", err))
+	}
+
+	app := application.NewApp(ctx, cfg, profile, transport.NewTransport(cfg.App.TLS, knownHosts), logger)
 	app.Run(ctx)
 }
@@ -7,6 +7,8 @@ import (
 	"time"
 	"ws-battleship-client/internal/application/states"
 	"ws-battleship-client/internal/config"
+	"ws-battleship-client/internal/delivery/transport"
+	"ws-battleship-client/internal/domain/profile"
 	"ws-battleship-client/internal/domain/views"
 	"ws-battleship-shared/domain"
 	"ws-battleship-shared/events"
@@ -28,19 +30,23 @@ type App struct {
 	renderCh chan tea.Model
 
 	cfg          *config.Config
+	profile      *profile.Profile
+	transport    *transport.Transport
 	logger       logger.Logger
 	stateMachine states.StateMachine
 	mainMenu     *views.MainMenuView
 	metadata     domain.ClientMetadata
 }
 
-func NewApp(ctx context.Context, cfg *config.Config, logger logger.Logger) *App {
+func NewApp(ctx context.Context, cfg *config.Config, profile *profile.Profile, transport *transport.Transport, logger logger.Logger) *App {
 	stateMachine := states.NewStateMachine()
 
 	app := &App{
 		ctx:          ctx,
 		renderCh:     make(chan tea.Model, 1),
 		cfg:          cfg,
+		profile:      profile,
+		transport:    transport,
 		logger:       logger,
 		stateMachine: stateMachine,
 		mainMenu:     views.NewMainMenuView(),
@@ -56,7 +62,7 @@ func (a *App) Run(ctx context.Context) {
 	a.runGameLoop(ctx, &wg)
 	a.runRenderLoop(ctx, &wg)
 
-	a.stateMachine.SwitchState(states.NewMainMenuState(a.stateMachine, a.logger))
+	a.stateMachine.SwitchState(states.NewMainMenuState(a.stateMachine, a.cfg, a.profile, a.transport, a.logger))
 
 	<-ctx.Done()
 	a.logger.Info("received a signal to shutdown the client")
@@ -5,22 +5,28 @@ import (
 	"errors"
 	"net"
 	"time"
+	"ws-battleship-client/internal/delivery/transport"
 	client "ws-battleship-client/internal/delivery/websocket"
+	"ws-battleship-client/internal/domain/profile"
 	"ws-battleship-client/internal/domain/views"
 	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
 	"ws-battleship-shared/pkg/logger"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 )
 
 type ConnectingState struct {
 	stateMachine StateMachine
 	cancel       context.CancelFunc
+	// leaveQueue stops waiting in the matchmaking queue, which has no timeout.
+	leaveQueue context.CancelFunc
 
 	client client.Client
 	ipv4   net.IP
+	// isRanked keeps the state on the screen, while the player waits in the matchmaking queue.
+	isRanked bool
 
 	connectServerView *views.ConnectServerView
 
@@ -28,26 +34,46 @@ type ConnectingState struct {
 	onError   func(err error)
 }
 
-func NewConnectingState(stateMachine StateMachine, ipv4 net.IP, logger logger.Logger) *ConnectingState {
-	metadata := domain.NewClientMetadata(uuid.New().Domain().String())
+func NewConnectingState(stateMachine StateMachine, ipv4 net.IP, profile *profile.Profile, transport *transport.Transport, opts views.ConnectOptions, logger logger.Logger) *ConnectingState {
+	metadata := domain.NewClientMetadata(profile.Nickname)
+	if opts.SpectateMatchID != "" {
+		metadata.Role = domain.SpectatorRole
+		metadata.MatchID = opts.SpectateMatchID
+	} else {
+		metadata.MatchID = opts.JoinMatchID
+		metadata.BotDifficulty = opts.BotDifficulty
+		metadata.CreateRoom = opts.CreateRoom
+		metadata.RoomCode = opts.RoomCode
+		metadata.RoomPassword = opts.RoomPassword
+		metadata.Ranked = opts.Ranked
+	}
 
 	return &ConnectingState{
 		stateMachine:      stateMachine,
-		client:            client.NewClient(stateMachine.Context(), logger, metadata),
+		client:            client.NewClient(stateMachine.Context(), logger, transport, metadata, profile),
 		ipv4:              ipv4,
+		isRanked:          metadata.Ranked,
 		connectServerView: views.NewConnectServerView(),
 	}
 }
 
 func (s *ConnectingState) OnExit() {
-
+	if s.isRanked {
+		s.connectServerView.CancelFunc = nil
+	}
 }
 
 func (s *ConnectingState) OnEnter() {
 	const timeout = 5 * time.Second
 	var ctx context.Context
 	ctx, s.cancel = context.WithTimeout(s.stateMachine.Context(), timeout)
-	go s.startClient(ctx)
+
+	var queueCtx context.Context
+	queueCtx, s.leaveQueue = context.WithCancel(s.stateMachine.Context())
+	if s.isRanked {
+		s.connectServerView.CancelFunc = s.leaveQueue
+	}
+	go s.startClient(ctx, queueCtx)
 }
 
 func (s *ConnectingState) FixedUpdate() {
@@ -69,9 +95,11 @@ func (s *ConnectingState) SetOnError(fn func(err error)) {
 var (
 	ErrTimeout        = context.DeadlineExceeded
 	ErrInvalidAddress = errors.New("invalid IP-address")
+	ErrQueueLeft      = errors.New("left the matchmaking queue")
+	ErrConnectionLost = errors.New("connection to the server was lost")
 )
 
-func (s *ConnectingState) startClient(ctx context.Context) {
+func (s *ConnectingState) startClient(ctx, queueCtx context.Context) {
 	if err := s.client.Connect(ctx, s.ipv4); err != nil {
 		if s.onError != nil {
 			switch err {
@@ -85,7 +113,46 @@ func (s *ConnectingState) startClient(ctx context.Context) {
 		return
 	}
 
+	if s.isRanked {
+		if err := s.awaitMatch(queueCtx); err != nil {
+			if s.onError != nil {
+				s.onError(err)
+			}
+			return
+		}
+	}
+
 	if s.onSuccess != nil {
 		s.onSuccess(s.client)
 	}
 }
+
+// awaitMatch shows the status of the matchmaking queue, until the server finds opponents.
+func (s *ConnectingState) awaitMatch(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			_ = s.client.Shutdown()
+			return ErrQueueLeft
+		case e, opened := <-s.client.Messages():
+			if !opened {
+				return ErrConnectionLost
+			}
+
+			// Nothing else is sent, until the player is matched.
+			if e.Type != events.QueueStatusEventType {
+				continue
+			}
+
+			status, err := events.CastTo[events.QueueStatusEvent](e)
+			if err != nil {
+				return err
+			}
+
+			s.connectServerView.SetQueueStatus(status)
+			if status.IsMatched {
+				return nil
+			}
+		}
+	}
+}
@@ -3,9 +3,14 @@ package states
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 	client "ws-battleship-client/internal/delivery/websocket"
+	"ws-battleship-client/internal/domain/views"
+	"ws-battleship-shared/events"
+
+	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -120,3 +125,76 @@ func TestConnectingStateSuccessCallbacks(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectingStateAwaitsMatch(t *testing.T) {
+	newQueueStatus := func(t *testing.T, position int, isMatched bool) events.Event {
+		e, err := events.NewQueueStatusEvent(position, 0, isMatched)
+		require.NoError(t, err)
+		return e
+	}
+
+	t.Run("success callback is called, when the player is matched", func(t *testing.T) {
+		// 1. Arrange
+		messages := make(chan events.Event, 2)
+		messages <- newQueueStatus(t, 1, false)
+		messages <- newQueueStatus(t, 0, true)
+
+		clientMock := client.NewMockClient(t)
+		clientMock.On("Connect", mock.Anything, mock.Anything).Return(nil)
+		clientMock.On("Messages").Return((<-chan events.Event)(messages))
+
+		successCh := make(chan struct{}, 1)
+		state := &ConnectingState{
+			stateMachine:      NewStateMachine(),
+			client:            clientMock,
+			isRanked:          true,
+			connectServerView: views.NewConnectServerView(),
+			onSuccess: func(client client.Client) {
+				successCh <- struct{}{}
+			},
+		}
+
+		// 2. Act
+		state.OnEnter()
+
+		// 3. Assert
+		select {
+		case <-successCh:
+		case <-time.After(time.Second):
+			require.Fail(t, "success callback wasn't called")
+		}
+	})
+
+	t.Run("player leaves the queue", func(t *testing.T) {
+		// 1. Arrange
+		messages := make(chan events.Event, 1)
+		messages <- newQueueStatus(t, 1, false)
+
+		clientMock := client.NewMockClient(t)
+		clientMock.On("Connect", mock.Anything, mock.Anything).Return(nil)
+		clientMock.On("Messages").Return((<-chan events.Event)(messages))
+		clientMock.On("Shutdown").Return(nil)
+
+		errCh := make(chan error, 1)
+		connectServerView := views.NewConnectServerView()
+		state := &ConnectingState{
+			stateMachine:      NewStateMachine(),
+			client:            clientMock,
+			isRanked:          true,
+			connectServerView: connectServerView,
+			onError: func(err error) {
+				errCh <- err
+			},
+		}
+
+		// 2. Act
+		state.OnEnter()
+		require.Eventually(t, func() bool {
+			return strings.Contains(connectServerView.View(), "Position in queue: 1")
+		}, time.Second, 10*time.Millisecond)
+		connectServerView.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+		// 3. Assert
+		require.ErrorIs(t, <-errCh, ErrQueueLeft)
+	})
+}
@@ -1,18 +1,31 @@
 package states
 
-import "ws-battleship-shared/events"
+import (
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+)
 
 func (s *GameState) onPlayerTypedMessage(e events.Event) error {
+	// Spectators are read-only, the server ignores their messages anyway.
+	if s.metadata.IsSpectator() {
+		return nil
+	}
+
 	e.Type = events.SendMessageType
 	return s.client.SendMessage(e)
 }
 
-func (s *GameState) onPlayerPressedFireHandler(targetPlayerID string, cellX, cellY byte) {
+func (s *GameState) onPlayerPressedFireHandler(targetPlayerID string, shots []domain.Coordinate) {
+	if len(shots) == 0 {
+		return
+	}
+
 	args := events.FireCommandArgs{
 		FiringPlayerID: s.metadata.ClientID,
 		TargetPlayerID: targetPlayerID,
-		CellX:          cellX,
-		CellY:          cellY,
+		CellX:          shots[0].X,
+		CellY:          shots[0].Y,
+		Shots:          shots,
 	}
 
 	event, _ := events.NewPlayerFireEvent(args)
@@ -21,3 +34,24 @@ func (s *GameState) onPlayerPressedFireHandler(targetPlayerID string, cellX, cel
 		s.logger.Errorf("failed to send a message: %s", err)
 	}
 }
+
+func (s *GameState) onPlayerPlacedFleetHandler(ships []domain.ShipPlacement) {
+	args := events.PlaceFleetCommandArgs{
+		PlayerID: s.metadata.ClientID,
+		Ships:    ships,
+	}
+
+	event, _ := events.NewPlaceFleetEvent(args)
+
+	if err := s.client.SendMessage(event); err != nil {
+		s.logger.Errorf("failed to send a message: %s", err)
+	}
+}
+
+func (s *GameState) onPlayerRerolledFleetHandler() {
+	event, _ := events.NewRerollFleetEvent(s.metadata.ClientID)
+
+	if err := s.client.SendMessage(event); err != nil {
+		s.logger.Errorf("failed to send a message: %s", err)
+	}
+}
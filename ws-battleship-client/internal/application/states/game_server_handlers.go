@@ -7,6 +7,26 @@ import (
 	"ws-battleship-shared/events"
 )
 
+func (s *GameState) onPlacementStartedHandler(e events.Event) error {
+	placementStartEvent, err := events.CastTo[events.PlacementStartEvent](e)
+	if err != nil {
+		return err
+	}
+
+	s.gameView.StartPlacement(placementStartEvent)
+	return nil
+}
+
+func (s *GameState) onPlayerReadyHandler(e events.Event) error {
+	playerReadyEvent, err := events.CastTo[events.PlayerReadyEvent](e)
+	if err != nil {
+		return err
+	}
+
+	s.gameView.SetPlayerReady(playerReadyEvent.PlayerID)
+	return nil
+}
+
 func (s *GameState) onGameStartedHandler(e events.Event) error {
 	s.gameView.StartGame()
 	return nil
@@ -26,7 +46,7 @@ func (s *GameState) onPlayerUpdateState(e events.Event) error {
 		return err
 	}
 
-	s.gameView.SetGameModel(playerUpdateEvent.GameModel)
+	s.gameView.SetGameModel(playerUpdateEvent.GameState.Model())
 	return nil
 }
 
@@ -40,6 +60,57 @@ func (a *GameState) onPlayerTurnHandler(e events.Event) error {
 	return a.gameView.GiveTurnToPlayer(playerTurnEvent, isLocalPlayer)
 }
 
+func (s *GameState) onShipSunkHandler(e events.Event) error {
+	shipSunkEvent, err := events.CastTo[events.ShipSunkEvent](e)
+	if err != nil {
+		return err
+	}
+
+	s.gameView.SinkShip(shipSunkEvent)
+	return nil
+}
+
+func (s *GameState) onSessionResumedHandler(e events.Event) error {
+	sessionResumedEvent, err := events.CastTo[events.SessionResumedEvent](e)
+	if err != nil {
+		return err
+	}
+
+	s.gameView.ResumeSession(sessionResumedEvent)
+	for _, msg := range sessionResumedEvent.Chat {
+		if err := s.onPlayerSendMessageHandler(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GameState) onPlayerDisconnectedHandler(e events.Event) error {
+	playerDisconnectedEvent, err := events.CastTo[events.PlayerDisconnectedEvent](e)
+	if err != nil {
+		return err
+	}
+
+	s.gameView.SetPlayerDisconnected(playerDisconnectedEvent.PlayerID)
+	return nil
+}
+
+func (s *GameState) onPlayerReconnectedHandler(e events.Event) error {
+	playerReconnectedEvent, err := events.CastTo[events.PlayerReconnectedEvent](e)
+	if err != nil {
+		return err
+	}
+
+	s.gameView.SetPlayerReconnected(playerReconnectedEvent.PlayerID, playerReconnectedEvent.RemainingTime)
+	return nil
+}
+
+// onConnectionLostHandler is invoked by the client itself, when it starts reconnecting.
+func (s *GameState) onConnectionLostHandler(e events.Event) error {
+	s.gameView.SetReconnecting(true)
+	return nil
+}
+
 func (s *GameState) onPlayerSendMessageHandler(e events.Event) error {
 	sendMessageEvent, err := events.CastTo[events.SendMessageEvent](e)
 	if err != nil {
@@ -38,13 +38,22 @@ func NewGameState(stateMachine StateMachine, client websocket.Client, logger log
 }
 
 func (s *GameState) OnExit() {
+	s.eventBus.Unsubscribe(serverEvents.PlacementStartEventType, s.onPlacementStartedHandler)
+	s.eventBus.Unsubscribe(serverEvents.PlayerReadyEventType, s.onPlayerReadyHandler)
 	s.eventBus.Unsubscribe(serverEvents.GameStartEventType, s.onGameStartedHandler)
 	s.eventBus.Unsubscribe(serverEvents.GameEndEventType, s.onGameEndHandler)
 	s.eventBus.Unsubscribe(serverEvents.PlayerUpdateStateEventType, s.onPlayerUpdateState)
 	s.eventBus.Unsubscribe(serverEvents.PlayerTurnEventType, s.onPlayerTurnHandler)
+	s.eventBus.Unsubscribe(serverEvents.ShipSunkEventType, s.onShipSunkHandler)
 	s.eventBus.Unsubscribe(serverEvents.SendMessageType, s.onPlayerSendMessageHandler)
+	s.eventBus.Unsubscribe(serverEvents.SessionResumedEventType, s.onSessionResumedHandler)
+	s.eventBus.Unsubscribe(serverEvents.PlayerDisconnectedEventType, s.onPlayerDisconnectedHandler)
+	s.eventBus.Unsubscribe(serverEvents.PlayerReconnectedEventType, s.onPlayerReconnectedHandler)
+	s.eventBus.Unsubscribe(clientEvents.ConnectionLostType, s.onConnectionLostHandler)
 	s.eventBus.Unsubscribe(clientEvents.PlayerTypedMessageType, s.onPlayerTypedMessage)
 	s.gameView.SetPlayerFiredHandler(nil)
+	s.gameView.SetFleetPlacedHandler(nil)
+	s.gameView.SetFleetRerollHandler(nil)
 
 	_ = s.client.Shutdown()
 	s.wg.Wait()
@@ -52,13 +61,22 @@ func (s *GameState) OnExit() {
 
 func (s *GameState) OnEnter() {
 	s.gameView.Init()
+	s.eventBus.Subscribe(serverEvents.PlacementStartEventType, s.onPlacementStartedHandler)
+	s.eventBus.Subscribe(serverEvents.PlayerReadyEventType, s.onPlayerReadyHandler)
 	s.eventBus.Subscribe(serverEvents.GameStartEventType, s.onGameStartedHandler)
 	s.eventBus.Subscribe(serverEvents.GameEndEventType, s.onGameEndHandler)
 	s.eventBus.Subscribe(serverEvents.PlayerUpdateStateEventType, s.onPlayerUpdateState)
 	s.eventBus.Subscribe(serverEvents.PlayerTurnEventType, s.onPlayerTurnHandler)
+	s.eventBus.Subscribe(serverEvents.ShipSunkEventType, s.onShipSunkHandler)
 	s.eventBus.Subscribe(serverEvents.SendMessageType, s.onPlayerSendMessageHandler)
+	s.eventBus.Subscribe(serverEvents.SessionResumedEventType, s.onSessionResumedHandler)
+	s.eventBus.Subscribe(serverEvents.PlayerDisconnectedEventType, s.onPlayerDisconnectedHandler)
+	s.eventBus.Subscribe(serverEvents.PlayerReconnectedEventType, s.onPlayerReconnectedHandler)
+	s.eventBus.Subscribe(clientEvents.ConnectionLostType, s.onConnectionLostHandler)
 	s.eventBus.Subscribe(clientEvents.PlayerTypedMessageType, s.onPlayerTypedMessage)
 	s.gameView.SetPlayerFiredHandler(s.onPlayerPressedFireHandler)
+	s.gameView.SetFleetPlacedHandler(s.onPlayerPlacedFleetHandler)
+	s.gameView.SetFleetRerollHandler(s.onPlayerRerolledFleetHandler)
 
 	s.wg.Add(1)
 	go func() {
@@ -86,6 +104,7 @@ func (s *GameState) handleConnection(ctx context.Context) {
 			return
 		case msg, opened := <-s.client.Messages():
 			if !opened {
+				s.gameView.SetConnectionLost()
 				return
 			}
 
@@ -0,0 +1,95 @@
+package states
+
+import (
+	"context"
+	"time"
+	"ws-battleship-client/internal/delivery/leaderboard"
+	"ws-battleship-client/internal/domain/views"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/pkg/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// leaderboardFetchTimeout keeps the leaderboard responsive, if the server is unreachable.
+const leaderboardFetchTimeout = 3 * time.Second
+
+type LeaderboardState struct {
+	stateMachine    StateMachine
+	leaderboardView *views.LeaderboardView
+	client          leaderboard.Client
+	// handle is the profile, which the server made for the player. It's empty, if the player
+	// hasn't logged into the server yet.
+	handle string
+	// backState is the state, which the player returns to from the leaderboard.
+	backState State
+	logger    logger.Logger
+}
+
+func NewLeaderboardState(stateMachine StateMachine, handle string, client leaderboard.Client, backState State, logger logger.Logger) *LeaderboardState {
+	return &LeaderboardState{
+		stateMachine:    stateMachine,
+		leaderboardView: views.NewLeaderboardView(handle),
+		client:          client,
+		handle:          handle,
+		backState:       backState,
+		logger:          logger,
+	}
+}
+
+func (s *LeaderboardState) OnExit() {
+	s.leaderboardView.LeaderboardFunc = nil
+	s.leaderboardView.HistoryFunc = nil
+	s.leaderboardView.BackFunc = nil
+}
+
+func (s *LeaderboardState) OnEnter() {
+	s.leaderboardView.Init()
+	s.leaderboardView.LeaderboardFunc = s.onLeaderboardFetched
+	s.leaderboardView.HistoryFunc = s.onHistoryFetched
+	s.leaderboardView.BackFunc = s.onBack
+	s.leaderboardView.Fetch()
+}
+
+func (s *LeaderboardState) FixedUpdate() {
+	s.leaderboardView.FixedUpdate()
+}
+
+func (s *LeaderboardState) View() tea.Model {
+	return s.leaderboardView
+}
+
+func (s *LeaderboardState) onLeaderboardFetched(sortBy domain.LeaderboardSort, page int) {
+	ctx, cancel := context.WithTimeout(s.stateMachine.Context(), leaderboardFetchTimeout)
+	defer cancel()
+
+	entries, err := s.client.Leaderboard(ctx, sortBy, page)
+	s.leaderboardView.Err = err
+	if err != nil {
+		s.logger.Errorf("failed to fetch the leaderboard: %s", err)
+		return
+	}
+	s.leaderboardView.SetLeaderboard(entries)
+}
+
+func (s *LeaderboardState) onHistoryFetched(page int) {
+	if s.handle == "" {
+		s.leaderboardView.SetHistory(domain.Page[domain.MatchRecord]{})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(s.stateMachine.Context(), leaderboardFetchTimeout)
+	defer cancel()
+
+	records, err := s.client.PlayerMatches(ctx, s.handle, page)
+	s.leaderboardView.Err = err
+	if err != nil {
+		s.logger.Errorf("failed to fetch the match history: %s", err)
+		return
+	}
+	s.leaderboardView.SetHistory(records)
+}
+
+func (s *LeaderboardState) onBack() {
+	s.stateMachine.SwitchState(s.backState)
+}
@@ -0,0 +1,118 @@
+package states
+
+import (
+	"context"
+	"net"
+	"time"
+	"ws-battleship-client/internal/delivery/lobby"
+	"ws-battleship-client/internal/delivery/transport"
+	"ws-battleship-client/internal/delivery/websocket"
+	"ws-battleship-client/internal/domain/profile"
+	"ws-battleship-client/internal/domain/views"
+	"ws-battleship-shared/pkg/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// lobbyFetchTimeout keeps the lobby responsive, if the server is unreachable.
+const lobbyFetchTimeout = 3 * time.Second
+
+type LobbyState struct {
+	stateMachine StateMachine
+	lobbyView    *views.LobbyView
+	client       lobby.Client
+	ipv4         net.IP
+	profile      *profile.Profile
+	transport    *transport.Transport
+	// backState is the state, which the player returns to from the lobby.
+	backState State
+	logger    logger.Logger
+}
+
+func NewLobbyState(stateMachine StateMachine, ipv4 net.IP, profile *profile.Profile, transport *transport.Transport, client lobby.Client, backState State, logger logger.Logger) *LobbyState {
+	return &LobbyState{
+		stateMachine: stateMachine,
+		lobbyView:    views.NewLobbyView(),
+		client:       client,
+		ipv4:         ipv4,
+		profile:      profile,
+		transport:    transport,
+		backState:    backState,
+		logger:       logger,
+	}
+}
+
+func (s *LobbyState) OnExit() {
+	s.lobbyView.JoinFunc = nil
+	s.lobbyView.SpectateFunc = nil
+	s.lobbyView.RefreshFunc = nil
+	s.lobbyView.BackFunc = nil
+}
+
+func (s *LobbyState) OnEnter() {
+	s.lobbyView.Init()
+	s.lobbyView.JoinFunc = s.onMatchJoined
+	s.lobbyView.SpectateFunc = s.onMatchSpectated
+	s.lobbyView.RefreshFunc = s.onRefresh
+	s.lobbyView.BackFunc = s.onBack
+
+	// The error of the failed connection stays on the screen.
+	if err := s.fetchMatches(); err != nil {
+		s.lobbyView.Err = err
+	}
+}
+
+func (s *LobbyState) FixedUpdate() {
+	s.lobbyView.FixedUpdate()
+}
+
+func (s *LobbyState) View() tea.Model {
+	return s.lobbyView
+}
+
+func (s *LobbyState) onRefresh() {
+	s.lobbyView.Err = s.fetchMatches()
+}
+
+func (s *LobbyState) fetchMatches() error {
+	ctx, cancel := context.WithTimeout(s.stateMachine.Context(), lobbyFetchTimeout)
+	defer cancel()
+
+	matches, err := s.client.Matches(ctx)
+	if err != nil {
+		s.logger.Errorf("failed to fetch matches: %s", err)
+		return err
+	}
+
+	s.lobbyView.SetMatches(matches)
+	return nil
+}
+
+func (s *LobbyState) onMatchJoined(matchID string) {
+	s.connect(views.ConnectOptions{JoinMatchID: matchID})
+}
+
+func (s *LobbyState) onMatchSpectated(matchID string) {
+	s.connect(views.ConnectOptions{SpectateMatchID: matchID})
+}
+
+func (s *LobbyState) connect(opts views.ConnectOptions) {
+	connectionState := NewConnectingState(s.stateMachine, s.ipv4, s.profile, s.transport, opts, s.logger)
+
+	connectionState.SetOnSuccess(func(client websocket.Client) {
+		time.Sleep(time.Second)
+		s.stateMachine.SwitchState(NewGameState(s.stateMachine, client, s.logger))
+	})
+
+	// On connection failure, return to the lobby and display error to user.
+	connectionState.SetOnError(func(err error) {
+		s.lobbyView.Err = err
+		s.stateMachine.SwitchState(s)
+	})
+
+	s.stateMachine.SwitchState(connectionState)
+}
+
+func (s *LobbyState) onBack() {
+	s.stateMachine.SwitchState(s.backState)
+}
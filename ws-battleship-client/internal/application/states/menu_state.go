@@ -3,8 +3,15 @@ package states
 import (
 	"net"
 	"time"
+	"ws-battleship-client/internal/config"
+	"ws-battleship-client/internal/delivery/leaderboard"
+	"ws-battleship-client/internal/delivery/lobby"
+	"ws-battleship-client/internal/delivery/transport"
 	"ws-battleship-client/internal/delivery/websocket"
+	"ws-battleship-client/internal/domain/offline"
+	"ws-battleship-client/internal/domain/profile"
 	"ws-battleship-client/internal/domain/views"
+	"ws-battleship-shared/domain"
 	"ws-battleship-shared/pkg/logger"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,24 +20,38 @@ import (
 type MainMenuState struct {
 	stateMachine StateMachine
 	menuView     *views.MainMenuView
+	cfg          *config.Config
+	profile      *profile.Profile
+	transport    *transport.Transport
 	logger       logger.Logger
 }
 
-func NewMainMenuState(stateMachine StateMachine, logger logger.Logger) *MainMenuState {
+func NewMainMenuState(stateMachine StateMachine, cfg *config.Config, profile *profile.Profile, transport *transport.Transport, logger logger.Logger) *MainMenuState {
 	return &MainMenuState{
 		stateMachine: stateMachine,
 		menuView:     views.NewMainMenuView(),
+		cfg:          cfg,
+		profile:      profile,
+		transport:    transport,
 		logger:       logger,
 	}
 }
 
 func (s *MainMenuState) OnExit() {
 	s.menuView.ConnectFunc = nil
+	s.menuView.LobbyFunc = nil
+	s.menuView.LeaderboardFunc = nil
+	s.menuView.OfflineFunc = nil
+	s.menuView.ReplaysFunc = nil
 }
 
 func (s *MainMenuState) OnEnter() {
 	s.menuView.Init()
 	s.menuView.ConnectFunc = s.onPlayerConnecting
+	s.menuView.LobbyFunc = s.onLobbyOpened
+	s.menuView.LeaderboardFunc = s.onLeaderboardOpened
+	s.menuView.OfflineFunc = s.onOfflineGameStarted
+	s.menuView.ReplaysFunc = s.onReplaysOpened
 }
 
 func (s *MainMenuState) FixedUpdate() {
@@ -41,8 +62,8 @@ func (s *MainMenuState) View() tea.Model {
 	return s.menuView
 }
 
-func (s *MainMenuState) onPlayerConnecting(ipv4 net.IP) {
-	connectionState := NewConnectingState(s.stateMachine, ipv4, s.logger)
+func (s *MainMenuState) onPlayerConnecting(ipv4 net.IP, opts views.ConnectOptions) {
+	connectionState := NewConnectingState(s.stateMachine, ipv4, s.profile, s.transport, opts, s.logger)
 
 	// If connection succeeds, proceed to game state.
 	connectionState.SetOnSuccess(func(client websocket.Client) {
@@ -58,3 +79,29 @@ func (s *MainMenuState) onPlayerConnecting(ipv4 net.IP) {
 
 	s.stateMachine.SwitchState(connectionState)
 }
+
+func (s *MainMenuState) onLobbyOpened(ipv4 net.IP) {
+	s.stateMachine.SwitchState(NewLobbyState(s.stateMachine, ipv4, s.profile, s.transport, lobby.NewHTTPClient(ipv4, s.transport), s, s.logger))
+}
+
+func (s *MainMenuState) onLeaderboardOpened(ipv4 net.IP) {
+	credential, _ := s.profile.Credential(ipv4.String())
+	s.stateMachine.SwitchState(NewLeaderboardState(s.stateMachine, credential.Handle, leaderboard.NewHTTPClient(ipv4, s.transport), s, s.logger))
+}
+
+// onOfflineGameStarted plays against the computer, which runs on the local server.
+func (s *MainMenuState) onOfflineGameStarted() {
+	metadata := domain.NewClientMetadata("You")
+	game := offline.NewGame(metadata, domain.DefaultRules(), domain.NewSeed())
+	client := websocket.NewLocalClient(metadata, game, s.logger)
+
+	if err := client.Connect(s.stateMachine.Context(), nil); err != nil {
+		s.menuView.IPv4Error = err
+		return
+	}
+	s.stateMachine.SwitchState(NewGameState(s.stateMachine, client, s.logger))
+}
+
+func (s *MainMenuState) onReplaysOpened() {
+	s.stateMachine.SwitchState(NewReplayListState(s.stateMachine, s.cfg.App.ReplaysDir, s, s.logger))
+}
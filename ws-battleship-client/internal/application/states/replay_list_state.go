@@ -0,0 +1,89 @@
+package states
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+	"ws-battleship-client/internal/domain/replay"
+	"ws-battleship-client/internal/domain/views"
+	"ws-battleship-shared/pkg/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ReplayListState struct {
+	stateMachine StateMachine
+	listView     *views.ReplayListView
+	// backState is the state, which the player returns to from the list.
+	backState State
+	dir       string
+	logger    logger.Logger
+}
+
+func NewReplayListState(stateMachine StateMachine, dir string, backState State, logger logger.Logger) *ReplayListState {
+	return &ReplayListState{
+		stateMachine: stateMachine,
+		listView:     views.NewReplayListView(dir),
+		backState:    backState,
+		dir:          dir,
+		logger:       logger,
+	}
+}
+
+func (s *ReplayListState) OnExit() {
+	s.listView.OpenFunc = nil
+	s.listView.BackFunc = nil
+}
+
+func (s *ReplayListState) OnEnter() {
+	s.listView.Init()
+	s.listView.SetReplays(s.findReplays())
+	s.listView.OpenFunc = s.onReplayOpened
+	s.listView.BackFunc = s.onBack
+}
+
+func (s *ReplayListState) FixedUpdate() {
+	s.listView.FixedUpdate()
+}
+
+func (s *ReplayListState) View() tea.Model {
+	return s.listView
+}
+
+func (s *ReplayListState) onReplayOpened(path string) {
+	matchReplay, err := replay.Open(path)
+	if err != nil {
+		s.logger.Errorf("failed to open replay %s: %s", path, err)
+		s.listView.Err = err
+		return
+	}
+
+	s.listView.Err = nil
+	s.stateMachine.SwitchState(NewReplayState(s.stateMachine, matchReplay, s, s.logger))
+}
+
+func (s *ReplayListState) onBack() {
+	s.stateMachine.SwitchState(s.backState)
+}
+
+// findReplays returns journals in the replays directory, the latest ones go first.
+func (s *ReplayListState) findReplays() []string {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.jsonl"))
+	if err != nil {
+		s.logger.Errorf("failed to list replays: %s", err)
+		return nil
+	}
+
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+
+	slices.SortFunc(paths, func(lhs, rhs string) int {
+		return modTimes[rhs].Compare(modTimes[lhs])
+	})
+	return paths
+}
@@ -0,0 +1,190 @@
+package states
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+	"ws-battleship-client/internal/delivery/websocket"
+	"ws-battleship-client/internal/domain/replay"
+	"ws-battleship-client/internal/domain/views"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+	"ws-battleship-shared/pkg/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// replayTurnTime is the pause between turns, while the replay is playing.
+const replayTurnTime = time.Second
+
+// ReplayState plays the recorded match back through the same game state, which is used online.
+// The game state is recreated from scratch, whenever the replay is rewound or watched from
+// another side.
+type ReplayState struct {
+	mu     sync.Mutex
+	logger logger.Logger
+
+	stateMachine StateMachine
+	// backState is the state, which the player returns to from the replay.
+	backState  State
+	replay     *replay.Replay
+	replayView *views.ReplayView
+	gameState  *GameState
+	client     *websocket.ReplayClient
+
+	isPaused   bool
+	nextTurnAt time.Time
+}
+
+func NewReplayState(stateMachine StateMachine, matchReplay *replay.Replay, backState State, logger logger.Logger) *ReplayState {
+	return &ReplayState{
+		logger:       logger,
+		stateMachine: stateMachine,
+		backState:    backState,
+		replay:       matchReplay,
+		replayView:   views.NewReplayView(),
+	}
+}
+
+func (s *ReplayState) OnExit() {
+	s.replayView.TogglePauseFunc = nil
+	s.replayView.StepFunc = nil
+	s.replayView.SeekFunc = nil
+	s.replayView.SwitchViewFunc = nil
+	s.replayView.BackFunc = nil
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.gameState != nil {
+		s.gameState.OnExit()
+		s.gameState = nil
+	}
+}
+
+func (s *ReplayState) OnEnter() {
+	s.replayView.TogglePauseFunc = s.onTogglePause
+	s.replayView.StepFunc = s.onStep
+	s.replayView.SeekFunc = s.onSeek
+	s.replayView.SwitchViewFunc = s.onSwitchView
+	s.replayView.BackFunc = s.onBack
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seek(0)
+}
+
+func (s *ReplayState) FixedUpdate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.gameState == nil {
+		return
+	}
+	s.gameState.FixedUpdate()
+
+	if s.isPaused || time.Now().Before(s.nextTurnAt) {
+		return
+	}
+
+	if s.replay.IsOver() {
+		s.isPaused = true
+	} else {
+		s.client.Push(s.replay.NextTurn()...)
+		s.nextTurnAt = time.Now().Add(replayTurnTime)
+	}
+	s.updateStatus()
+}
+
+func (s *ReplayState) View() tea.Model {
+	return s.replayView
+}
+
+func (s *ReplayState) onTogglePause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.isPaused = !s.isPaused
+	if !s.isPaused && s.replay.IsOver() {
+		s.seek(0)
+	}
+	s.nextTurnAt = time.Now()
+	s.updateStatus()
+}
+
+func (s *ReplayState) onStep(turns int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.isPaused = true
+	if turns == 1 {
+		s.client.Push(s.replay.NextTurn()...)
+	} else {
+		s.seek(s.replay.Turn() + turns)
+	}
+	s.updateStatus()
+}
+
+func (s *ReplayState) onSeek(turn int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.isPaused = true
+	s.seek(turn)
+}
+
+func (s *ReplayState) onSwitchView() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	viewIDs := s.replay.Views()
+	next := (slices.Index(viewIDs, s.replay.ViewID()) + 1) % len(viewIDs)
+	s.replay.SetView(viewIDs[next])
+	s.seek(s.replay.Turn())
+}
+
+func (s *ReplayState) onBack() {
+	s.stateMachine.SwitchState(s.backState)
+}
+
+// seek recreates the game state and feeds it with all events, which lead to the turn.
+func (s *ReplayState) seek(turn int) {
+	if s.gameState != nil {
+		s.gameState.OnExit()
+	}
+
+	s.client = websocket.NewReplayClient(s.viewMetadata(), events.ReadBufferBytesMax)
+	s.gameState = NewGameState(s.stateMachine, s.client, s.logger)
+	s.gameState.OnEnter()
+	s.client.Push(s.replay.Seek(max(turn, 0))...)
+
+	s.replayView.SetGameView(s.gameState.gameView)
+	s.nextTurnAt = time.Now().Add(replayTurnTime)
+	s.updateStatus()
+}
+
+// viewMetadata makes the game view read-only. It's shown from the side of the watched player,
+// if there is any.
+func (s *ReplayState) viewMetadata() domain.ClientMetadata {
+	return domain.ClientMetadata{
+		ClientID: s.replay.ViewID(),
+		Role:     domain.SpectatorRole,
+	}
+}
+
+func (s *ReplayState) updateStatus() {
+	viewName := "OMNISCIENT"
+	for _, player := range s.replay.Players() {
+		if player.ID == s.replay.ViewID() {
+			viewName = fmt.Sprintf("PLAYER '%s'", player.Nickname)
+		}
+	}
+
+	s.replayView.SetStatus(views.ReplayStatus{
+		IsPaused:   s.isPaused,
+		Turn:       s.replay.Turn(),
+		TurnsCount: s.replay.TurnsCount(),
+		ViewName:   viewName,
+	})
+}
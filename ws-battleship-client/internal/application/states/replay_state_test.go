@@ -0,0 +1,68 @@
+package states
+
+import (
+	"testing"
+	"ws-battleship-client/internal/domain/replay"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReplay(t *testing.T) *replay.Replay {
+	t.Helper()
+
+	must := func(e events.Event, err error) events.Event {
+		require.NoError(t, err)
+		return e
+	}
+
+	var models []*domain.PlayerModel
+	for _, id := range []string{"a", "b"} {
+		models = append(models, domain.NewPlayerModel(domain.NewBoard(domain.DefaultBoardSize), domain.ClientMetadata{ClientID: id, Nickname: id}))
+	}
+	gameState := events.NewRevealedGameState(0, models...)
+
+	return replay.NewReplay([]events.JournalEntry{
+		{RecipientID: events.JournalOmniscient, Event: must(events.NewPlayerUpdateStateEvent(gameState))},
+		{Event: must(events.NewGameStartEvent(1))},
+		{Event: must(events.NewPlayerTurnEvent(1, "a", 0, false, 1))},
+		{Event: must(events.NewPlayerTurnEvent(2, "b", 0, false, 1))},
+		{Event: must(events.NewPlayerTurnEvent(3, "a", 0, false, 1))},
+	})
+}
+
+func TestReplayState(t *testing.T) {
+	t.Run("stepping pauses the replay", func(t *testing.T) {
+		// 1. Arrange
+		state := NewReplayState(NewStateMachine(), newTestReplay(t), nil, nil)
+		state.OnEnter()
+		defer state.OnExit()
+
+		// 2. Act
+		state.onStep(1)
+		state.onStep(1)
+		state.onStep(-1)
+
+		// 3. Assert
+		require.True(t, state.isPaused)
+		require.Equal(t, 1, state.replay.Turn())
+	})
+
+	t.Run("switching the view keeps the turn", func(t *testing.T) {
+		// 1. Arrange
+		state := NewReplayState(NewStateMachine(), newTestReplay(t), nil, nil)
+		state.OnEnter()
+		defer state.OnExit()
+		state.onStep(1)
+		state.onStep(1)
+
+		// 2. Act
+		state.onSwitchView()
+
+		// 3. Assert
+		require.Equal(t, "a", state.client.Metadata().ClientID)
+		require.True(t, state.client.Metadata().IsSpectator())
+		require.Equal(t, 2, state.replay.Turn())
+	})
+}
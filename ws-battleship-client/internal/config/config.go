@@ -12,6 +12,14 @@ type AppConfig struct {
 	Port         string `envconfig:"SERVER_PORT" default:"8080"`
 	IsDebugMode  bool   `envconfig:"DEBUG" default:"true"`
 	MouseEnabled bool   `envconfig:"ENABLE_MOUSE" default:"false"`
+	// ReplaysDir holds journals of matches, which are copied from the server to replay them.
+	ReplaysDir string `envconfig:"REPLAYS_DIR" default:"replays"`
+	// ProfilePath is the file with the profile of the player, which is sent to servers.
+	ProfilePath string `envconfig:"PROFILE_PATH" default:"profile.json"`
+	// TLS connects to servers with wss://. Self-signed certificates are pinned on the first
+	// connection into KnownHostsPath.
+	TLS            bool   `envconfig:"SERVER_TLS" default:"false"`
+	KnownHostsPath string `envconfig:"KNOWN_HOSTS_PATH" default:"known_hosts.json"`
 }
 
 func NewConfig() (*Config, error) {
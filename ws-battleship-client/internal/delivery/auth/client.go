@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"ws-battleship-client/internal/delivery/transport"
+	"ws-battleship-shared/domain"
+)
+
+const authPort = 8080
+
+// ErrUnauthorized is returned, when the server doesn't know the profile or its secret.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// Client gets session tokens, which the websocket connection is authenticated with.
+type Client interface {
+	Login(ctx context.Context, req domain.LoginRequest) (domain.SessionToken, error)
+	Refresh(ctx context.Context, token domain.SessionToken) (domain.SessionToken, error)
+}
+
+type HTTPClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+func NewHTTPClient(ipv4 net.IP, transport *transport.Transport) *HTTPClient {
+	return &HTTPClient{
+		httpClient: transport.HTTPClient(ipv4),
+		baseURL:    fmt.Sprintf("%s://%s:%d", transport.HTTPScheme(), ipv4.String(), authPort),
+	}
+}
+
+func (c *HTTPClient) Login(ctx context.Context, loginReq domain.LoginRequest) (domain.SessionToken, error) {
+	body, err := json.Marshal(loginReq)
+	if err != nil {
+		return domain.SessionToken{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/auth/guest", bytes.NewReader(body))
+	if err != nil {
+		return domain.SessionToken{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.do(req, http.StatusCreated)
+}
+
+// Refresh extends the session, which isn't expired yet.
+func (c *HTTPClient) Refresh(ctx context.Context, token domain.SessionToken) (domain.SessionToken, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/auth/refresh", nil)
+	if err != nil {
+		return domain.SessionToken{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+
+	return c.do(req, http.StatusOK)
+}
+
+func (c *HTTPClient) do(req *http.Request, wantCode int) (domain.SessionToken, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return domain.SessionToken{}, fmt.Errorf("failed to request %s: %w", req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return domain.SessionToken{}, fmt.Errorf("failed to request %s: %w", req.URL.Path, ErrUnauthorized)
+	}
+	if resp.StatusCode != wantCode {
+		return domain.SessionToken{}, fmt.Errorf("failed to request %s: %s", req.URL.Path, resp.Status)
+	}
+
+	var body struct {
+		Data domain.SessionToken `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return domain.SessionToken{}, fmt.Errorf("failed to decode %s: %w", req.URL.Path, err)
+	}
+	return body.Data, nil
+}
@@ -0,0 +1,71 @@
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"ws-battleship-client/internal/delivery/transport"
+	"ws-battleship-shared/domain"
+)
+
+const leaderboardPort = 8080
+
+// Client fetches the leaderboard and the match history from the server.
+type Client interface {
+	Leaderboard(ctx context.Context, sortBy domain.LeaderboardSort, page int) (domain.Page[domain.LeaderboardEntry], error)
+	PlayerMatches(ctx context.Context, handle string, page int) (domain.Page[domain.MatchRecord], error)
+}
+
+type HTTPClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+func NewHTTPClient(ipv4 net.IP, transport *transport.Transport) *HTTPClient {
+	return &HTTPClient{
+		httpClient: transport.HTTPClient(ipv4),
+		baseURL:    fmt.Sprintf("%s://%s:%d", transport.HTTPScheme(), ipv4.String(), leaderboardPort),
+	}
+}
+
+func (c *HTTPClient) Leaderboard(ctx context.Context, sortBy domain.LeaderboardSort, page int) (domain.Page[domain.LeaderboardEntry], error) {
+	query := url.Values{}
+	query.Set("sort", sortBy)
+	query.Set("page", strconv.Itoa(page))
+	return getPage[domain.LeaderboardEntry](ctx, c, "/leaderboard?"+query.Encode())
+}
+
+func (c *HTTPClient) PlayerMatches(ctx context.Context, handle string, page int) (domain.Page[domain.MatchRecord], error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	return getPage[domain.MatchRecord](ctx, c, "/players/"+url.PathEscape(handle)+"/matches?"+query.Encode())
+}
+
+func getPage[T any](ctx context.Context, c *HTTPClient, path string) (domain.Page[T], error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return domain.Page[T]{}, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return domain.Page[T]{}, fmt.Errorf("failed to fetch %s: %w", req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return domain.Page[T]{}, fmt.Errorf("failed to fetch %s: %s", req.URL.Path, resp.Status)
+	}
+
+	var body struct {
+		Data domain.Page[T] `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return domain.Page[T]{}, fmt.Errorf("failed to decode %s: %w", req.URL.Path, err)
+	}
+	return body.Data, nil
+}
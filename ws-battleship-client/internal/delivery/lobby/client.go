@@ -0,0 +1,58 @@
+package lobby
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"ws-battleship-client/internal/delivery/transport"
+	"ws-battleship-shared/domain"
+)
+
+const (
+	lobbyEndpoint = "/matches"
+	lobbyPort     = 8080
+)
+
+// Client fetches open matches from the server.
+type Client interface {
+	Matches(ctx context.Context) ([]domain.MatchSummary, error)
+}
+
+type HTTPClient struct {
+	httpClient *http.Client
+	url        string
+}
+
+func NewHTTPClient(ipv4 net.IP, transport *transport.Transport) *HTTPClient {
+	return &HTTPClient{
+		httpClient: transport.HTTPClient(ipv4),
+		url:        fmt.Sprintf("%s://%s:%d%s", transport.HTTPScheme(), ipv4.String(), lobbyPort, lobbyEndpoint),
+	}
+}
+
+func (c *HTTPClient) Matches(ctx context.Context) ([]domain.MatchSummary, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch matches: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch matches: %s", resp.Status)
+	}
+
+	var body struct {
+		Data []domain.MatchSummary `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode matches: %w", err)
+	}
+	return body.Data, nil
+}
@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"ws-battleship-shared/pkg/certs"
+)
+
+var (
+	ErrNoCertificate      = errors.New("server sent no certificate")
+	ErrCertificateChanged = errors.New("certificate of the server has changed since the first connection")
+)
+
+// KnownHosts pins certificates of servers, which aren't trusted by system roots, like self-signed
+// ones in LAN. The certificate is trusted on the first use, and must stay the same afterwards.
+type KnownHosts struct {
+	mu   sync.Mutex
+	path string
+	// fingerprints are pinned certificates by hosts.
+	fingerprints map[string]string
+}
+
+// LoadKnownHosts reads pinned certificates from the file. Pins aren't saved, if the path is empty.
+func LoadKnownHosts(path string) (*KnownHosts, error) {
+	knownHosts := &KnownHosts{
+		path:         path,
+		fingerprints: make(map[string]string),
+	}
+	if path == "" {
+		return knownHosts, nil
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return knownHosts, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read known hosts: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &knownHosts.fingerprints); err != nil {
+		return nil, fmt.Errorf("failed to parse known hosts: %w", err)
+	}
+	return knownHosts, nil
+}
+
+// Verify accepts the certificate, which is signed by a trusted authority, or is pinned for the
+// host. The certificate of an unknown host is pinned.
+func (k *KnownHosts) Verify(host string, state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return ErrNoCertificate
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	leaf := state.PeerCertificates[0]
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: host, Intermediates: intermediates}); err == nil {
+		return nil
+	}
+	return k.trust(host, certs.Fingerprint(leaf))
+}
+
+func (k *KnownHosts) Fingerprint(host string) string {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.fingerprints[host]
+}
+
+func (k *KnownHosts) trust(host, fingerprint string) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	pinned, found := k.fingerprints[host]
+	switch {
+	case !found:
+		k.fingerprints[host] = fingerprint
+		return k.save()
+	case pinned != fingerprint:
+		return fmt.Errorf("%w: host %s, pinned %s, got %s", ErrCertificateChanged, host, pinned, fingerprint)
+	}
+	return nil
+}
+
+func (k *KnownHosts) save() error {
+	if k.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(k.fingerprints, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(k.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create known hosts directory: %w", err)
+	}
+	return os.WriteFile(k.path, data, 0o600)
+}
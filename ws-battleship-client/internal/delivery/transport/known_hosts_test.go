@@ -0,0 +1,105 @@
+package transport
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"ws-battleship-shared/pkg/certs"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+var localhost = net.IPv4(127, 0, 0, 1)
+
+func newTLSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if websocket.IsWebSocketUpgrade(r) {
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err == nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestKnownHosts(t *testing.T) {
+	t.Run("certificate is pinned on the first connection", func(t *testing.T) {
+		// 1. Arrange
+		srv := newTLSServer(t)
+		path := filepath.Join(t.TempDir(), "known_hosts.json")
+		knownHosts, err := LoadKnownHosts(path)
+		require.NoError(t, err)
+
+		// 2. Act
+		resp, err := NewTransport(true, knownHosts).HTTPClient(localhost).Get(srv.URL)
+
+		// 3. Assert
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		reloaded, err := LoadKnownHosts(path)
+		require.NoError(t, err)
+		require.Equal(t, certs.Fingerprint(srv.Certificate()), reloaded.Fingerprint("127.0.0.1"))
+	})
+
+	t.Run("pinned certificate is trusted over wss", func(t *testing.T) {
+		// 1. Arrange
+		srv := newTLSServer(t)
+		knownHosts, err := LoadKnownHosts("")
+		require.NoError(t, err)
+		transport := NewTransport(true, knownHosts)
+		dialer := websocket.Dialer{TLSClientConfig: transport.TLSConfig(localhost)}
+		url := transport.WebsocketScheme() + strings.TrimPrefix(srv.URL, "https")
+
+		// 2. Act
+		for range 2 {
+			conn, _, err := dialer.Dial(url, nil)
+
+			// 3. Assert
+			require.NoError(t, err)
+			_ = conn.Close()
+		}
+	})
+
+	t.Run("changed certificate is refused", func(t *testing.T) {
+		// 1. Arrange
+		srv := newTLSServer(t)
+		path := filepath.Join(t.TempDir(), "known_hosts.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"127.0.0.1": "AA:BB"}`), 0o600))
+		knownHosts, err := LoadKnownHosts(path)
+		require.NoError(t, err)
+
+		// 2. Act
+		_, err = NewTransport(true, knownHosts).HTTPClient(localhost).Get(srv.URL)
+
+		// 3. Assert
+		require.ErrorIs(t, err, ErrCertificateChanged)
+		require.Equal(t, "AA:BB", knownHosts.Fingerprint("127.0.0.1"))
+	})
+
+	t.Run("plain transport doesn't use TLS", func(t *testing.T) {
+		// 1. Arrange
+		transport := NewTransport(false, nil)
+
+		// 2. Act
+		tlsConfig := transport.TLSConfig(localhost)
+
+		// 3. Assert
+		require.Nil(t, tlsConfig)
+		require.Equal(t, "http", transport.HTTPScheme())
+		require.Equal(t, "ws", transport.WebsocketScheme())
+	})
+}
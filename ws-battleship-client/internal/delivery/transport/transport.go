@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+)
+
+// Transport picks, whether the server is reached in plain text or over TLS.
+type Transport struct {
+	isSecure   bool
+	knownHosts *KnownHosts
+}
+
+func NewTransport(isSecure bool, knownHosts *KnownHosts) *Transport {
+	return &Transport{
+		isSecure:   isSecure,
+		knownHosts: knownHosts,
+	}
+}
+
+func (t *Transport) HTTPScheme() string {
+	if t.isSecure {
+		return "https"
+	}
+	return "http"
+}
+
+func (t *Transport) WebsocketScheme() string {
+	if t.isSecure {
+		return "wss"
+	}
+	return "ws"
+}
+
+// TLSConfig returns nil, if TLS is disabled. Certificates are verified by known hosts, since
+// servers in LAN are reached by IP-addresses with self-signed certificates.
+func (t *Transport) TLSConfig(ipv4 net.IP) *tls.Config {
+	if !t.isSecure {
+		return nil
+	}
+
+	// The server name isn't sent for IP-addresses, so the host is kept by the closure.
+	host := ipv4.String()
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The chain is verified in VerifyConnection, which pins self-signed certificates.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			return t.knownHosts.Verify(host, state)
+		},
+	}
+}
+
+func (t *Transport) HTTPClient(ipv4 net.IP) *http.Client {
+	if !t.isSecure {
+		return http.DefaultClient
+	}
+
+	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
+	httpTransport.TLSClientConfig = t.TLSConfig(ipv4)
+	return &http.Client{Transport: httpTransport}
+}
@@ -0,0 +1,149 @@
+package websocket
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+	"ws-battleship-shared/pkg/logger"
+)
+
+// computerThinkTime delays turns of the computer, so the player can follow the game.
+const computerThinkTime = 700 * time.Millisecond
+
+// LocalServer plays the role of the server in offline games.
+type LocalServer interface {
+	// Start returns the events, which the server sends right after the connection.
+	Start() ([]events.Event, error)
+	// Handle applies the event sent by the player.
+	Handle(e events.Event) ([]events.Event, error)
+	// Step makes a move of the computer. It reports false, if the computer doesn't move now.
+	Step() ([]events.Event, bool, error)
+}
+
+// LocalClient runs the game on the local server, so no network is needed.
+type LocalClient struct {
+	wg      sync.WaitGroup
+	once    sync.Once
+	closeCh chan struct{}
+	sendCh  chan events.Event
+	readCh  chan events.Event
+
+	server   LocalServer
+	metadata domain.ClientMetadata
+	logger   logger.Logger
+}
+
+func NewLocalClient(metadata domain.ClientMetadata, server LocalServer, logger logger.Logger) *LocalClient {
+	return &LocalClient{
+		closeCh:  make(chan struct{}),
+		sendCh:   make(chan events.Event, events.WriteBufferBytesMax),
+		readCh:   make(chan events.Event, events.ReadBufferBytesMax),
+		server:   server,
+		metadata: metadata,
+		logger:   logger,
+	}
+}
+
+func (c *LocalClient) Metadata() domain.ClientMetadata {
+	return c.metadata
+}
+
+func (c *LocalClient) Messages() <-chan events.Event {
+	return c.readCh
+}
+
+// Connect starts the local server. The address is ignored.
+func (c *LocalClient) Connect(ctx context.Context, ipv4 net.IP) error {
+	startEvents, err := c.server.Start()
+	if err != nil {
+		return err
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		if c.push(ctx, startEvents) {
+			c.serve(ctx)
+		}
+	}()
+	return nil
+}
+
+func (c *LocalClient) Shutdown() error {
+	c.once.Do(func() {
+		close(c.closeCh)
+		c.wg.Wait()
+		close(c.readCh)
+	})
+	return nil
+}
+
+func (c *LocalClient) SendMessage(e events.Event) error {
+	select {
+	case <-c.closeCh:
+	case c.sendCh <- e:
+	}
+	return nil
+}
+
+// serve handles events of the player one by one and lets the computer move in between.
+func (c *LocalClient) serve(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.closeCh:
+			return
+		case e := <-c.sendCh:
+			answer, err := c.server.Handle(e)
+			if err != nil {
+				c.logger.Errorf("local server failed to handle event type=%s: %s", e.Type, err)
+			}
+			if !c.push(ctx, answer) || !c.stepComputer(ctx) {
+				return
+			}
+		}
+	}
+}
+
+// stepComputer lets the computer move, until the turn returns to the player. It reports false,
+// if the client was closed meanwhile.
+func (c *LocalClient) stepComputer(ctx context.Context) bool {
+	for {
+		answer, ok, err := c.server.Step()
+		if err != nil {
+			c.logger.Errorf("computer failed to move: %s", err)
+		}
+		if !ok {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-c.closeCh:
+			return false
+		case <-time.After(computerThinkTime):
+		}
+
+		if !c.push(ctx, answer) {
+			return false
+		}
+	}
+}
+
+func (c *LocalClient) push(ctx context.Context, answer []events.Event) bool {
+	for _, e := range answer {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-c.closeCh:
+			return false
+		case c.readCh <- e:
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package websocket
+
+import (
+	"context"
+	"net"
+	"sync"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+)
+
+// ReplayClient feeds the game with events of a recorded match instead of the server. Replays
+// are read-only, so all messages sent by the player are dropped.
+type ReplayClient struct {
+	mu       sync.Mutex
+	isClosed bool
+
+	readCh   chan events.Event
+	metadata domain.ClientMetadata
+}
+
+func NewReplayClient(metadata domain.ClientMetadata, bufferSize int) *ReplayClient {
+	return &ReplayClient{
+		readCh:   make(chan events.Event, bufferSize),
+		metadata: metadata,
+	}
+}
+
+func (c *ReplayClient) Metadata() domain.ClientMetadata {
+	return c.metadata
+}
+
+func (c *ReplayClient) Messages() <-chan events.Event {
+	return c.readCh
+}
+
+func (c *ReplayClient) Connect(ctx context.Context, ipv4 net.IP) error {
+	return nil
+}
+
+func (c *ReplayClient) Shutdown() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.isClosed {
+		c.isClosed = true
+		close(c.readCh)
+	}
+	return nil
+}
+
+func (c *ReplayClient) SendMessage(e events.Event) error {
+	return nil
+}
+
+// Push delivers recorded events to the game, as if they came from the server.
+func (c *ReplayClient) Push(recordedEvents ...events.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.isClosed {
+		return
+	}
+
+	for _, e := range recordedEvents {
+		c.readCh <- e
+	}
+}
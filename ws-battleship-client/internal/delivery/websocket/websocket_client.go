@@ -3,10 +3,15 @@ package websocket
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
 	"time"
+	"ws-battleship-client/internal/delivery/auth"
+	"ws-battleship-client/internal/delivery/transport"
+	clientEvents "ws-battleship-client/internal/domain/events"
+	"ws-battleship-client/internal/domain/profile"
 	"ws-battleship-shared/domain"
 	"ws-battleship-shared/events"
 	"ws-battleship-shared/pkg/logger"
@@ -14,53 +19,206 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+const websocketEndpoint = "/ws"
+
+// Dropped connection is restored with exponential backoff until the server can't hold
+// the session anymore.
 const (
-	websocketProtocol = "ws"
-	websocketEndpoint = "/ws"
+	reconnectBackoffMin = 500 * time.Millisecond
+	reconnectBackoffMax = 8 * time.Second
+	reconnectTimeout    = time.Minute
 )
 
+// sessionRefreshMargin refreshes the session token before the dial, if it's about to expire.
+const sessionRefreshMargin = 10 * time.Second
+
 type WebsocketClient struct {
 	once sync.Once
 	wg   sync.WaitGroup
+	mu   sync.RWMutex
 	ctx  context.Context
 
-	logger  logger.Logger
-	conn    *websocket.Conn
-	readCh  chan events.Event
-	writeCh chan []byte
-	closeCh chan struct{}
+	logger    logger.Logger
+	transport *transport.Transport
+	conn      *websocket.Conn
+	readCh    chan events.Event
+	writeCh   chan []byte
+	closeCh   chan struct{}
 
+	ipv4     net.IP
 	metadata domain.ClientMetadata
+	profile  *profile.Profile
+	sessions auth.Client
+	session  domain.SessionToken
 }
 
-func NewClient(ctx context.Context, logger logger.Logger, metadata domain.ClientMetadata) *WebsocketClient {
+func NewClient(ctx context.Context, logger logger.Logger, transport *transport.Transport, metadata domain.ClientMetadata, profile *profile.Profile) *WebsocketClient {
 	return &WebsocketClient{
-		ctx:      ctx,
-		logger:   logger,
-		readCh:   make(chan events.Event, events.ReadBufferBytesMax),
-		writeCh:  make(chan []byte, events.WriteBufferBytesMax),
-		closeCh:  make(chan struct{}),
-		metadata: metadata,
+		ctx:       ctx,
+		logger:    logger,
+		transport: transport,
+		readCh:    make(chan events.Event, events.ReadBufferBytesMax),
+		writeCh:   make(chan []byte, events.WriteBufferBytesMax),
+		closeCh:   make(chan struct{}),
+		metadata:  metadata,
+		profile:   profile,
 	}
 }
 
 func (c *WebsocketClient) Metadata() domain.ClientMetadata {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.metadata
 }
 
 func (c *WebsocketClient) Connect(ctx context.Context, ipv4 net.IP) error {
+	c.ipv4 = ipv4
+	c.sessions = auth.NewHTTPClient(ipv4, c.transport)
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.wg.Add(3)
+	go func(wg *sync.WaitGroup, conn *websocket.Conn) {
+		defer wg.Done()
+		c.ReadMessages(c.ctx, conn)
+	}(&c.wg, conn)
+
+	go func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		c.WriteMessages(c.ctx)
+	}(&c.wg)
+
+	go func(wg *sync.WaitGroup) {
+		defer wg.Done()
+		c.keepSessionAlive(c.ctx)
+	}(&c.wg)
+
+	return nil
+}
+
+// authenticate gets a new session token, unless the current one is still valid. The session is
+// refreshed, while it's alive, so the server keeps the client ID and the session can be resumed.
+func (c *WebsocketClient) authenticate(ctx context.Context) error {
+	session := c.getSession()
+	now := time.Now()
+	if !session.IsExpired(now.Add(sessionRefreshMargin)) {
+		return nil
+	}
+
+	var err error
+	if session.IsExpired(now) {
+		session, err = c.login(ctx)
+	} else {
+		session, err = c.sessions.Refresh(ctx, session)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	c.setSession(session)
+	return nil
+}
+
+// login logs into the profile, which the server made for the player before. The server makes
+// a new profile, if it doesn't know the stored one anymore, and gives out its credential once.
+func (c *WebsocketClient) login(ctx context.Context) (domain.SessionToken, error) {
+	server := c.ipv4.String()
+	req := domain.LoginRequest{Nickname: c.Metadata().Nickname}
+
+	credential, found := c.profile.Credential(server)
+	if found {
+		req.ProfileID, req.ProfileSecret = credential.ID, credential.Secret
+	}
+
+	session, err := c.sessions.Login(ctx, req)
+	if found && errors.Is(err, auth.ErrUnauthorized) {
+		c.logger.Errorf("server doesn't know the profile %s anymore, creating a new one", credential.ID)
+		req.ProfileID, req.ProfileSecret = "", ""
+		session, err = c.sessions.Login(ctx, req)
+	}
+	if err != nil {
+		return domain.SessionToken{}, err
+	}
+
+	if session.Profile != nil {
+		if err := c.profile.SetCredential(server, *session.Profile); err != nil {
+			return domain.SessionToken{}, err
+		}
+	}
+	return session, nil
+}
+
+// keepSessionAlive refreshes the session token at the half of its lifetime, so it doesn't expire
+// while the client is connected.
+func (c *WebsocketClient) keepSessionAlive(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.closeCh:
+			return
+		case <-time.After(max(time.Until(c.getSession().ExpiresAt)/2, reconnectBackoffMax)):
+		}
+
+		// The expired session is started anew on the next dial.
+		session := c.getSession()
+		if session.IsExpired(time.Now()) {
+			continue
+		}
+
+		session, err := c.sessions.Refresh(ctx, session)
+		if err != nil {
+			c.logger.Errorf("failed to refresh the session: %s", err)
+			continue
+		}
+		c.setSession(session)
+	}
+}
+
+func (c *WebsocketClient) getSession() domain.SessionToken {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.session
+}
+
+func (c *WebsocketClient) setSession(session domain.SessionToken) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.session = session
+	c.metadata.SessionToken = session.Token
+	c.metadata.ClientID = session.ClientID
+	c.metadata.Nickname = session.Nickname
+}
+
+func (c *WebsocketClient) dial(ctx context.Context) (*websocket.Conn, error) {
 	dialer := websocket.Dialer{
 		HandshakeTimeout: 10 * time.Second,
 		ReadBufferSize:   events.ReadBufferBytesMax,
 		WriteBufferSize:  events.WriteBufferBytesMax,
+		TLSClientConfig:  c.transport.TLSConfig(c.ipv4),
+	}
+
+	if err := c.authenticate(ctx); err != nil {
+		return nil, err
 	}
 
 	const port = 8080
-	serverUrl := fmt.Sprintf("%s://%s:%d%s", websocketProtocol, ipv4.String(), 8080, websocketEndpoint)
+	serverUrl := fmt.Sprintf("%s://%s:%d%s", c.transport.WebsocketScheme(), c.ipv4.String(), port, websocketEndpoint)
 
-	conn, _, err := dialer.DialContext(ctx, serverUrl, domain.ParseClientMetadataToHeaders(c.metadata))
+	conn, resp, err := dialer.DialContext(ctx, serverUrl, domain.ParseClientMetadataToHeaders(c.Metadata()))
 	if err != nil {
-		return fmt.Errorf("failed to dial: %w", err)
+		// The server tells, why the connection was refused, like a full server.
+		var body struct {
+			Error string `json:"error"`
+		}
+		if resp != nil && json.NewDecoder(resp.Body).Decode(&body) == nil && body.Error != "" {
+			return nil, fmt.Errorf("server refused the connection: %s", body.Error)
+		}
+		return nil, fmt.Errorf("failed to dial: %w", err)
 	}
 
 	const pongTimeout = time.Second * 10
@@ -68,28 +226,79 @@ func (c *WebsocketClient) Connect(ctx context.Context, ipv4 net.IP) error {
 		return conn.WriteControl(websocket.PongMessage, []byte(appData), time.Now().Add(pongTimeout))
 	})
 	conn.SetPongHandler(func(appData string) error {
-		return c.conn.SetReadDeadline(time.Now().Add(pongTimeout))
+		return conn.SetReadDeadline(time.Now().Add(pongTimeout))
 	})
+
+	c.mu.Lock()
 	c.conn = conn
+	c.mu.Unlock()
 
-	c.wg.Add(2)
-	go func(wg *sync.WaitGroup, conn *websocket.Conn) {
-		defer wg.Done()
-		c.ReadMessages(c.ctx, conn)
-	}(&c.wg, conn)
+	return conn, nil
+}
 
-	go func(wg *sync.WaitGroup) {
-		defer wg.Done()
-		c.WriteMessages(c.ctx)
-	}(&c.wg)
+// reconnect dials the server again with the resume token. It returns nil, if the connection
+// couldn't be restored before the deadline.
+func (c *WebsocketClient) reconnect(ctx context.Context, deadline time.Time) *websocket.Conn {
+	if c.Metadata().ResumeToken == "" {
+		return nil
+	}
 
+	c.pushLocalEvent(clientEvents.NewConnectionLostEvent())
+
+	for backoff := reconnectBackoffMin; time.Now().Before(deadline); backoff = min(backoff*2, reconnectBackoffMax) {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.closeCh:
+			return nil
+		case <-time.After(backoff):
+		}
+
+		conn, err := c.dial(ctx)
+		if err != nil {
+			c.logger.Errorf("failed to reconnect to the server: %s", err)
+			continue
+		}
+
+		// The game is restored, as soon as the server sends the resumed session.
+		c.logger.Info("connection to the server is restored")
+		return conn
+	}
+
+	c.logger.Error("failed to reconnect to the server in time, giving up")
 	return nil
 }
 
+func (c *WebsocketClient) pushLocalEvent(event events.Event, err error) {
+	if err != nil {
+		c.logger.Error(err)
+		return
+	}
+	c.readCh <- event
+}
+
+func (c *WebsocketClient) setResumeToken(e events.Event) {
+	sessionEvent, err := events.CastTo[events.SessionEvent](e)
+	if err != nil {
+		c.logger.Errorf("failed to read a resume token: %s", err)
+		return
+	}
+
+	c.mu.Lock()
+	c.metadata.ResumeToken = sessionEvent.ResumeToken
+	c.mu.Unlock()
+}
+
+func (c *WebsocketClient) getConn() *websocket.Conn {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conn
+}
+
 func (c *WebsocketClient) Shutdown() error {
 	c.once.Do(func() {
 		close(c.closeCh)
-		if err := c.conn.Close(); err != nil {
+		if err := c.getConn().Close(); err != nil {
 			c.logger.Errorf("failed to close a websocket client: %s", err)
 		}
 	})
@@ -124,6 +333,12 @@ func (c *WebsocketClient) SendMessage(e events.Event) error {
 func (c *WebsocketClient) ReadMessages(ctx context.Context, conn *websocket.Conn) {
 	defer close(c.readCh)
 
+	// The deadline is kept across reconnects, until the server resumes the session.
+	var (
+		reconnectDeadline time.Time
+		isResuming        bool
+	)
+
 	for {
 		if err := ctx.Err(); err != nil {
 			c.logger.Info("client received a closing signal, stopping reading messages...")
@@ -142,20 +357,39 @@ func (c *WebsocketClient) ReadMessages(ctx context.Context, conn *websocket.Conn
 					return
 				}
 
+				select {
+				case <-c.closeCh:
+					c.logger.Info("client received a closing signal, stopping reading messages...")
+					return
+				default:
+				}
+
 				switch {
 				case websocket.IsUnexpectedCloseError(err,
 					websocket.CloseGoingAway,
 					websocket.CloseAbnormalClosure,
 					websocket.CloseNormalClosure):
 					c.logger.Errorf("failed to read a message: %s", err)
-					return
 				case websocket.IsUnexpectedCloseError(err, websocket.CloseMessage):
 					c.logger.Info("received a close signal from the server")
-					return
 				default:
 					c.logger.Errorf("unknown error while reading message: %s", err)
+				}
+
+				// The server closes the connection, if it can't resume the session anymore.
+				if isResuming {
+					c.logger.Error("server refused to resume the session, giving up")
 					return
 				}
+
+				if reconnectDeadline.IsZero() {
+					reconnectDeadline = time.Now().Add(reconnectTimeout)
+				}
+				if conn = c.reconnect(ctx, reconnectDeadline); conn == nil {
+					return
+				}
+				isResuming = true
+				continue
 			}
 
 			var event events.Event
@@ -164,6 +398,17 @@ func (c *WebsocketClient) ReadMessages(ctx context.Context, conn *websocket.Conn
 				continue
 			}
 
+			// The resume token is kept by the client, it's not a part of the game.
+			if event.Type == events.SessionEventType {
+				c.setResumeToken(event)
+				continue
+			}
+
+			if event.Type == events.SessionResumedEventType {
+				reconnectDeadline = time.Time{}
+				isResuming = false
+			}
+
 			c.readCh <- event
 		}
 	}
@@ -184,8 +429,9 @@ func (c *WebsocketClient) WriteMessages(ctx context.Context) {
 			c.logger.Info("client received a closing signal, stopping writing messages...")
 			return
 		case msg := <-c.writeCh:
-			_ = c.conn.SetWriteDeadline(time.Now().Add(time.Second * 5))
-			if err := c.conn.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+			conn := c.getConn()
+			_ = conn.SetWriteDeadline(time.Now().Add(time.Second * 5))
+			if err := conn.WriteMessage(websocket.BinaryMessage, msg); err != nil {
 				c.logger.Errorf("failed to send a message to client: %s", err)
 			}
 		}
@@ -7,6 +7,7 @@ import (
 const (
 	// Local client events. ONLY FOR INTERNAL USAGE! We don't need to send them to server.
 	PlayerTypedMessageType events.EventType = "player_typed_message"
+	ConnectionLostType     events.EventType = "connection_lost"
 )
 
 func NewPlayerTypedMessageEvent(sender string, message string) (events.Event, error) {
@@ -17,3 +18,7 @@ func NewPlayerTypedMessageEvent(sender string, message string) (events.Event, er
 	event.Type = PlayerTypedMessageType
 	return event, nil
 }
+
+func NewConnectionLostEvent() (events.Event, error) {
+	return events.NewEvent(ConnectionLostType, struct{}{})
+}
@@ -0,0 +1,289 @@
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+)
+
+const (
+	ComputerID       = "computer"
+	computerNickname = "Computer"
+)
+
+var (
+	ErrNotPlacing    = errors.New("fleet placement is over")
+	ErrAlreadyReady  = errors.New("your fleet is already placed")
+	ErrNotStarted    = errors.New("game isn't started yet")
+	ErrMatchIsOver   = errors.New("game is over")
+	ErrNotYourTurn   = errors.New("it's not your turn")
+	ErrInvalidTarget = domain.ErrInvalidTarget
+	ErrInvalidSalvo  = domain.ErrInvalidSalvo
+)
+
+// Game runs the rules of the match against the computer locally. It takes events of the player,
+// as the server would do, and answers with the events the server would send back.
+type Game struct {
+	rules domain.GameRules
+	rng   domain.RNG
+	seed  int64
+	// strategy picks shots of the computer, as it does for bots on the server.
+	strategy domain.BotStrategy
+
+	playerID string
+	players  map[string]*domain.PlayerModel
+	// revealed holds cells, which were fired at or revealed around sunk ships, by IDs of players.
+	revealed        map[string][]domain.Coordinate
+	readyPlayers    map[string]bool
+	turnCount       int
+	turningPlayerID string
+	isStarted       bool
+	isEnded         bool
+}
+
+func NewGame(metadata domain.ClientMetadata, rules domain.GameRules, seed int64) *Game {
+	rng := domain.NewRNG(seed)
+
+	player := domain.NewPlayerModel(domain.RandomizeBoardWithRNG(rng, rules), metadata)
+	computer := domain.NewPlayerModel(domain.RandomizeBoardWithRNG(rng, rules), domain.ClientMetadata{
+		ClientID: ComputerID,
+		Nickname: computerNickname,
+	})
+
+	return &Game{
+		rules:        rules,
+		rng:          rng,
+		seed:         seed,
+		strategy:     domain.HuntTargetStrategy{},
+		playerID:     player.ID,
+		players:      map[string]*domain.PlayerModel{player.ID: player, computer.ID: computer},
+		revealed:     make(map[string][]domain.Coordinate, 2),
+		readyPlayers: make(map[string]bool, 2),
+	}
+}
+
+// Start begins the fleet placement. The computer keeps the board it got right away.
+func (g *Game) Start() ([]events.Event, error) {
+	var answer answer
+	answer.add(events.NewPlayerUpdateStateEvent(g.buildGameState()))
+	answer.add(events.NewPlacementStartEvent(g.rules, 0))
+	answer.notify("Place your fleet!", events.RoomNotificationType)
+
+	g.readyPlayers[ComputerID] = true
+	answer.add(events.NewPlayerReadyEvent(ComputerID))
+	answer.notify(fmt.Sprintf("Player '%s' is ready.", computerNickname), events.RoomNotificationType)
+	return answer.events, answer.err
+}
+
+// Handle applies the event sent by the player.
+func (g *Game) Handle(e events.Event) ([]events.Event, error) {
+	var answer answer
+
+	switch e.Type {
+	case events.PlaceFleetEventType:
+		placeFleetEvent, err := events.CastTo[events.PlaceFleetEvent](e)
+		if err != nil {
+			return nil, err
+		}
+		g.placeFleet(&answer, placeFleetEvent.Ships)
+
+	case events.RerollFleetEventType:
+		g.rerollFleet(&answer)
+
+	case events.PlayerFireEventType:
+		playerFireEvent, err := events.CastTo[events.PlayerFireEvent](e)
+		if err != nil {
+			return nil, err
+		}
+		g.fire(&answer, g.playerID, playerFireEvent.TargetPlayerID, playerFireEvent.Targets())
+
+	case events.SendMessageType:
+		sendMessageEvent, err := events.CastTo[events.SendMessageEvent](e)
+		if err != nil {
+			return nil, err
+		}
+		answer.add(events.NewSendMessageEvent(g.players[g.playerID].Nickname, sendMessageEvent.Message))
+	}
+	return answer.events, answer.err
+}
+
+// Step makes the turn of the computer. It reports false, if the computer doesn't turn now.
+func (g *Game) Step() ([]events.Event, bool, error) {
+	if !g.isStarted || g.isEnded || g.turningPlayerID != ComputerID {
+		return nil, false, nil
+	}
+
+	target := g.players[g.playerID]
+	shots := g.strategy.NextShots(g.rng, g.maskBoard(target), g.rules.AfloatShips(target), g.rules.ShotsPerTurn(g.players[ComputerID]))
+
+	var answer answer
+	g.fire(&answer, ComputerID, target.ID, shots)
+	return answer.events, true, answer.err
+}
+
+func (g *Game) placeFleet(answer *answer, ships []domain.ShipPlacement) {
+	switch {
+	case g.isStarted:
+		answer.notify(ErrNotPlacing.Error(), events.GameNotificationType)
+		return
+	case g.readyPlayers[g.playerID]:
+		answer.notify(ErrAlreadyReady.Error(), events.GameNotificationType)
+		return
+	}
+
+	board, err := domain.BuildBoard(ships, g.rules)
+	if err != nil {
+		answer.notify(fmt.Sprintf("Invalid fleet layout: %s.", err), events.GameNotificationType)
+		return
+	}
+
+	player := g.players[g.playerID]
+	player.SetBoard(board)
+	g.readyPlayers[g.playerID] = true
+
+	answer.add(events.NewPlayerUpdateStateEvent(g.buildGameState()))
+	answer.add(events.NewPlayerReadyEvent(g.playerID))
+	answer.notify(fmt.Sprintf("Player '%s' is ready.", player.Nickname), events.RoomNotificationType)
+
+	g.isStarted = true
+	answer.add(events.NewGameStartEvent(g.seed))
+	answer.notify(fmt.Sprintf("Game started! Seed: %d.", g.seed), events.RoomNotificationType)
+
+	playerIDs := []string{g.playerID, ComputerID}
+	g.giveTurnToPlayer(answer, playerIDs[g.rng.Intn(len(playerIDs))])
+}
+
+func (g *Game) rerollFleet(answer *answer) {
+	switch {
+	case g.isStarted:
+		answer.notify(ErrNotPlacing.Error(), events.GameNotificationType)
+		return
+	case g.readyPlayers[g.playerID]:
+		answer.notify(ErrAlreadyReady.Error(), events.GameNotificationType)
+		return
+	}
+
+	g.players[g.playerID].SetBoard(domain.RandomizeBoardWithRNG(g.rng, g.rules))
+	answer.add(events.NewPlayerUpdateStateEvent(g.buildGameState()))
+}
+
+func (g *Game) fire(answer *answer, firingPlayerID, targetPlayerID string, targets []domain.Coordinate) {
+	firingPlayer := g.players[firingPlayerID]
+	targetPlayer, found := g.players[targetPlayerID]
+
+	var err error
+	switch {
+	case !g.isStarted:
+		err = ErrNotStarted
+	case g.isEnded:
+		err = ErrMatchIsOver
+	case g.turningPlayerID != firingPlayerID:
+		err = ErrNotYourTurn
+	case !found || targetPlayer.Equal(firingPlayer):
+		err = ErrInvalidTarget
+	default:
+		err = g.rules.CheckShots(firingPlayer, targetPlayer, targets)
+	}
+	if err != nil {
+		answer.notify(err.Error(), events.GameNotificationType)
+		return
+	}
+
+	salvo, err := targetPlayer.TakeSalvo(targets)
+	if err != nil {
+		answer.notify(err.Error(), events.GameNotificationType)
+		return
+	}
+	g.revealed[targetPlayer.ID] = append(g.revealed[targetPlayer.ID], targets...)
+	answer.notify(salvo.Describe(firingPlayer.Nickname), events.GameNotificationType)
+
+	for _, sunkShip := range salvo.SunkShips {
+		// There can't be any ship next to the sunk one, so its surroundings are known to be water.
+		g.revealed[targetPlayer.ID] = append(g.revealed[targetPlayer.ID], sunkShip.Surroundings(&targetPlayer.Board)...)
+
+		answer.add(events.NewShipSunkEvent(firingPlayer.ID, targetPlayer.ID, sunkShip))
+		answer.notify(fmt.Sprintf("Player '%s' sunk a %d-deck ship of player '%s'!", firingPlayer.Nickname, sunkShip.Length, targetPlayer.Nickname), events.GameNotificationType)
+	}
+
+	if targetPlayer.IsDead() {
+		g.isEnded = true
+		targetPlayer.IsEliminated = true
+	}
+
+	answer.add(events.NewPlayerUpdateStateEvent(g.buildGameState()))
+
+	if g.isEnded {
+		answer.add(events.NewGameEndEvent(firingPlayer))
+		answer.notify(fmt.Sprintf("Player '%s' has won!", firingPlayer.Nickname), events.RoomNotificationType)
+		return
+	}
+
+	if g.rules.KeepsTurn(salvo) {
+		g.continueTurn(answer)
+		return
+	}
+	g.giveTurnToPlayer(answer, targetPlayer.ID)
+}
+
+func (g *Game) giveTurnToPlayer(answer *answer, playerID string) {
+	g.turnCount++
+	g.turningPlayerID = playerID
+
+	player := g.players[playerID]
+	answer.add(events.NewPlayerTurnEvent(g.turnCount, playerID, 0, false, g.rules.ShotsPerTurn(player)))
+	answer.notify(fmt.Sprintf("Player '%s' turns now.", player.Nickname), events.GameNotificationType)
+}
+
+// continueTurn lets the player, who has hit, fire once more.
+func (g *Game) continueTurn(answer *answer) {
+	g.turnCount++
+
+	player := g.players[g.turningPlayerID]
+	answer.add(events.NewPlayerTurnEvent(g.turnCount, player.ID, 0, true, g.rules.ShotsPerTurn(player)))
+	answer.notify(fmt.Sprintf("Player '%s' hit and fires again.", player.Nickname), events.GameNotificationType)
+}
+
+// buildGameState masks the board of the computer. The game ends with the first sunk fleet, so
+// then the board is revealed.
+func (g *Game) buildGameState() *events.GameState {
+	models := make([]*domain.PlayerModel, 0, len(g.players))
+	for _, player := range g.players {
+		player := *player
+		player.Board = player.Board.Clone()
+		models = append(models, &player)
+	}
+
+	if g.isEnded {
+		return events.NewRevealedGameState(g.turnCount, models...)
+	}
+
+	gameState := events.NewGameState(g.turnCount, len(models))
+	for _, player := range models {
+		gameState.Players[player.ID] = events.NewPlayerStateFor(g.players[g.playerID], player, g.maskBoard(player))
+	}
+	return gameState
+}
+
+// maskBoard hides cells of the player, which the opponent hasn't revealed yet.
+func (g *Game) maskBoard(player *domain.PlayerModel) domain.Board {
+	return player.Board.Mask(g.revealed[player.ID])
+}
+
+// answer collects events, which are sent back to the player, and the first error met.
+type answer struct {
+	events []events.Event
+	err    error
+}
+
+func (a *answer) add(e events.Event, err error) {
+	if err != nil {
+		a.err = errors.Join(a.err, err)
+		return
+	}
+	a.events = append(a.events, e)
+}
+
+func (a *answer) notify(msg string, notificationType events.ChatMessageType) {
+	a.add(events.NewChatNotificationEvent(msg, notificationType))
+}
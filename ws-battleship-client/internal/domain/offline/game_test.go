@@ -0,0 +1,164 @@
+package offline
+
+import (
+	"slices"
+	"testing"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStartedGame(t *testing.T) (*Game, []events.Event) {
+	t.Helper()
+
+	game := NewGame(domain.ClientMetadata{ClientID: "player", Nickname: "You"}, domain.DefaultRules(), 1)
+	_, err := game.Start()
+	require.NoError(t, err)
+
+	placeFleetEvent, err := events.NewPlaceFleetEvent(events.PlaceFleetCommandArgs{
+		PlayerID: "player",
+		Ships:    domain.ExtractShips(game.players["player"].Board),
+	})
+	require.NoError(t, err)
+
+	answer, err := game.Handle(placeFleetEvent)
+	require.NoError(t, err)
+	return game, answer
+}
+
+func findEvent(answer []events.Event, eventType events.EventType) (events.Event, bool) {
+	for _, e := range answer {
+		if e.Type == eventType {
+			return e, true
+		}
+	}
+	return events.Event{}, false
+}
+
+func TestGameStart(t *testing.T) {
+	// 1. Act
+	game, answer := newStartedGame(t)
+
+	// 2. Assert
+	_, isStarted := findEvent(answer, events.GameStartEventType)
+	require.True(t, isStarted)
+
+	e, found := findEvent(answer, events.PlayerUpdateStateEventType)
+	require.True(t, found)
+	updateStateEvent, err := events.CastTo[events.PlayerUpdateStateEvent](e)
+	require.NoError(t, err)
+
+	computerBoard := updateStateEvent.GameState.Players[ComputerID].Board
+	for y := range computerBoard {
+		for x := range computerBoard[y] {
+			require.NotEqual(t, domain.Ship, computerBoard.GetCellType(byte(x), byte(y)))
+		}
+	}
+	require.Equal(t, game.players["player"].Board, updateStateEvent.GameState.Players["player"].Board)
+}
+
+func TestGameFire(t *testing.T) {
+	t.Run("player can't fire out of turn", func(t *testing.T) {
+		// 1. Arrange
+		game, _ := newStartedGame(t)
+		game.turningPlayerID = ComputerID
+		fireEvent, err := events.NewPlayerFireEvent(events.FireCommandArgs{FiringPlayerID: "player", TargetPlayerID: ComputerID})
+		require.NoError(t, err)
+
+		// 2. Act
+		answer, err := game.Handle(fireEvent)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Len(t, answer, 1)
+		sendMessageEvent, err := events.CastTo[events.SendMessageEvent](answer[0])
+		require.NoError(t, err)
+		require.Equal(t, ErrNotYourTurn.Error(), sendMessageEvent.Message)
+	})
+
+	t.Run("game goes on until a fleet is sunk", func(t *testing.T) {
+		// 1. Arrange
+		game, _ := newStartedGame(t)
+
+		// 2. Act
+		var gameEndEvent events.Event
+		for turn := 0; turn < 2*domain.DefaultBoardSize*domain.DefaultBoardSize; turn++ {
+			answer, ok, err := game.Step()
+			require.NoError(t, err)
+
+			if !ok {
+				shots := domain.RandomStrategy{}.NextShots(game.rng, game.maskBoard(game.players[ComputerID]), nil, 1)
+				fireEvent, err := events.NewPlayerFireEvent(events.FireCommandArgs{
+					FiringPlayerID: "player",
+					TargetPlayerID: ComputerID,
+					Shots:          shots,
+				})
+				require.NoError(t, err)
+
+				answer, err = game.Handle(fireEvent)
+				require.NoError(t, err)
+			}
+
+			if e, found := findEvent(answer, events.GameEndEventType); found {
+				gameEndEvent = e
+				break
+			}
+		}
+
+		// 3. Assert
+		require.Equal(t, events.GameEndEventType, gameEndEvent.Type)
+		event, err := events.CastTo[events.GameEndEvent](gameEndEvent)
+		require.NoError(t, err)
+		require.True(t, game.players[event.WinningPlayer.ID].ShipCells > 0)
+
+		_, ok, err := game.Step()
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+	t.Run("hit keeps the turn, when the rule is on", func(t *testing.T) {
+		// 1. Arrange
+		game, _ := newStartedGame(t)
+		game.rules.HitGrantsShot = true
+		game.turningPlayerID = "player"
+
+		ship := game.players[ComputerID].Ships[0].Cells[0]
+		fireEvent, err := events.NewPlayerFireEvent(events.FireCommandArgs{FiringPlayerID: "player", TargetPlayerID: ComputerID, CellX: ship.X, CellY: ship.Y})
+		require.NoError(t, err)
+
+		// 2. Act
+		answer, err := game.Handle(fireEvent)
+
+		// 3. Assert
+		require.NoError(t, err)
+		e, found := findEvent(answer, events.PlayerTurnEventType)
+		require.True(t, found)
+		playerTurnEvent, err := events.CastTo[events.PlayerTurnEvent](e)
+		require.NoError(t, err)
+		require.Equal(t, "player", playerTurnEvent.TurningPlayerID)
+		require.True(t, playerTurnEvent.IsContinuation)
+	})
+
+	t.Run("surroundings of the sunk ship are revealed", func(t *testing.T) {
+		// 1. Arrange
+		game, _ := newStartedGame(t)
+		computer := game.players[ComputerID]
+		ship := computer.Ships[slices.IndexFunc(computer.Ships, func(ship *domain.ShipModel) bool {
+			return ship.Length == 1
+		})]
+
+		// 2. Act
+		game.turningPlayerID = "player"
+		fireEvent, err := events.NewPlayerFireEvent(events.FireCommandArgs{FiringPlayerID: "player", TargetPlayerID: ComputerID, CellX: ship.Cells[0].X, CellY: ship.Cells[0].Y})
+		require.NoError(t, err)
+		_, err = game.Handle(fireEvent)
+		require.NoError(t, err)
+
+		// 3. Assert
+		maskedBoard := game.maskBoard(computer)
+		require.Equal(t, domain.Sunk, maskedBoard.GetCellType(ship.Cells[0].X, ship.Cells[0].Y))
+		for _, cell := range ship.Surroundings(&computer.Board) {
+			require.Equal(t, domain.Miss, maskedBoard.GetCellType(cell.X, cell.Y))
+		}
+	})
+}
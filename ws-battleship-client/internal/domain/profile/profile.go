@@ -0,0 +1,114 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"ws-battleship-shared/domain"
+
+	"github.com/google/uuid"
+)
+
+// nicknameIDLength is enough to tell default nicknames apart.
+const nicknameIDLength = 4
+
+// Profile keeps the nickname of the player and credentials of profiles, which servers made for
+// the player. Each server keeps statistics by its own profile. The nickname may be changed in
+// the file.
+type Profile struct {
+	mu   sync.RWMutex
+	path string
+
+	Nickname    string
+	credentials map[string]domain.ProfileCredential
+}
+
+// profileFile is the profile, as it's saved.
+type profileFile struct {
+	Nickname    string                              `json:"nickname"`
+	Credentials map[string]domain.ProfileCredential `json:"credentials,omitempty"`
+}
+
+// Load reads the profile from the file. A new profile is created and saved on the first launch.
+func Load(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return create(path)
+	case err != nil:
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	var file profileFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+
+	if file.Nickname == "" {
+		file.Nickname = defaultNickname()
+	}
+	if file.Credentials == nil {
+		file.Credentials = make(map[string]domain.ProfileCredential)
+	}
+
+	return &Profile{
+		path:        path,
+		Nickname:    file.Nickname,
+		credentials: file.Credentials,
+	}, nil
+}
+
+func create(path string) (*Profile, error) {
+	profile := &Profile{
+		path:        path,
+		Nickname:    defaultNickname(),
+		credentials: make(map[string]domain.ProfileCredential),
+	}
+
+	if err := profile.save(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Credential returns the profile, which the server made for the player.
+func (p *Profile) Credential(server string) (domain.ProfileCredential, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	credential, found := p.credentials[server]
+	return credential, found
+}
+
+// SetCredential saves the profile, which the server has just made. The server gives its secret
+// out once, so it's lost, if it isn't saved.
+func (p *Profile) SetCredential(server string, credential domain.ProfileCredential) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.credentials[server] = credential
+	return p.save()
+}
+
+func (p *Profile) save() error {
+	data, err := json.MarshalIndent(profileFile{Nickname: p.Nickname, Credentials: p.credentials}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
+	if err := os.WriteFile(p.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save profile: %w", err)
+	}
+	return nil
+}
+
+func defaultNickname() string {
+	return "Player-" + uuid.New().String()[:nicknameIDLength]
+}
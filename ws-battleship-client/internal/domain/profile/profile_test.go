@@ -0,0 +1,64 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"ws-battleship-shared/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("profile is created on the first launch and kept", func(t *testing.T) {
+		// 1. Arrange
+		path := filepath.Join(t.TempDir(), "profile.json")
+
+		// 2. Act
+		created, err := Load(path)
+		require.NoError(t, err)
+		loaded, err := Load(path)
+		require.NoError(t, err)
+
+		// 3. Assert
+		require.NotEmpty(t, created.Nickname)
+		require.Equal(t, created.Nickname, loaded.Nickname)
+	})
+
+	t.Run("nickname is changed in the file", func(t *testing.T) {
+		// 1. Arrange
+		path := filepath.Join(t.TempDir(), "profile.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"nickname":"captain"}`), 0o600))
+
+		// 2. Act
+		got, err := Load(path)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Equal(t, "captain", got.Nickname)
+	})
+}
+
+func TestCredential(t *testing.T) {
+	t.Run("credential is kept per server", func(t *testing.T) {
+		// 1. Arrange
+		path := filepath.Join(t.TempDir(), "profile.json")
+		profile, err := Load(path)
+		require.NoError(t, err)
+		credential := domain.ProfileCredential{ID: "1", Secret: "secret"}
+
+		// 2. Act
+		err = profile.SetCredential("127.0.0.1", credential)
+		require.NoError(t, err)
+		loaded, err := Load(path)
+		require.NoError(t, err)
+
+		// 3. Assert
+		got, found := loaded.Credential("127.0.0.1")
+		require.True(t, found)
+		require.Equal(t, credential, got)
+
+		_, found = loaded.Credential("10.0.0.1")
+		require.False(t, found)
+	})
+}
@@ -0,0 +1,142 @@
+package replay
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+)
+
+// OmniscientView shows the match with all boards unmasked.
+const OmniscientView = events.JournalOmniscient
+
+// Replay plays the journal of the match back turn by turn from the side of a single player or
+// from the omniscient one.
+type Replay struct {
+	entries []events.JournalEntry
+	players []*domain.PlayerModel
+	viewID  string
+	cursor  int
+	turn    int
+	turns   int
+}
+
+func Open(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay: %w", err)
+	}
+	defer file.Close()
+
+	entries, err := events.ReadJournal(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplay(entries), nil
+}
+
+func NewReplay(entries []events.JournalEntry) *Replay {
+	r := &Replay{
+		entries: entries,
+		viewID:  OmniscientView,
+	}
+
+	players := make(map[string]*domain.PlayerModel)
+	for _, entry := range entries {
+		switch entry.Event.Type {
+		case events.PlayerTurnEventType:
+			r.turns++
+
+		// Players, who left the match early, are missing in the latest states.
+		case events.PlayerUpdateStateEventType:
+			if entry.RecipientID != events.JournalOmniscient {
+				continue
+			}
+
+			updateStateEvent, err := events.CastTo[events.PlayerUpdateStateEvent](entry.Event)
+			if err != nil || updateStateEvent.GameState == nil {
+				continue
+			}
+			maps.Copy(players, updateStateEvent.GameState.Model().Players)
+		}
+	}
+
+	r.players = slices.SortedFunc(maps.Values(players), (*domain.PlayerModel).Compare)
+	return r
+}
+
+func (r *Replay) Players() []*domain.PlayerModel {
+	return r.players
+}
+
+// Views returns the omniscient view followed by views of all players.
+func (r *Replay) Views() []string {
+	views := []string{OmniscientView}
+	for _, player := range r.players {
+		views = append(views, player.ID)
+	}
+	return views
+}
+
+func (r *Replay) ViewID() string {
+	return r.viewID
+}
+
+// SetView switches the side, from which the match is watched. The replay has to be seeked
+// afterwards, since the events already seen differ.
+func (r *Replay) SetView(viewID string) {
+	r.viewID = viewID
+}
+
+// Turn returns the number of turns played so far.
+func (r *Replay) Turn() int {
+	return r.turn
+}
+
+func (r *Replay) TurnsCount() int {
+	return r.turns
+}
+
+func (r *Replay) IsOver() bool {
+	return r.cursor >= len(r.entries)
+}
+
+// NextTurn returns events till the start of the next turn including it. After the last turn
+// the rest of the journal is returned.
+func (r *Replay) NextTurn() []events.Event {
+	var turnEvents []events.Event
+	for ; r.cursor < len(r.entries); r.cursor++ {
+		entry := r.entries[r.cursor]
+		if r.isVisible(entry) {
+			turnEvents = append(turnEvents, entry.Event)
+		}
+
+		if entry.Event.Type == events.PlayerTurnEventType {
+			r.cursor++
+			r.turn++
+			break
+		}
+	}
+	return turnEvents
+}
+
+// Seek rewinds the replay to the given turn and returns all events, which lead to it. Seeking
+// past the last turn plays the journal till the end.
+func (r *Replay) Seek(turn int) []events.Event {
+	r.cursor = 0
+	r.turn = 0
+
+	var seekEvents []events.Event
+	for r.turn < turn && !r.IsOver() {
+		seekEvents = append(seekEvents, r.NextTurn()...)
+	}
+	return seekEvents
+}
+
+// isVisible reports whether the event was received from the current side. The omniscient view
+// gets the unmasked state of the match instead of the masked ones sent to players.
+func (r *Replay) isVisible(entry events.JournalEntry) bool {
+	return entry.RecipientID == events.JournalBroadcast || entry.RecipientID == r.viewID
+}
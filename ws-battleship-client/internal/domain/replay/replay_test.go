@@ -0,0 +1,125 @@
+package replay
+
+import (
+	"testing"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestJournal(t *testing.T) []events.JournalEntry {
+	t.Helper()
+
+	must := func(e events.Event, err error) events.Event {
+		require.NoError(t, err)
+		return e
+	}
+
+	players := map[string]*domain.PlayerModel{
+		"b": domain.NewPlayerModel(domain.NewBoard(domain.DefaultBoardSize), domain.ClientMetadata{ClientID: "b"}),
+		"a": domain.NewPlayerModel(domain.NewBoard(domain.DefaultBoardSize), domain.ClientMetadata{ClientID: "a"}),
+	}
+
+	gameState := events.NewRevealedGameState(0, players["a"], players["b"])
+
+	updateStateEvent := must(events.NewPlayerUpdateStateEvent(gameState))
+
+	return []events.JournalEntry{
+		{RecipientID: "a", Event: updateStateEvent},
+		{RecipientID: "b", Event: updateStateEvent},
+		{RecipientID: events.JournalOmniscient, Event: updateStateEvent},
+		{RecipientID: events.JournalBroadcast, Event: must(events.NewGameStartEvent(1))},
+		{RecipientID: events.JournalBroadcast, Event: must(events.NewPlayerTurnEvent(1, "a", 0, false, 1))},
+		{RecipientID: events.JournalOmniscient, Event: must(events.NewPlayerFireEvent(events.FireCommandArgs{FiringPlayerID: "a", TargetPlayerID: "b"}))},
+		{RecipientID: events.JournalBroadcast, Event: must(events.NewPlayerTurnEvent(2, "b", 0, false, 1))},
+		{RecipientID: events.JournalBroadcast, Event: must(events.NewGameEndEvent(players["b"]))},
+	}
+}
+
+func TestReplayPlayers(t *testing.T) {
+	// 1. Arrange
+	replay := NewReplay(newTestJournal(t))
+
+	// 2. Act
+	views := replay.Views()
+
+	// 3. Assert
+	require.Equal(t, []string{OmniscientView, "a", "b"}, views)
+	require.Equal(t, 2, replay.TurnsCount())
+}
+
+func TestReplayNextTurn(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		viewID   string
+		expected []events.EventType
+	}{
+		{
+			name:   "omniscient view gets the unmasked state and shots",
+			viewID: OmniscientView,
+			expected: []events.EventType{
+				events.PlayerUpdateStateEventType,
+				events.GameStartEventType,
+				events.PlayerTurnEventType,
+				events.PlayerFireEventType,
+				events.PlayerTurnEventType,
+			},
+		},
+		{
+			name:   "player view gets only events sent to the player",
+			viewID: "b",
+			expected: []events.EventType{
+				events.PlayerUpdateStateEventType,
+				events.GameStartEventType,
+				events.PlayerTurnEventType,
+				events.PlayerTurnEventType,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			replay := NewReplay(newTestJournal(t))
+			replay.SetView(tt.viewID)
+
+			// 2. Act
+			got := append(replay.NextTurn(), replay.NextTurn()...)
+
+			// 3. Assert
+			types := make([]events.EventType, 0, len(got))
+			for _, e := range got {
+				types = append(types, e.Type)
+			}
+			require.Equal(t, tt.expected, types)
+			require.Equal(t, 2, replay.Turn())
+			require.False(t, replay.IsOver())
+		})
+	}
+}
+
+func TestReplaySeek(t *testing.T) {
+	t.Run("seek back to the first turn", func(t *testing.T) {
+		// 1. Arrange
+		replay := NewReplay(newTestJournal(t))
+		replay.Seek(2)
+
+		// 2. Act
+		got := replay.Seek(1)
+
+		// 3. Assert
+		require.Len(t, got, 3)
+		require.Equal(t, 1, replay.Turn())
+	})
+
+	t.Run("seek past the last turn plays the journal till the end", func(t *testing.T) {
+		// 1. Arrange
+		replay := NewReplay(newTestJournal(t))
+
+		// 2. Act
+		got := replay.Seek(replay.TurnsCount() + 1)
+
+		// 3. Assert
+		require.Equal(t, events.GameEndEventType, got[len(got)-1].Type)
+		require.True(t, replay.IsOver())
+	})
+}
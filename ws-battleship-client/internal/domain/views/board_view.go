@@ -1,6 +1,7 @@
 package views
 
 import (
+	"slices"
 	"strconv"
 	"strings"
 	"ws-battleship-shared/domain"
@@ -22,6 +23,12 @@ var (
 	highlightForbiddenCell = lipgloss.NewStyle().
 				Background(lipgloss.Color("#B83921")).
 				Foreground(lipgloss.Color("#ffffff")).Bold(true)
+
+	sunkShipStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#B83921")).Bold(true)
+
+	markedCellStyle = lipgloss.NewStyle().
+			Background(lipgloss.Color("#D9A21B")).
+			Foreground(lipgloss.Color("#ffffff")).Bold(true)
 )
 
 type BoardView struct {
@@ -34,23 +41,19 @@ type BoardView struct {
 	board          domain.Board
 	alphabet       string
 	isSelectable   bool
-}
 
-func NewBoardView() *BoardView {
-	var emptyBoard domain.Board
+	previewCells     []domain.Coordinate
+	isPreviewAllowed bool
 
-	alphabet := make([]rune, 0, emptyBoard.Size()*2)
-	for i, r := range emptyBoard.Alphabet() {
-		alphabet = append(alphabet, r)
+	markedCells []domain.Coordinate
+}
 
-		if i < len(emptyBoard.Alphabet())-1 {
-			alphabet = append(alphabet, ' ')
-		}
-	}
+func NewBoardView() *BoardView {
+	emptyBoard := domain.NewBoard(domain.DefaultBoardSize)
 	return &BoardView{
 		nickname: "Unknown",
 		board:    emptyBoard,
-		alphabet: string(alphabet),
+		alphabet: renderAlphabet(emptyBoard),
 	}
 }
 
@@ -117,8 +120,25 @@ func (v *BoardView) SetPlayer(player *domain.PlayerModel) {
 	}
 
 	v.playerID = player.ID
-	v.board = player.Board
 	v.nickname = player.Nickname
+	v.SetBoard(player.Board)
+}
+
+func (v *BoardView) SetBoard(board domain.Board) {
+	isResized := v.board.Size() != board.Size()
+	v.board = board
+
+	if isResized {
+		v.alphabet = renderAlphabet(board)
+		v.SelectCell(v.cellX, v.cellY)
+	}
+}
+
+// SetPreview highlights the given cells regardless of the selection. It's used to show where
+// a ship is going to be placed.
+func (v *BoardView) SetPreview(cells []domain.Coordinate, isAllowed bool) {
+	v.previewCells = cells
+	v.isPreviewAllowed = isAllowed
 }
 
 func (v *BoardView) SetSelectable(isSelectable bool) {
@@ -137,19 +157,113 @@ func (v *BoardView) SelectCell(cellX, cellY int) {
 	v.selectedColIdx = math.Clamp(cellX*2, 0, len(v.alphabet)-1)
 }
 
+// ToggleMark marks the selected cell as a salvo target or removes the mark from it.
+// It returns false if the cell can't be fired at.
+func (v *BoardView) ToggleMark() bool {
+	cell := domain.Coordinate{X: byte(v.cellX), Y: byte(v.cellY)}
+	if idx := slices.Index(v.markedCells, cell); idx >= 0 {
+		v.markedCells = slices.Delete(v.markedCells, idx, idx+1)
+		return true
+	}
+
+	if !v.IsAllowedToFire() {
+		return false
+	}
+
+	v.markedCells = append(v.markedCells, cell)
+	return true
+}
+
+func (v *BoardView) MarkedCells() []domain.Coordinate {
+	return slices.Clone(v.markedCells)
+}
+
+func (v *BoardView) ClearMarks() {
+	v.markedCells = nil
+}
+
+// FireableCells returns the number of cells which haven't been fired at yet.
+func (v *BoardView) FireableCells() int {
+	var count int
+	for y := 0; y < v.board.Size(); y++ {
+		for x := 0; x < v.board.Size(); x++ {
+			if v.board.IsCellEmpty(byte(x), byte(y)) || v.board.GetCellType(byte(x), byte(y)) == domain.Ship {
+				count++
+			}
+		}
+	}
+	return count
+}
+
 func (v *BoardView) IsAllowedToFire() bool {
 	return v.board.IsCellEmpty(byte(v.cellX), byte(v.cellY)) || v.board.GetCellType(byte(v.cellX), byte(v.cellY)) == domain.Ship
 }
 
 func (v *BoardView) renderBoardRow(str string, currentRowIdx int) string {
-	if !v.isSelectable {
+	runes := []rune(str)
+	styles := make(map[int]lipgloss.Style, len(runes))
+
+	for i, r := range runes {
+		if r == domain.Sunk {
+			styles[i] = sunkShipStyle
+		}
+	}
+
+	switch {
+	case len(v.previewCells) > 0:
+		v.applyPreviewStyles(styles, currentRowIdx)
+	case v.isSelectable:
+		v.applySelectionStyles(styles, len(runes), currentRowIdx)
+		v.applyMarkedStyles(styles, currentRowIdx)
+	}
+
+	if len(styles) == 0 {
 		return str
 	}
 
-	if v.selectedRowIdx == currentRowIdx {
-		return lipgloss.StyleRunes(str, []int{v.selectedColIdx}, v.getCellHighlighStyle(), highlightStyle)
+	var row strings.Builder
+	for i, r := range runes {
+		if style, found := styles[i]; found {
+			row.WriteString(style.Render(string(r)))
+		} else {
+			row.WriteRune(r)
+		}
+	}
+	return row.String()
+}
+
+func (v *BoardView) applySelectionStyles(styles map[int]lipgloss.Style, rowLen int, currentRowIdx int) {
+	if v.selectedRowIdx != currentRowIdx {
+		styles[v.selectedColIdx] = highlightStyle
+		return
+	}
+
+	for i := 0; i < rowLen; i++ {
+		styles[i] = highlightStyle
+	}
+	styles[v.selectedColIdx] = v.getCellHighlighStyle()
+}
+
+func (v *BoardView) applyPreviewStyles(styles map[int]lipgloss.Style, currentRowIdx int) {
+	style := highlightForbiddenCell
+	if v.isPreviewAllowed {
+		style = highlightAllowedCell
+	}
+
+	for _, cell := range v.previewCells {
+		if int(cell.Y) == currentRowIdx && int(cell.X) < v.board.Size() {
+			styles[int(cell.X)*2] = style
+		}
+	}
+}
+
+func (v *BoardView) applyMarkedStyles(styles map[int]lipgloss.Style, currentRowIdx int) {
+	for _, cell := range v.markedCells {
+		isSelected := int(cell.X) == v.cellX && int(cell.Y) == v.cellY
+		if int(cell.Y) == currentRowIdx && !isSelected {
+			styles[int(cell.X)*2] = markedCellStyle
+		}
 	}
-	return lipgloss.StyleRunes(str, []int{v.selectedColIdx}, highlightStyle, defaultText)
 }
 
 func (v *BoardView) getCellHighlighStyle() lipgloss.Style {
@@ -160,6 +274,20 @@ func (v *BoardView) getCellHighlighStyle() lipgloss.Style {
 	}
 }
 
+func renderAlphabet(board domain.Board) string {
+	letters := board.Alphabet()
+
+	alphabet := make([]rune, 0, len(letters)*2)
+	for i, r := range letters {
+		alphabet = append(alphabet, r)
+
+		if i < len(letters)-1 {
+			alphabet = append(alphabet, ' ')
+		}
+	}
+	return string(alphabet)
+}
+
 func (v *BoardView) selectionUp() {
 	v.SelectCell(v.cellX, v.cellY-1)
 }
@@ -221,9 +221,8 @@ func TestIsAllowedToFire(t *testing.T) {
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			// 1. Arrange
-			var board = domain.Board{
-				{domain.Miss, domain.Ship, domain.Dead, domain.Empty, 0},
-			}
+			board := domain.NewBoard(domain.DefaultBoardSize)
+			copy(board[0], []domain.Cell{domain.Miss, domain.Ship, domain.Dead, domain.Empty, 0})
 			view := NewBoardView()
 			view.board = board
 			view.SelectCell(tt.cellX, 0)
@@ -294,3 +293,37 @@ func assertSelectionCoordinates(t *testing.T, view *BoardView, expectedY, expect
 	require.Equal(t, view.cellY, view.selectedRowIdx)
 	require.Equal(t, view.cellX*2, view.selectedColIdx)
 }
+
+func TestToggleMark(t *testing.T) {
+	t.Run("mark and unmark a cell", func(t *testing.T) {
+		// 1. Arrange
+		view := NewBoardView()
+		view.SelectCell(3, 4)
+
+		// 2. Act
+		require.True(t, view.ToggleMark())
+		require.Equal(t, []domain.Coordinate{{X: 3, Y: 4}}, view.MarkedCells())
+		require.True(t, view.ToggleMark())
+
+		// 3. Assert
+		require.Empty(t, view.MarkedCells())
+	})
+
+	t.Run("cell that was already fired at cannot be marked", func(t *testing.T) {
+		// 1. Arrange
+		board := domain.NewBoard(domain.DefaultBoardSize)
+		board.SetCell(0, 0, domain.Miss)
+
+		view := NewBoardView()
+		view.SetBoard(board)
+		view.SelectCell(0, 0)
+
+		// 2. Act
+		got := view.ToggleMark()
+
+		// 3. Assert
+		require.False(t, got)
+		require.Empty(t, view.MarkedCells())
+		require.Equal(t, board.Size()*board.Size()-1, view.FireableCells())
+	})
+}
@@ -1,13 +1,24 @@
 package views
 
 import (
+	"fmt"
+	"sync"
+	"time"
+	"ws-battleship-shared/events"
+
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 type ConnectServerView struct {
+	// CancelFunc leaves the matchmaking queue.
+	CancelFunc func()
+
 	spinner spinner.Model
+	mu      sync.RWMutex
+	// queueStatus is set, while the player waits in the matchmaking queue.
+	queueStatus *events.QueueStatusEvent
 }
 
 func NewConnectServerView() *ConnectServerView {
@@ -25,6 +36,12 @@ func (v *ConnectServerView) Init() tea.Cmd {
 }
 
 func (v *ConnectServerView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.Type == tea.KeyEsc {
+		if v.QueueStatus() != nil && v.CancelFunc != nil {
+			v.CancelFunc()
+		}
+	}
+
 	var cmd tea.Cmd
 	v.spinner, cmd = v.spinner.Update(msg)
 	return v, cmd
@@ -33,6 +50,38 @@ func (v *ConnectServerView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (v *ConnectServerView) FixedUpdate() {
 }
 
+// SetQueueStatus is safe to call from other goroutines.
+func (v *ConnectServerView) SetQueueStatus(status events.QueueStatusEvent) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.queueStatus = &status
+}
+
+func (v *ConnectServerView) QueueStatus() *events.QueueStatusEvent {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.queueStatus
+}
+
 func (v *ConnectServerView) View() string {
-	return v.spinner.View() + " Connecting to server..."
+	status := v.QueueStatus()
+	if status == nil {
+		return v.spinner.View() + " Connecting to server..."
+	}
+
+	if status.IsMatched {
+		return v.spinner.View() + " Opponents are found, joining the match..."
+	}
+
+	estimatedWait := "unknown"
+	if wait := status.EstimatedWait; wait > 0 {
+		estimatedWait = wait.Round(time.Second).String()
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		v.spinner.View()+" Searching for opponents of your rating...",
+		fmt.Sprintf("Position in queue: %d", status.Position),
+		fmt.Sprintf("Estimated wait: %s", estimatedWait),
+		"",
+		helpStyle.Render("Press Esc to Leave the Queue"))
 }
@@ -1,6 +1,11 @@
 package views
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 	clientEvents "ws-battleship-client/internal/domain/events"
 	"ws-battleship-shared/domain"
 	"ws-battleship-shared/events"
@@ -17,19 +22,41 @@ var (
 	helpStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
 )
 
+const (
+	shotStatusTime = 3 * time.Second
+)
+
 type GameView struct {
 	isLocalPlayerTurn bool
+	isContinuation    bool
+	isPlacing         bool
+	shots             int
 	localPlayerID     string
+	isReconnecting    bool
+	isConnectionLost  bool
+	// isSpectator turns the view into read-only mode.
+	isSpectator     bool
+	turningPlayerID string
 
-	boards     map[string]*BoardView
-	yourBoard  *BoardView
-	enemyBoard *BoardView
+	shotStatus     string
+	shotStatusTime time.Time
 
+	// boards holds boards of all opponents, enemyBoard is the one being targeted.
+	boards     map[string]*BoardView
+	enemyIDs   []string
+	allyBoards []*BoardView
+	eliminated map[string]bool
+	// disconnected holds players, who are reconnecting. The turn timer is paused meanwhile.
+	disconnected map[string]bool
+	yourBoard    *BoardView
+	enemyBoard   *BoardView
+
+	placementView  *PlacementView
 	turnTimerView  *TimerView
 	gameTickerView *TickerView
 	chatView       *ChatView
 
-	playerFiredHandler func(targetPlayerID string, cellX, cellY byte)
+	playerFiredHandler func(targetPlayerID string, shots []domain.Coordinate)
 }
 
 func NewGameView(eventBus *events.EventBus, metadata domain.ClientMetadata) *GameView {
@@ -45,9 +72,13 @@ func NewGameView(eventBus *events.EventBus, metadata domain.ClientMetadata) *Gam
 
 	return &GameView{
 		localPlayerID:  metadata.ClientID,
+		isSpectator:    metadata.IsSpectator(),
 		boards:         make(map[string]*BoardView),
+		eliminated:     make(map[string]bool),
+		disconnected:   make(map[string]bool),
 		yourBoard:      NewBoardView(),
 		enemyBoard:     NewBoardView(),
+		placementView:  NewPlacementView(),
 		turnTimerView:  NewTimerView(),
 		gameTickerView: NewTickerView(),
 		chatView:       chatView,
@@ -61,6 +92,7 @@ func (v *GameView) Init() tea.Cmd {
 
 	return tea.Batch(v.yourBoard.Init(),
 		v.enemyBoard.Init(),
+		v.placementView.Init(),
 		v.turnTimerView.Init(),
 		v.gameTickerView.Init(),
 		v.chatView.Init(),
@@ -68,6 +100,10 @@ func (v *GameView) Init() tea.Cmd {
 }
 
 func (v *GameView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if v.isPlacing {
+		return v.updatePlacement(msg)
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.Type {
@@ -77,6 +113,8 @@ func (v *GameView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if v.isLocalPlayerTurn {
 				v.onPlayerFiredHandler()
 			}
+		case tea.KeyShiftTab:
+			v.SelectNextEnemy()
 		}
 	}
 
@@ -99,9 +137,16 @@ func (v *GameView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (v *GameView) FixedUpdate() {
 	v.gameTickerView.FixedUpdate()
 	v.turnTimerView.FixedUpdate()
+	v.placementView.FixedUpdate()
 }
 
 func (v *GameView) View() string {
+	if v.isPlacing {
+		placementTime := "PLACEMENT TIME: " + v.turnTimerView.View()
+		placement := boardStyle.Render(lipgloss.JoinVertical(lipgloss.Center, placementTime, "", v.placementView.View()))
+		return lipgloss.JoinHorizontal(lipgloss.Top, placement, " ", v.chatView.View())
+	}
+
 	gameTime := "GAME TIME: " + v.gameTickerView.View()
 
 	boards := boardStyle.Render(lipgloss.JoinVertical(lipgloss.Center, gameTime, v.renderPlayersBoards()))
@@ -109,7 +154,30 @@ func (v *GameView) View() string {
 	return gameView
 }
 
+func (v *GameView) StartPlacement(event events.PlacementStartEvent) {
+	if v.isSpectator {
+		v.turnTimerView.Reset(int(event.RemainingTime.Seconds()))
+		v.turnTimerView.Start()
+		return
+	}
+
+	v.isPlacing = true
+	v.placementView.Start(event.Rules)
+	v.turnTimerView.Reset(int(event.RemainingTime.Seconds()))
+	// Offline games don't limit the time.
+	if event.RemainingTime > 0 {
+		v.turnTimerView.Start()
+	}
+}
+
+func (v *GameView) SetPlayerReady(playerID string) {
+	if playerID == v.localPlayerID {
+		v.placementView.SetReady()
+	}
+}
+
 func (v *GameView) StartGame() {
+	v.isPlacing = false
 	v.gameTickerView.Start()
 }
 
@@ -121,71 +189,333 @@ func (v *GameView) EndGame() {
 		v.yourBoard.SetSelectable(false)
 	}
 
-	if v.enemyBoard != nil {
-		v.enemyBoard.SetSelectable(false)
+	for _, board := range v.boards {
+		board.SetSelectable(false)
 	}
 }
 
 func (v *GameView) SetGameModel(gameModel *domain.GameModel) {
-	clear(v.boards)
+	clear(v.eliminated)
+	v.enemyIDs = v.enemyIDs[:0]
+	v.allyBoards = v.allyBoards[:0]
 
+	perspectivePlayerID := v.perspectivePlayerID(gameModel)
+	localPlayer := gameModel.Players[perspectivePlayerID]
 	for playerID, player := range gameModel.Players {
-		if playerID == v.localPlayerID {
+		v.eliminated[playerID] = player.IsEliminated
+
+		if playerID == perspectivePlayerID {
 			v.yourBoard.SetPlayer(gameModel.Players[playerID])
-		} else {
-			v.enemyBoard.SetPlayer(player)
+			v.placementView.SetRerolledBoard(player.Board)
+			continue
+		}
+
+		// Teammates' boards are shown next to yours and can't be targeted.
+		if player.IsAllyOf(localPlayer) {
+			allyBoard := NewBoardView()
+			allyBoard.SetPlayer(player)
+			v.allyBoards = append(v.allyBoards, allyBoard)
+			delete(v.boards, playerID)
+			continue
 		}
-		v.boards[playerID] = v.enemyBoard
+
+		board, found := v.boards[playerID]
+		if !found {
+			board = NewBoardView()
+			board.Init()
+			v.boards[playerID] = board
+		}
+		board.SetPlayer(player)
+		v.enemyIDs = append(v.enemyIDs, playerID)
+	}
+
+	// Players who left the match are removed.
+	for playerID := range v.boards {
+		if _, found := gameModel.Players[playerID]; !found {
+			delete(v.boards, playerID)
+		}
+	}
+	slices.Sort(v.enemyIDs)
+	slices.SortFunc(v.allyBoards, func(lhs, rhs *BoardView) int {
+		return strings.Compare(lhs.playerID, rhs.playerID)
+	})
+
+	if board, found := v.boards[v.enemyBoard.playerID]; !found || v.eliminated[board.playerID] {
+		v.SelectNextEnemy()
 	}
 }
 
+// SelectNextEnemy switches the target to the next opponent, who is still in the game.
+func (v *GameView) SelectNextEnemy() {
+	if len(v.enemyIDs) == 0 {
+		return
+	}
+
+	idx := slices.Index(v.enemyIDs, v.enemyBoard.playerID)
+	for range v.enemyIDs {
+		idx = (idx + 1) % len(v.enemyIDs)
+		if !v.eliminated[v.enemyIDs[idx]] {
+			break
+		}
+	}
+
+	v.enemyBoard.SetSelectable(false)
+	v.enemyBoard.ClearMarks()
+	v.enemyBoard = v.boards[v.enemyIDs[idx]]
+	v.enemyBoard.SetSelectable(v.isLocalPlayerTurn)
+}
+
 func (v *GameView) GiveTurnToPlayer(event events.PlayerTurnEvent, isLocalPlayer bool) error {
+	// Spectators never fire, even if they watch from the side of the turning player.
+	isLocalPlayer = isLocalPlayer && !v.isSpectator
+
 	v.isLocalPlayerTurn = isLocalPlayer
+	v.turningPlayerID = event.TurningPlayerID
+	v.isContinuation = event.IsContinuation
+	v.shots = max(event.Shots, 1)
+	v.enemyBoard.ClearMarks()
 	v.enemyBoard.SetSelectable(isLocalPlayer)
 	v.turnTimerView.Reset(int(event.RemainingTime.Seconds()))
-	v.turnTimerView.Start()
+	switch {
+	case event.RemainingTime <= 0:
+		v.turnTimerView.Stop()
+	case len(v.disconnected) == 0:
+		v.turnTimerView.Start()
+	}
 	return nil
 }
 
+// SetReconnecting shows that the connection to the server dropped and is being restored.
+func (v *GameView) SetReconnecting(isReconnecting bool) {
+	v.isReconnecting = isReconnecting
+	if isReconnecting {
+		v.isLocalPlayerTurn = false
+		v.enemyBoard.SetSelectable(false)
+		v.turnTimerView.Stop()
+	}
+}
+
+func (v *GameView) SetConnectionLost() {
+	v.isReconnecting = false
+	v.isConnectionLost = true
+	v.EndGame()
+}
+
+// ResumeSession restores the match, after the connection was restored. The chat is cleared, since
+// the server sends it back as well.
+func (v *GameView) ResumeSession(event events.SessionResumedEvent) {
+	v.isReconnecting = false
+	clear(v.disconnected)
+	v.chatView.Clear()
+	v.SetGameModel(event.GameState.Model())
+
+	if event.Turn == nil {
+		return
+	}
+
+	if v.isPlacing {
+		v.StartGame()
+	}
+	_ = v.GiveTurnToPlayer(*event.Turn, event.Turn.TurningPlayerID == v.localPlayerID)
+}
+
+func (v *GameView) SetPlayerDisconnected(playerID string) {
+	v.disconnected[playerID] = true
+	v.turnTimerView.Stop()
+}
+
+func (v *GameView) SetPlayerReconnected(playerID string, remainingTime time.Duration) {
+	delete(v.disconnected, playerID)
+	if len(v.disconnected) == 0 {
+		v.turnTimerView.Reset(int(remainingTime.Seconds()))
+		v.turnTimerView.Start()
+	}
+}
+
+func (v *GameView) SinkShip(event events.ShipSunkEvent) {
+	switch v.localPlayerID {
+	case event.FiringPlayerID:
+		v.shotStatus = "ENEMY SHIP SUNK!"
+	case event.TargetPlayerID:
+		v.shotStatus = "YOUR SHIP WAS SUNK!"
+	default:
+		return
+	}
+	v.shotStatusTime = time.Now()
+}
+
 func (v *GameView) AppendMessageInChat(msg ChatMessage) error {
 	v.chatView.AppendMessage(msg)
 	return nil
 }
 
-func (v *GameView) SetPlayerFiredHandler(fn func(targetPlayerID string, cellY, cellX byte)) {
+func (v *GameView) SetPlayerFiredHandler(fn func(targetPlayerID string, shots []domain.Coordinate)) {
 	v.playerFiredHandler = fn
 }
 
+func (v *GameView) SetFleetPlacedHandler(fn func(ships []domain.ShipPlacement)) {
+	v.placementView.SetFleetPlacedHandler(fn)
+}
+
+func (v *GameView) SetFleetRerollHandler(fn func()) {
+	v.placementView.SetRerollHandler(fn)
+}
+
+func (v *GameView) updatePlacement(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC, tea.KeyEsc:
+			return v, tea.Quit
+		}
+
+		// Keys are consumed by the fleet placement, so the chat stays read-only meanwhile.
+		_, cmd := v.placementView.Update(msg)
+		cmds = append(cmds, cmd)
+
+	default:
+		_, cmd := v.chatView.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	_, cmd := v.turnTimerView.Update(msg)
+	cmds = append(cmds, cmd)
+
+	return v, tea.Batch(cmds...)
+}
+
 func (v *GameView) renderPlayersBoards() string {
-	return lipgloss.JoinHorizontal(lipgloss.Center, v.yourBoard.View(), v.renderGameTurn(), v.enemyBoard.View())
+	enemyBoard := v.enemyBoard.View()
+	if len(v.enemyIDs) > 1 {
+		enemyBoard = lipgloss.JoinVertical(lipgloss.Center, v.renderEnemyTabs(), enemyBoard)
+	}
+
+	boards := make([]string, 0, len(v.allyBoards)+3)
+	for _, allyBoard := range v.allyBoards {
+		boards = append(boards, allyBoard.View(), "  ")
+	}
+	boards = append(boards, v.yourBoard.View(), v.renderGameTurn(), enemyBoard)
+
+	return lipgloss.JoinHorizontal(lipgloss.Center, boards...)
+}
+
+func (v *GameView) renderEnemyTabs() string {
+	tabs := make([]string, 0, len(v.enemyIDs))
+	for _, playerID := range v.enemyIDs {
+		board := v.boards[playerID]
+
+		switch {
+		case board == v.enemyBoard:
+			tabs = append(tabs, highlightStyle.Render(" "+board.nickname+" "))
+		case v.eliminated[playerID]:
+			tabs = append(tabs, helpStyle.Render(" ✗ "+board.nickname+" "))
+		default:
+			tabs = append(tabs, " "+board.nickname+" ")
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Center, tabs...)
 }
 
 func (v *GameView) renderGameTurn() string {
 	var turn string
-	if v.isLocalPlayerTurn {
+	if v.isConnectionLost {
+		turn = highlightForbiddenCell.Render(" CONNECTION LOST ")
+	} else if v.isReconnecting {
+		turn = highlightForbiddenCell.Render(" RECONNECTING… ")
+	} else if v.isSpectator {
+		turn = highlightStyle.Render(" SPECTATING ")
+		if nickname := v.nicknameOf(v.turningPlayerID); nickname != "" {
+			turn = lipgloss.JoinVertical(lipgloss.Center, turn, fmt.Sprintf("'%s' turns", nickname))
+		}
+	} else if v.eliminated[v.localPlayerID] {
+		turn = highlightForbiddenCell.Render(" ELIMINATED ")
+	} else if v.isLocalPlayerTurn {
 		turn = highlightAllowedCell.Render(" YOUR TURN ")
 	} else {
 		turn = highlightForbiddenCell.Render(" ENEMY TURN ")
 	}
+	if v.isLocalPlayerTurn && v.isContinuation {
+		turn = lipgloss.JoinVertical(lipgloss.Center, turn, highlightAllowedCell.Render(" HIT — FIRE AGAIN "))
+	}
 	turn = lipgloss.JoinVertical(lipgloss.Center, turn, v.turnTimerView.View())
 
-	if v.isLocalPlayerTurn {
-		help := helpStyle.Align(lipgloss.Center).Render("Press ↑ ↓ → ← to Navigate\nPress Tab to Fire")
+	if len(v.disconnected) > 0 && !v.isReconnecting {
+		turn = lipgloss.JoinVertical(lipgloss.Center, turn, "", helpStyle.Render("Waiting for a player to reconnect..."))
+	}
+
+	if v.shotStatus != "" && time.Since(v.shotStatusTime) < shotStatusTime {
+		turn = lipgloss.JoinVertical(lipgloss.Center, turn, "", sunkShipStyle.Render(v.shotStatus))
+	}
+
+	var switchHelp string
+	if len(v.enemyIDs) > 1 {
+		switchHelp = "\nPress Shift+Tab to Switch Target"
+	}
+
+	if v.isLocalPlayerTurn && v.shots > 1 {
+		salvo := fmt.Sprintf("SALVO: %d/%d marked", len(v.enemyBoard.MarkedCells()), v.shots)
+		help := helpStyle.Align(lipgloss.Center).Render("Press ↑ ↓ → ← to Navigate\nPress Tab to Mark a Target" + switchHelp)
+		return lipgloss.PlaceHorizontal(30, lipgloss.Center, turn+"\n\n"+salvo+"\n\n"+help)
+	} else if v.isLocalPlayerTurn {
+		help := helpStyle.Align(lipgloss.Center).Render("Press ↑ ↓ → ← to Navigate\nPress Tab to Fire" + switchHelp)
+		return lipgloss.PlaceHorizontal(30, lipgloss.Center, turn+"\n\n"+help)
+	} else if switchHelp != "" {
+		help := helpStyle.Align(lipgloss.Center).Render(switchHelp[1:])
 		return lipgloss.PlaceHorizontal(30, lipgloss.Center, turn+"\n\n"+help)
 	} else {
 		return lipgloss.PlaceHorizontal(30, lipgloss.Center, turn)
 	}
 }
 
+// perspectivePlayerID returns the player, whose board is shown as yours. Spectators watch the
+// match from the side of the first player, unless they are replaying it from the side of another one.
+func (v *GameView) perspectivePlayerID(gameModel *domain.GameModel) string {
+	if _, found := gameModel.Players[v.localPlayerID]; found || !v.isSpectator {
+		return v.localPlayerID
+	}
+
+	playerIDs := slices.Sorted(maps.Keys(gameModel.Players))
+	if len(playerIDs) == 0 {
+		return ""
+	}
+	return playerIDs[0]
+}
+
+func (v *GameView) nicknameOf(playerID string) string {
+	if v.yourBoard.playerID == playerID {
+		return v.yourBoard.nickname
+	}
+
+	if board, found := v.boards[playerID]; found {
+		return board.nickname
+	}
+
+	for _, allyBoard := range v.allyBoards {
+		if allyBoard.playerID == playerID {
+			return allyBoard.nickname
+		}
+	}
+	return ""
+}
+
 func (v *GameView) onPlayerFiredHandler() {
-	if v.enemyBoard == nil || !v.enemyBoard.IsAllowedToFire() {
+	if v.enemyBoard == nil || !v.enemyBoard.ToggleMark() {
+		return
+	}
+
+	// In salvo mode shots are sent only when all of them are marked.
+	shots := v.enemyBoard.MarkedCells()
+	if len(shots) < min(v.shots, v.enemyBoard.FireableCells()) {
 		return
 	}
 
 	v.isLocalPlayerTurn = false
 	v.enemyBoard.SetSelectable(false)
+	v.enemyBoard.ClearMarks()
 
 	if v.playerFiredHandler != nil {
-		v.playerFiredHandler(v.enemyBoard.playerID, byte(v.enemyBoard.cellX), byte(v.enemyBoard.cellY))
+		v.playerFiredHandler(v.enemyBoard.playerID, shots)
 	}
 }
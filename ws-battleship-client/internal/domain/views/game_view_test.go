@@ -0,0 +1,162 @@
+package views
+
+import (
+	"slices"
+	"testing"
+	"time"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectNextEnemy(t *testing.T) {
+	newGameModel := func(eliminatedIDs ...string) *domain.GameModel {
+		gameModel := &domain.GameModel{Players: make(map[string]*domain.PlayerModel)}
+		for _, id := range []string{"local", "a", "b", "c"} {
+			player := domain.NewPlayerModel(domain.NewBoard(domain.DefaultBoardSize), domain.ClientMetadata{ClientID: id, Nickname: id})
+			player.IsEliminated = slices.Contains(eliminatedIDs, id)
+			gameModel.Players[id] = player
+		}
+		return gameModel
+	}
+
+	t.Run("cycle through opponents", func(t *testing.T) {
+		// 1. Arrange
+		view := NewGameView(events.NewEventBus(), domain.ClientMetadata{ClientID: "local"})
+		view.SetGameModel(newGameModel())
+		require.Equal(t, "a", view.enemyBoard.playerID)
+
+		// 2. Act
+		view.SelectNextEnemy()
+		view.SelectNextEnemy()
+		view.SelectNextEnemy()
+
+		// 3. Assert
+		require.Equal(t, "a", view.enemyBoard.playerID)
+	})
+
+	t.Run("eliminated opponents are skipped", func(t *testing.T) {
+		// 1. Arrange
+		view := NewGameView(events.NewEventBus(), domain.ClientMetadata{ClientID: "local"})
+		view.SetGameModel(newGameModel())
+
+		// 2. Act
+		view.SetGameModel(newGameModel("a", "b"))
+
+		// 3. Assert
+		require.Equal(t, "c", view.enemyBoard.playerID)
+	})
+}
+
+func TestTeamLayout(t *testing.T) {
+	t.Run("teammate board is shown and cannot be targeted", func(t *testing.T) {
+		// 1. Arrange
+		gameModel := &domain.GameModel{Players: make(map[string]*domain.PlayerModel)}
+		for i, id := range []string{"local", "ally", "enemy1", "enemy2"} {
+			player := domain.NewPlayerModel(domain.NewBoard(domain.DefaultBoardSize), domain.ClientMetadata{ClientID: id, Nickname: id})
+			player.Team = i/2 + 1
+			gameModel.Players[id] = player
+		}
+		view := NewGameView(events.NewEventBus(), domain.ClientMetadata{ClientID: "local"})
+
+		// 2. Act
+		view.SetGameModel(gameModel)
+
+		// 3. Assert
+		require.Len(t, view.allyBoards, 1)
+		require.Equal(t, "ally", view.allyBoards[0].playerID)
+		require.Equal(t, []string{"enemy1", "enemy2"}, view.enemyIDs)
+	})
+}
+
+func TestResumeSession(t *testing.T) {
+	newGameModel := func() *domain.GameModel {
+		gameModel := &domain.GameModel{Players: make(map[string]*domain.PlayerModel)}
+		for _, id := range []string{"local", "enemy"} {
+			gameModel.Players[id] = domain.NewPlayerModel(domain.NewBoard(domain.DefaultBoardSize), domain.ClientMetadata{ClientID: id, Nickname: id})
+		}
+		return gameModel
+	}
+
+	newGameState := func() *events.GameState {
+		var models []*domain.PlayerModel
+		for _, player := range newGameModel().Players {
+			models = append(models, player)
+		}
+		return events.NewRevealedGameState(0, models...)
+	}
+
+	t.Run("turn is given back after reconnecting", func(t *testing.T) {
+		// 1. Arrange
+		view := NewGameView(events.NewEventBus(), domain.ClientMetadata{ClientID: "local"})
+		view.SetGameModel(newGameModel())
+		require.NoError(t, view.GiveTurnToPlayer(events.PlayerTurnEvent{TurningPlayerID: "local"}, true))
+		view.SetReconnecting(true)
+		require.False(t, view.isLocalPlayerTurn)
+
+		// 2. Act
+		view.ResumeSession(events.SessionResumedEvent{
+			GameState: newGameState(),
+			Turn:      &events.PlayerTurnEvent{TurningPlayerID: "local", Shots: 1},
+		})
+
+		// 3. Assert
+		require.False(t, view.isReconnecting)
+		require.True(t, view.isLocalPlayerTurn)
+		require.True(t, view.enemyBoard.isSelectable)
+	})
+
+	t.Run("turn timer is paused while an opponent is reconnecting", func(t *testing.T) {
+		// 1. Arrange
+		view := NewGameView(events.NewEventBus(), domain.ClientMetadata{ClientID: "local"})
+		view.SetGameModel(newGameModel())
+		view.SetPlayerDisconnected("enemy")
+
+		// 2. Act
+		require.NoError(t, view.GiveTurnToPlayer(events.PlayerTurnEvent{TurningPlayerID: "enemy"}, false))
+		isPaused := view.turnTimerView.isStopped
+		view.SetPlayerReconnected("enemy", time.Minute)
+
+		// 3. Assert
+		require.True(t, isPaused)
+		require.False(t, view.turnTimerView.isStopped)
+	})
+}
+
+func TestSpectatorLayout(t *testing.T) {
+	newGameModel := func() *domain.GameModel {
+		gameModel := &domain.GameModel{Players: make(map[string]*domain.PlayerModel)}
+		for _, id := range []string{"b", "a"} {
+			gameModel.Players[id] = domain.NewPlayerModel(domain.NewBoard(domain.DefaultBoardSize), domain.ClientMetadata{ClientID: id, Nickname: id})
+		}
+		return gameModel
+	}
+
+	t.Run("both boards are shown and cannot be targeted", func(t *testing.T) {
+		// 1. Arrange
+		view := NewGameView(events.NewEventBus(), domain.ClientMetadata{ClientID: "spectator", Role: domain.SpectatorRole})
+
+		// 2. Act
+		view.SetGameModel(newGameModel())
+		require.NoError(t, view.GiveTurnToPlayer(events.PlayerTurnEvent{TurningPlayerID: "a"}, false))
+
+		// 3. Assert
+		require.Equal(t, "a", view.yourBoard.playerID)
+		require.Equal(t, "b", view.enemyBoard.playerID)
+		require.False(t, view.enemyBoard.isSelectable)
+		require.Equal(t, "a", view.nicknameOf(view.turningPlayerID))
+	})
+
+	t.Run("placement is skipped", func(t *testing.T) {
+		// 1. Arrange
+		view := NewGameView(events.NewEventBus(), domain.ClientMetadata{ClientID: "spectator", Role: domain.SpectatorRole})
+		view.SetGameModel(newGameModel())
+
+		// 2. Act
+		view.StartPlacement(events.PlacementStartEvent{Rules: domain.DefaultRules(), RemainingTime: time.Minute})
+
+		// 3. Assert
+		require.False(t, view.isPlacing)
+	})
+}
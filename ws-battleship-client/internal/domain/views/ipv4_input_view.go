@@ -37,6 +37,14 @@ func (v *IPv4InputView) View() string {
 	return v.textInput.View()
 }
 
+func (v *IPv4InputView) Focus() {
+	v.textInput.Focus()
+}
+
+func (v *IPv4InputView) Blur() {
+	v.textInput.Blur()
+}
+
 func (v *IPv4InputView) IPAddress() (net.IP, error) {
 	ipv4Addr := v.textInput.Value()
 	return net.ParseIP(ipv4Addr), validateIPInputText(ipv4Addr)
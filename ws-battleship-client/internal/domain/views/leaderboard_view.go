@@ -0,0 +1,189 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"ws-battleship-shared/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LeaderboardView shows the leaderboard of the server, or the matches of the player.
+type LeaderboardView struct {
+	LeaderboardFunc func(sortBy domain.LeaderboardSort, page int)
+	HistoryFunc     func(page int)
+	BackFunc        func()
+
+	Err error
+	// handle highlights the player on the leaderboard.
+	handle      string
+	leaderboard domain.Page[domain.LeaderboardEntry]
+	history     domain.Page[domain.MatchRecord]
+	sortIdx     int
+	page        int
+	isHistory   bool
+}
+
+func NewLeaderboardView(handle string) *LeaderboardView {
+	return &LeaderboardView{
+		handle:  handle,
+		sortIdx: len(domain.LeaderboardSorts) - 1,
+		page:    1,
+	}
+}
+
+func (v *LeaderboardView) Init() tea.Cmd {
+	return nil
+}
+
+func (v *LeaderboardView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return v, tea.Quit
+		case tea.KeyEsc:
+			if v.BackFunc != nil {
+				v.BackFunc()
+			}
+		case tea.KeyLeft:
+			if v.page > 1 {
+				v.page--
+				v.Fetch()
+			}
+		case tea.KeyRight:
+			if v.hasNextPage() {
+				v.page++
+				v.Fetch()
+			}
+		case tea.KeyRunes:
+			switch strings.ToLower(msg.String()) {
+			case "s":
+				if !v.isHistory {
+					v.sortIdx = (v.sortIdx + 1) % len(domain.LeaderboardSorts)
+					v.page = 1
+					v.Fetch()
+				}
+			case "m":
+				v.isHistory = !v.isHistory
+				v.page = 1
+				v.Fetch()
+			case "r":
+				v.Fetch()
+			}
+		}
+	}
+	return v, nil
+}
+
+func (v *LeaderboardView) FixedUpdate() {
+}
+
+// Fetch asks for the shown page of the leaderboard or of the history.
+func (v *LeaderboardView) Fetch() {
+	if v.isHistory {
+		if v.HistoryFunc != nil {
+			v.HistoryFunc(v.page)
+		}
+		return
+	}
+
+	if v.LeaderboardFunc != nil {
+		v.LeaderboardFunc(v.SortBy(), v.page)
+	}
+}
+
+func (v *LeaderboardView) SortBy() domain.LeaderboardSort {
+	return domain.LeaderboardSorts[v.sortIdx]
+}
+
+func (v *LeaderboardView) SetLeaderboard(page domain.Page[domain.LeaderboardEntry]) {
+	v.leaderboard = page
+}
+
+func (v *LeaderboardView) SetHistory(page domain.Page[domain.MatchRecord]) {
+	v.history = page
+}
+
+func (v *LeaderboardView) hasNextPage() bool {
+	if v.isHistory {
+		return v.page*v.history.PageSize < v.history.Total
+	}
+	return v.page*v.leaderboard.PageSize < v.leaderboard.Total
+}
+
+func (v *LeaderboardView) View() string {
+	var lines []string
+	if v.isHistory {
+		lines = v.historyLines()
+	} else {
+		lines = v.leaderboardLines()
+	}
+
+	if v.Err != nil {
+		lines = append(lines, "", highlightForbiddenCell.Render(v.Err.Error()))
+	}
+
+	lines = append(lines, "", helpStyle.Render("Press ← → to Turn Pages · S to Sort · M for My Matches · R to Refresh\nPress Esc to Go Back"))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func (v *LeaderboardView) leaderboardLines() []string {
+	title := fmt.Sprintf("LEADERBOARD by %s (page %d)", strings.ReplaceAll(v.SortBy(), "_", " "), v.page)
+	lines := []string{replayListTitleStyle.Render(title)}
+	if len(v.leaderboard.Items) == 0 {
+		return append(lines, "Nobody has finished a match yet.")
+	}
+
+	lines = append(lines, helpStyle.Render(fmt.Sprintf("  %-4s  %-16s  %-6s  %-7s  %-8s  %s", "RANK", "PLAYER", "RATING", "W/L", "WIN RATE", "ACCURACY")))
+	for _, entry := range v.leaderboard.Items {
+		row := fmt.Sprintf("%-4d  %-16s  %-6.0f  %-7s  %-8s  %s",
+			entry.Rank,
+			entry.Profile.Nickname,
+			entry.Profile.Rating,
+			fmt.Sprintf("%d/%d", entry.Profile.Wins, entry.Profile.Losses),
+			fmt.Sprintf("%.0f%%", entry.WinRate*100),
+			fmt.Sprintf("%.0f%%", entry.Accuracy*100))
+
+		if entry.Profile.Handle == v.handle {
+			row = highlightStyle.Render("> " + row)
+		} else {
+			row = "  " + row
+		}
+		lines = append(lines, row)
+	}
+	return lines
+}
+
+func (v *LeaderboardView) historyLines() []string {
+	lines := []string{replayListTitleStyle.Render(fmt.Sprintf("MY MATCHES (page %d)", v.page))}
+	if len(v.history.Items) == 0 {
+		return append(lines, "You haven't finished any match yet.")
+	}
+
+	lines = append(lines, helpStyle.Render(fmt.Sprintf("  %-16s  %-6s  %-5s  %-8s  %-8s  %s", "DATE", "RESULT", "TURNS", "DURATION", "ACCURACY", "PLAYERS")))
+	for _, record := range v.history.Items {
+		result, accuracy := "Lost", 0.0
+		nicknames := make([]string, 0, len(record.Participants))
+		for _, participant := range record.Participants {
+			nicknames = append(nicknames, participant.Nickname)
+			if participant.Handle == v.handle {
+				accuracy = participant.Accuracy
+				if participant.IsWinner {
+					result = "Won"
+				}
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("  %-16s  %-6s  %-5d  %-8s  %-8s  %s",
+			record.EndedAt.Local().Format("2006-01-02 15:04"),
+			result,
+			record.Turns,
+			record.Duration.Round(time.Second),
+			fmt.Sprintf("%.0f%%", accuracy*100),
+			strings.Join(nicknames, ", ")))
+	}
+	return lines
+}
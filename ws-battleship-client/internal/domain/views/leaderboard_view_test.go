@@ -0,0 +1,88 @@
+package views
+
+import (
+	"testing"
+	"ws-battleship-shared/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderboardView(t *testing.T) {
+	newEntries := func(total int) domain.Page[domain.LeaderboardEntry] {
+		return domain.Page[domain.LeaderboardEntry]{
+			Items: []domain.LeaderboardEntry{
+				{Rank: 1, Profile: domain.PublicProfile{Handle: "a", Nickname: "alice"}},
+				{Rank: 2, Profile: domain.PublicProfile{Handle: "b", Nickname: "bob"}},
+			},
+			Page:     1,
+			PageSize: 2,
+			Total:    total,
+		}
+	}
+
+	t.Run("next page is fetched only if it exists", func(t *testing.T) {
+		// 1. Arrange
+		view := NewLeaderboardView("a")
+		view.SetLeaderboard(newEntries(3))
+
+		var pages []int
+		view.LeaderboardFunc = func(_ domain.LeaderboardSort, page int) { pages = append(pages, page) }
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyRight})
+		view.Update(tea.KeyMsg{Type: tea.KeyRight})
+		view.Update(tea.KeyMsg{Type: tea.KeyLeft})
+		view.Update(tea.KeyMsg{Type: tea.KeyLeft})
+
+		// 3. Assert
+		require.Equal(t, []int{2, 1}, pages)
+	})
+
+	t.Run("sort is cycled from the first page", func(t *testing.T) {
+		// 1. Arrange
+		view := NewLeaderboardView("a")
+		view.SetLeaderboard(newEntries(3))
+		view.Update(tea.KeyMsg{Type: tea.KeyRight})
+
+		var sortBy domain.LeaderboardSort
+		var page int
+		view.LeaderboardFunc = func(s domain.LeaderboardSort, p int) { sortBy, page = s, p }
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+
+		// 3. Assert
+		require.Equal(t, domain.SortByWins, sortBy)
+		require.Equal(t, 1, page)
+	})
+
+	t.Run("my matches are fetched instead of the leaderboard", func(t *testing.T) {
+		// 1. Arrange
+		view := NewLeaderboardView("a")
+
+		var isLeaderboardFetched, isHistoryFetched bool
+		view.LeaderboardFunc = func(domain.LeaderboardSort, int) { isLeaderboardFetched = true }
+		view.HistoryFunc = func(int) { isHistoryFetched = true }
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+
+		// 3. Assert
+		require.False(t, isLeaderboardFetched)
+		require.True(t, isHistoryFetched)
+	})
+
+	t.Run("player is highlighted on the leaderboard", func(t *testing.T) {
+		// 1. Arrange
+		view := NewLeaderboardView("b")
+		view.SetLeaderboard(newEntries(2))
+
+		// 2. Act
+		out := view.View()
+
+		// 3. Assert
+		require.Contains(t, out, "> 2")
+		require.NotContains(t, out, "> 1")
+	})
+}
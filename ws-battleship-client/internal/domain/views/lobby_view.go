@@ -0,0 +1,130 @@
+package views
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"ws-battleship-shared/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// matchIDShortLength is enough to tell matches apart in the table.
+const matchIDShortLength = 8
+
+// LobbyView lists open matches of the server to pick one to join or to spectate.
+type LobbyView struct {
+	JoinFunc     func(matchID string)
+	SpectateFunc func(matchID string)
+	RefreshFunc  func()
+	BackFunc     func()
+
+	Err      error
+	matches  []domain.MatchSummary
+	selected int
+}
+
+func NewLobbyView() *LobbyView {
+	return &LobbyView{}
+}
+
+func (v *LobbyView) Init() tea.Cmd {
+	return nil
+}
+
+func (v *LobbyView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return v, tea.Quit
+		case tea.KeyEsc:
+			if v.BackFunc != nil {
+				v.BackFunc()
+			}
+		case tea.KeyUp:
+			if v.selected > 0 {
+				v.selected--
+			}
+		case tea.KeyDown:
+			if v.selected < len(v.matches)-1 {
+				v.selected++
+			}
+		case tea.KeyEnter:
+			if match, ok := v.selectedMatch(); ok && match.IsJoinable() && v.JoinFunc != nil {
+				v.JoinFunc(match.ID)
+			}
+		case tea.KeyRunes:
+			switch strings.ToLower(msg.String()) {
+			case "s":
+				if match, ok := v.selectedMatch(); ok && v.SpectateFunc != nil {
+					v.SpectateFunc(match.ID)
+				}
+			case "r":
+				if v.RefreshFunc != nil {
+					v.RefreshFunc()
+				}
+			}
+		}
+	}
+	return v, nil
+}
+
+func (v *LobbyView) FixedUpdate() {
+}
+
+func (v *LobbyView) View() string {
+	lines := []string{replayListTitleStyle.Render("LOBBY")}
+	if len(v.matches) == 0 {
+		lines = append(lines, "No open matches, connect to start a new one.")
+	} else {
+		lines = append(lines, helpStyle.Render(fmt.Sprintf("  %-8s  %-7s  %-5s  %-13s  %s", "MATCH", "STATUS", "SEATS", "RULES", "PLAYERS")))
+	}
+
+	for i, match := range v.matches {
+		row := fmt.Sprintf("%-8s  %-7s  %-5s  %-13s  %s",
+			match.ID[:min(len(match.ID), matchIDShortLength)],
+			match.Status,
+			fmt.Sprintf("%d/%d", len(match.Players), match.Capacity),
+			fmt.Sprintf("%dx%d %s", match.Rules.BoardSize, match.Rules.BoardSize, cmp.Or(match.Rules.Mode, domain.ClassicMode)),
+			strings.Join(match.Players, ", "))
+
+		if i == v.selected {
+			row = highlightStyle.Render("> " + row)
+		} else {
+			row = "  " + row
+		}
+		lines = append(lines, row)
+	}
+
+	if v.Err != nil {
+		lines = append(lines, "", highlightForbiddenCell.Render(v.Err.Error()))
+	}
+
+	lines = append(lines, "", helpStyle.Render("Press ↑ ↓ to Navigate\nPress Enter to Join · S to Spectate · R to Refresh\nPress Esc to Go Back"))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// SetMatches shows the fetched matches. The selection stays on the same match, if it's still open.
+func (v *LobbyView) SetMatches(matches []domain.MatchSummary) {
+	selectedID := ""
+	if match, ok := v.selectedMatch(); ok {
+		selectedID = match.ID
+	}
+
+	v.matches = matches
+	v.selected = 0
+	for i, match := range matches {
+		if match.ID == selectedID {
+			v.selected = i
+		}
+	}
+}
+
+func (v *LobbyView) selectedMatch() (domain.MatchSummary, bool) {
+	if v.selected >= len(v.matches) {
+		return domain.MatchSummary{}, false
+	}
+	return v.matches[v.selected], true
+}
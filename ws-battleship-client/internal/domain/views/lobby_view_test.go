@@ -0,0 +1,68 @@
+package views
+
+import (
+	"testing"
+	"ws-battleship-shared/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLobbyView(t *testing.T) {
+	newMatches := func() []domain.MatchSummary {
+		return []domain.MatchSummary{
+			{ID: "waiting", Players: []string{"a"}, Capacity: 2, Rules: domain.DefaultRules(), Status: domain.WaitingMatch},
+			{ID: "running", Players: []string{"b", "c"}, Capacity: 2, Rules: domain.DefaultRules(), Status: domain.RunningMatch},
+		}
+	}
+
+	t.Run("selection stays on the same match after refresh", func(t *testing.T) {
+		// 1. Arrange
+		view := NewLobbyView()
+		view.SetMatches(newMatches())
+		view.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+		// 2. Act
+		matches := newMatches()
+		view.SetMatches([]domain.MatchSummary{matches[1], matches[0]})
+
+		// 3. Assert
+		match, ok := view.selectedMatch()
+		require.True(t, ok)
+		require.Equal(t, "running", match.ID)
+	})
+
+	t.Run("running match can be spectated, but not joined", func(t *testing.T) {
+		// 1. Arrange
+		view := NewLobbyView()
+		view.SetMatches(newMatches())
+		view.Update(tea.KeyMsg{Type: tea.KeyDown})
+
+		var joinedID, spectatedID string
+		view.JoinFunc = func(matchID string) { joinedID = matchID }
+		view.SpectateFunc = func(matchID string) { spectatedID = matchID }
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+
+		// 3. Assert
+		require.Empty(t, joinedID)
+		require.Equal(t, "running", spectatedID)
+	})
+
+	t.Run("waiting match with a free seat is joined", func(t *testing.T) {
+		// 1. Arrange
+		view := NewLobbyView()
+		view.SetMatches(newMatches())
+
+		var joinedID string
+		view.JoinFunc = func(matchID string) { joinedID = matchID }
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		// 3. Assert
+		require.Equal(t, "waiting", joinedID)
+	})
+}
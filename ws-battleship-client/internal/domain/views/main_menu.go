@@ -1,8 +1,13 @@
 package views
 
 import (
+	"errors"
+	"fmt"
 	"net"
+	"strings"
+	"ws-battleship-shared/domain"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -11,26 +16,101 @@ var (
 	inputTextStyle = lipgloss.NewStyle().Align(lipgloss.Center).Border(lipgloss.ThickBorder())
 )
 
-type ConnectFunc func(ip net.IP)
+var ErrEmptyRoomCode = errors.New("enter the code of the room")
+
+// ConnectOptions tell the server what the client wants to do after the connection.
+type ConnectOptions struct {
+	// SpectateMatchID is the match to spectate. The client joins as a player, if it's empty.
+	SpectateMatchID string
+	// JoinMatchID is the match picked in the lobby. The client is matched automatically, if it's empty.
+	JoinMatchID string
+	// BotDifficulty starts a match against bots right away, if it's set.
+	BotDifficulty domain.BotDifficulty
+	// CreateRoom asks for a new private room, RoomCode joins the existing one.
+	CreateRoom   bool
+	RoomCode     string
+	RoomPassword string
+	// Ranked waits in the matchmaking queue for opponents of a close rating.
+	Ranked bool
+}
+
+// ConnectFunc connects to the server.
+type ConnectFunc func(ip net.IP, opts ConnectOptions)
 
 type MainMenuView struct {
-	ConnectFunc ConnectFunc
+	ConnectFunc     ConnectFunc
+	LobbyFunc       func(ip net.IP)
+	LeaderboardFunc func(ip net.IP)
+	OfflineFunc     func()
+	ReplaysFunc     func()
 
-	IPv4Error     error
-	ipv4InputView *IPv4InputView
-	connectButton *ButtonView
+	IPv4Error         error
+	ipv4InputView     *IPv4InputView
+	matchIDInput      textinput.Model
+	roomCodeInput     textinput.Model
+	roomPasswordInput textinput.Model
+	connectButton     *ButtonView
+	createRoomButton  *ButtonView
+	joinRoomButton    *ButtonView
+	lobbyButton       *ButtonView
+	leaderboardButton *ButtonView
+	offlineButton     *ButtonView
+	replaysButton     *ButtonView
+	// focusIdx points to the focused input in the order they are shown.
+	focusIdx int
+	// opponentIdx points to the chosen bot difficulty. Online and ranked opponents follow bots.
+	opponentIdx int
 }
 
 func NewMainMenuView() *MainMenuView {
+	matchIDInput := textinput.New()
+	matchIDInput.Placeholder = "Match ID to spectate..."
+	matchIDInput.CharLimit = 36
+	matchIDInput.Width = 20
+
+	roomCodeInput := textinput.New()
+	roomCodeInput.Placeholder = "Private room code..."
+	roomCodeInput.CharLimit = 6
+	roomCodeInput.Width = 20
+
+	roomPasswordInput := textinput.New()
+	roomPasswordInput.Placeholder = "Room password (optional)..."
+	roomPasswordInput.CharLimit = 32
+	roomPasswordInput.Width = 20
+	roomPasswordInput.EchoMode = textinput.EchoPassword
+
 	return &MainMenuView{
-		ipv4InputView: NewIPv4InputView(),
-		connectButton: NewButtonView("Connect"),
+		ipv4InputView:     NewIPv4InputView(),
+		matchIDInput:      matchIDInput,
+		roomCodeInput:     roomCodeInput,
+		roomPasswordInput: roomPasswordInput,
+		connectButton:     NewButtonView("Connect"),
+		createRoomButton:  NewButtonView("Create private room (Ctrl+N)"),
+		joinRoomButton:    NewButtonView("Join by code (Ctrl+K)"),
+		lobbyButton:       NewButtonView("Browse matches (Ctrl+L)"),
+		leaderboardButton: NewButtonView("Leaderboard (Ctrl+T)"),
+		offlineButton:     NewButtonView("Play vs Computer (Ctrl+P)"),
+		replaysButton:     NewButtonView("Replays (Ctrl+R)"),
+		opponentIdx:       len(domain.BotDifficulties),
 	}
 }
 
 func (v *MainMenuView) Init() tea.Cmd {
 	v.connectButton.SetClickHandler(v.onConnectHandler)
-	return tea.Batch(v.ipv4InputView.Init(), v.connectButton.Init())
+	v.createRoomButton.SetClickHandler(v.onCreateRoomHandler)
+	v.joinRoomButton.SetClickHandler(v.onJoinRoomHandler)
+	v.lobbyButton.SetClickHandler(v.onLobbyHandler)
+	v.leaderboardButton.SetClickHandler(v.onLeaderboardHandler)
+	v.offlineButton.SetClickHandler(v.onOfflineHandler)
+	v.replaysButton.SetClickHandler(v.onReplaysHandler)
+	return tea.Batch(v.ipv4InputView.Init(),
+		v.connectButton.Init(),
+		v.createRoomButton.Init(),
+		v.joinRoomButton.Init(),
+		v.lobbyButton.Init(),
+		v.leaderboardButton.Init(),
+		v.offlineButton.Init(),
+		v.replaysButton.Init())
 }
 
 func (v *MainMenuView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -43,6 +123,22 @@ func (v *MainMenuView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return v, tea.Quit
 		case tea.KeyEnter:
 			v.connectButton.Click()
+		case tea.KeyCtrlN:
+			v.createRoomButton.Click()
+		case tea.KeyCtrlK:
+			v.joinRoomButton.Click()
+		case tea.KeyCtrlL:
+			v.lobbyButton.Click()
+		case tea.KeyCtrlT:
+			v.leaderboardButton.Click()
+		case tea.KeyCtrlP:
+			v.offlineButton.Click()
+		case tea.KeyCtrlR:
+			v.replaysButton.Click()
+		case tea.KeyCtrlB:
+			v.opponentIdx = (v.opponentIdx + 1) % (len(domain.BotDifficulties) + 2)
+		case tea.KeyTab:
+			v.switchInputFocus()
 		}
 	}
 
@@ -50,6 +146,15 @@ func (v *MainMenuView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	_, cmd = v.ipv4InputView.Update(msg)
 	cmds = append(cmds, cmd)
 
+	v.matchIDInput, cmd = v.matchIDInput.Update(msg)
+	cmds = append(cmds, cmd)
+
+	v.roomCodeInput, cmd = v.roomCodeInput.Update(msg)
+	cmds = append(cmds, cmd)
+
+	v.roomPasswordInput, cmd = v.roomPasswordInput.Update(msg)
+	cmds = append(cmds, cmd)
+
 	_, cmd = v.connectButton.Update(msg)
 	cmds = append(cmds, cmd)
 
@@ -58,10 +163,29 @@ func (v *MainMenuView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (v *MainMenuView) FixedUpdate() {
 	v.connectButton.FixedUpdate()
+	v.createRoomButton.FixedUpdate()
+	v.joinRoomButton.FixedUpdate()
+	v.lobbyButton.FixedUpdate()
+	v.leaderboardButton.FixedUpdate()
+	v.offlineButton.FixedUpdate()
+	v.replaysButton.FixedUpdate()
 }
 
 func (v *MainMenuView) View() string {
-	ipv4Input := lipgloss.JoinVertical(lipgloss.Center, inputTextStyle.Render(v.ipv4InputView.View()), v.connectButton.View())
+	ipv4Input := lipgloss.JoinVertical(lipgloss.Center,
+		inputTextStyle.Render(v.ipv4InputView.View()),
+		inputTextStyle.Render(v.matchIDInput.View()),
+		v.opponentView(),
+		v.connectButton.View(),
+		v.lobbyButton.View(),
+		v.leaderboardButton.View(),
+		"",
+		inputTextStyle.Render(v.roomCodeInput.View()),
+		inputTextStyle.Render(v.roomPasswordInput.View()),
+		lipgloss.JoinHorizontal(lipgloss.Center, v.createRoomButton.View(), " ", v.joinRoomButton.View()),
+		"",
+		v.offlineButton.View(),
+		v.replaysButton.View())
 	if v.IPv4Error != nil {
 		err := lipgloss.PlaceVertical(3, lipgloss.Center, v.IPv4Error.Error())
 		ipv4Input = lipgloss.JoinHorizontal(lipgloss.Top, ipv4Input, err)
@@ -71,6 +195,34 @@ func (v *MainMenuView) View() string {
 }
 
 func (v *MainMenuView) onConnectHandler() {
+	v.connect(ConnectOptions{
+		SpectateMatchID: strings.TrimSpace(v.matchIDInput.Value()),
+		BotDifficulty:   v.BotDifficulty(),
+		Ranked:          v.IsRanked(),
+	})
+}
+
+func (v *MainMenuView) onCreateRoomHandler() {
+	v.connect(ConnectOptions{
+		CreateRoom:   true,
+		RoomPassword: v.roomPasswordInput.Value(),
+	})
+}
+
+func (v *MainMenuView) onJoinRoomHandler() {
+	code := strings.TrimSpace(v.roomCodeInput.Value())
+	if code == "" {
+		v.IPv4Error = ErrEmptyRoomCode
+		return
+	}
+
+	v.connect(ConnectOptions{
+		RoomCode:     code,
+		RoomPassword: v.roomPasswordInput.Value(),
+	})
+}
+
+func (v *MainMenuView) connect(opts ConnectOptions) {
 	var ipv4 net.IP
 	ipv4, v.IPv4Error = v.ipv4InputView.IPAddress()
 	if v.IPv4Error != nil {
@@ -78,6 +230,88 @@ func (v *MainMenuView) onConnectHandler() {
 	}
 
 	if v.ConnectFunc != nil {
-		v.ConnectFunc(ipv4)
+		v.ConnectFunc(ipv4, opts)
+	}
+}
+
+// BotDifficulty returns the chosen difficulty of bots, or an empty string for online opponents.
+func (v *MainMenuView) BotDifficulty() domain.BotDifficulty {
+	if v.opponentIdx >= len(domain.BotDifficulties) {
+		return ""
+	}
+	return domain.BotDifficulties[v.opponentIdx]
+}
+
+// IsRanked reports whether the player wants to be matched by rating.
+func (v *MainMenuView) IsRanked() bool {
+	return v.opponentIdx == len(domain.BotDifficulties)+1
+}
+
+func (v *MainMenuView) opponentView() string {
+	opponent := "Online"
+	if difficulty := v.BotDifficulty(); difficulty != "" {
+		opponent = fmt.Sprintf("Bots (%s)", difficulty)
+	} else if v.IsRanked() {
+		opponent = "Ranked"
+	}
+	return fmt.Sprintf("Opponent: %s (Ctrl+B)", opponent)
+}
+
+func (v *MainMenuView) onLobbyHandler() {
+	var ipv4 net.IP
+	ipv4, v.IPv4Error = v.ipv4InputView.IPAddress()
+	if v.IPv4Error != nil {
+		return
+	}
+
+	if v.LobbyFunc != nil {
+		v.LobbyFunc(ipv4)
+	}
+}
+
+func (v *MainMenuView) onLeaderboardHandler() {
+	var ipv4 net.IP
+	ipv4, v.IPv4Error = v.ipv4InputView.IPAddress()
+	if v.IPv4Error != nil {
+		return
+	}
+
+	if v.LeaderboardFunc != nil {
+		v.LeaderboardFunc(ipv4)
+	}
+}
+
+func (v *MainMenuView) onOfflineHandler() {
+	if v.OfflineFunc != nil {
+		v.OfflineFunc()
+	}
+}
+
+func (v *MainMenuView) onReplaysHandler() {
+	if v.ReplaysFunc != nil {
+		v.ReplaysFunc()
+	}
+}
+
+// switchInputFocus moves the focus to the next input: the server IP, the match ID, the room code
+// and the room password.
+func (v *MainMenuView) switchInputFocus() {
+	const inputsCount = 4
+	v.focusIdx = (v.focusIdx + 1) % inputsCount
+
+	v.ipv4InputView.Blur()
+	v.matchIDInput.Blur()
+	v.roomCodeInput.Blur()
+	v.roomPasswordInput.Blur()
+
+	switch v.focusIdx {
+	case 0:
+		v.ipv4InputView.Focus()
+	case 1:
+		v.matchIDInput.Focus()
+	case 2:
+		v.roomCodeInput.Focus()
+	case 3:
+		v.roomPasswordInput.Focus()
 	}
 }
@@ -0,0 +1,238 @@
+package views
+
+import (
+	"strings"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/pkg/math"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	placementTitleStyle = lipgloss.NewStyle().Bold(true)
+)
+
+type PlacementView struct {
+	rules        domain.GameRules
+	ships        []domain.ShipPlacement
+	board        domain.Board
+	cellX, cellY int
+	isHorizontal bool
+	isReady      bool
+	isRerolling  bool
+
+	boardView   *BoardView
+	readyButton *ButtonView
+
+	fleetPlacedHandler func(ships []domain.ShipPlacement)
+	rerollHandler      func()
+}
+
+func NewPlacementView() *PlacementView {
+	return &PlacementView{
+		isHorizontal: true,
+		boardView:    NewBoardView(),
+		readyButton:  NewButtonView("Ready", WithWidth(20)),
+	}
+}
+
+func (v *PlacementView) Init() tea.Cmd {
+	v.readyButton.SetClickHandler(v.onReadyHandler)
+	return tea.Batch(v.boardView.Init(), v.readyButton.Init())
+}
+
+func (v *PlacementView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if v.isReady {
+		return v, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyUp:
+			v.moveCursor(0, -1)
+		case tea.KeyDown:
+			v.moveCursor(0, 1)
+		case tea.KeyLeft:
+			v.moveCursor(-1, 0)
+		case tea.KeyRight:
+			v.moveCursor(1, 0)
+		case tea.KeyBackspace:
+			v.undo()
+		case tea.KeyEnter:
+			if v.IsFleetPlaced() {
+				v.readyButton.Click()
+			} else {
+				v.placeCurrentShip()
+			}
+		case tea.KeyRunes:
+			switch strings.ToLower(string(msg.Runes)) {
+			case "r":
+				v.rotate()
+			case "c":
+				v.clear()
+			case "a":
+				v.reroll()
+			}
+		}
+	}
+
+	return v, nil
+}
+
+func (v *PlacementView) FixedUpdate() {
+	v.readyButton.FixedUpdate()
+}
+
+func (v *PlacementView) View() string {
+	title := placementTitleStyle.Render("PLACE YOUR FLEET")
+
+	var status string
+	switch {
+	case v.isReady:
+		status = highlightAllowedCell.Render(" READY ") + "\n\nWaiting for other players..."
+	case v.isRerolling:
+		status = "Generating a random fleet..."
+	case v.IsFleetPlaced():
+		status = "All ships are placed!\n\n" + v.readyButton.View()
+	default:
+		ship, _ := v.currentShip()
+		status = "Next ship: " + strings.TrimSpace(strings.Repeat(string(domain.Ship)+" ", ship.Length))
+	}
+
+	help := helpStyle.Align(lipgloss.Center).Render("Press ↑ ↓ → ← to Move · R to Rotate · Enter to Place\nBackspace to Undo · C to Clear · A for Random Fleet")
+	return lipgloss.JoinVertical(lipgloss.Center, title, "", v.boardView.View(), "", status, "", help)
+}
+
+func (v *PlacementView) Start(rules domain.GameRules) {
+	v.rules = rules
+	v.ships = nil
+	v.cellX, v.cellY = 0, 0
+	v.isReady = false
+	v.isRerolling = false
+	v.rebuildBoard()
+}
+
+// SetRerolledBoard applies a random fleet generated by the server. Boards received without
+// a reroll request are ignored, so a player doesn't lose manually placed ships.
+func (v *PlacementView) SetRerolledBoard(board domain.Board) {
+	if !v.isRerolling {
+		return
+	}
+
+	v.isRerolling = false
+	v.ships = domain.ExtractShips(board)
+	v.rebuildBoard()
+}
+
+func (v *PlacementView) SetReady() {
+	v.isReady = true
+	v.boardView.SetPreview(nil, false)
+}
+
+func (v *PlacementView) IsReady() bool {
+	return v.isReady
+}
+
+func (v *PlacementView) IsFleetPlaced() bool {
+	return len(v.ships) >= len(v.rules.Fleet)
+}
+
+func (v *PlacementView) SetFleetPlacedHandler(fn func(ships []domain.ShipPlacement)) {
+	v.fleetPlacedHandler = fn
+}
+
+func (v *PlacementView) SetRerollHandler(fn func()) {
+	v.rerollHandler = fn
+}
+
+func (v *PlacementView) currentShip() (domain.ShipPlacement, bool) {
+	if v.IsFleetPlaced() {
+		return domain.ShipPlacement{}, false
+	}
+
+	return domain.ShipPlacement{
+		X:          byte(v.cellX),
+		Y:          byte(v.cellY),
+		Length:     v.rules.Fleet[len(v.ships)],
+		Horizontal: v.isHorizontal,
+	}, true
+}
+
+func (v *PlacementView) moveCursor(dx, dy int) {
+	v.cellX = math.Clamp(v.cellX+dx, 0, v.board.Size()-1)
+	v.cellY = math.Clamp(v.cellY+dy, 0, v.board.Size()-1)
+	v.updatePreview()
+}
+
+func (v *PlacementView) rotate() {
+	v.isHorizontal = !v.isHorizontal
+	v.updatePreview()
+}
+
+func (v *PlacementView) placeCurrentShip() {
+	ship, ok := v.currentShip()
+	if !ok || !domain.PlaceShip(&v.board, ship) {
+		return
+	}
+
+	v.ships = append(v.ships, ship)
+	v.rebuildBoard()
+}
+
+func (v *PlacementView) undo() {
+	if len(v.ships) == 0 {
+		return
+	}
+
+	v.ships = v.ships[:len(v.ships)-1]
+	v.rebuildBoard()
+}
+
+func (v *PlacementView) clear() {
+	v.ships = nil
+	v.rebuildBoard()
+}
+
+func (v *PlacementView) reroll() {
+	if v.isRerolling {
+		return
+	}
+
+	v.isRerolling = true
+	if v.rerollHandler != nil {
+		v.rerollHandler()
+	}
+}
+
+func (v *PlacementView) rebuildBoard() {
+	board := domain.NewBoard(v.rules.BoardSize)
+	for _, ship := range v.ships {
+		domain.PlaceShip(&board, ship)
+	}
+
+	v.board = board
+	v.boardView.SetBoard(board)
+	v.updatePreview()
+}
+
+func (v *PlacementView) updatePreview() {
+	ship, ok := v.currentShip()
+	if !ok {
+		v.boardView.SetPreview(nil, false)
+		return
+	}
+
+	v.boardView.SetPreview(ship.Cells(), domain.CanPlaceShip(&v.board, ship))
+}
+
+func (v *PlacementView) onReadyHandler() {
+	if v.isReady || !v.IsFleetPlaced() {
+		return
+	}
+
+	if v.fleetPlacedHandler != nil {
+		v.fleetPlacedHandler(v.ships)
+	}
+}
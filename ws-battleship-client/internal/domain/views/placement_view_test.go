@@ -0,0 +1,145 @@
+package views
+
+import (
+	"testing"
+	"ws-battleship-shared/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceShips(t *testing.T) {
+	t.Run("place a ship and move to the next one", func(t *testing.T) {
+		// 1. Arrange
+		view := NewPlacementView()
+		view.Start(domain.GameRules{BoardSize: domain.DefaultBoardSize, Fleet: []int{3, 1}})
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		// 3. Assert
+		require.Len(t, view.ships, 1)
+		require.Equal(t, domain.ShipPlacement{X: 0, Y: 0, Length: 3, Horizontal: true}, view.ships[0])
+		require.Equal(t, domain.Ship, view.board.GetCellType(2, 0))
+
+		ship, ok := view.currentShip()
+		require.True(t, ok)
+		require.Equal(t, 1, ship.Length)
+	})
+
+	t.Run("ship cannot be placed next to another ship", func(t *testing.T) {
+		// 1. Arrange
+		view := NewPlacementView()
+		view.Start(domain.GameRules{BoardSize: domain.DefaultBoardSize, Fleet: []int{3, 1}})
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyDown})
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		// 3. Assert
+		require.Lenf(t, view.ships, 1, "second ship touches the first one")
+	})
+
+	t.Run("rotate a ship", func(t *testing.T) {
+		// 1. Arrange
+		view := NewPlacementView()
+		view.Start(domain.GameRules{BoardSize: domain.DefaultBoardSize, Fleet: []int{3}})
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		// 3. Assert
+		require.Len(t, view.ships, 1)
+		require.Equal(t, domain.Ship, view.board.GetCellType(0, 2))
+		require.True(t, view.board.IsCellEmpty(1, 0))
+	})
+
+	t.Run("undo the last placed ship", func(t *testing.T) {
+		// 1. Arrange
+		view := NewPlacementView()
+		view.Start(domain.GameRules{BoardSize: domain.DefaultBoardSize, Fleet: []int{3}})
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+
+		// 3. Assert
+		require.Empty(t, view.ships)
+		require.True(t, view.board.IsCellEmpty(0, 0))
+	})
+}
+
+func TestFleetIsPlaced(t *testing.T) {
+	t.Run("handler is invoked when all ships are placed", func(t *testing.T) {
+		// 1. Arrange
+		var placedShips []domain.ShipPlacement
+		view := NewPlacementView()
+		view.Init()
+		view.SetFleetPlacedHandler(func(ships []domain.ShipPlacement) {
+			placedShips = ships
+		})
+		view.Start(domain.GameRules{BoardSize: domain.DefaultBoardSize, Fleet: []int{1}})
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		// 3. Assert
+		require.Len(t, placedShips, 1)
+	})
+
+	t.Run("ready player cannot change the fleet", func(t *testing.T) {
+		// 1. Arrange
+		view := NewPlacementView()
+		view.Start(domain.GameRules{BoardSize: domain.DefaultBoardSize, Fleet: []int{1, 1}})
+		view.SetReady()
+
+		// 2. Act
+		view.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+		// 3. Assert
+		require.Empty(t, view.ships)
+	})
+}
+
+func TestRerollFleet(t *testing.T) {
+	t.Run("random board is applied only after a reroll request", func(t *testing.T) {
+		// 1. Arrange
+		var rerollRequested bool
+		view := NewPlacementView()
+		view.SetRerollHandler(func() {
+			rerollRequested = true
+		})
+		view.Start(domain.DefaultRules())
+
+		// 2. Act
+		view.SetRerolledBoard(domain.RandomizeBoard(domain.DefaultRules()))
+		require.Emptyf(t, view.ships, "board without reroll request must be ignored")
+
+		view.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+		view.SetRerolledBoard(domain.RandomizeBoard(domain.DefaultRules()))
+
+		// 3. Assert
+		require.True(t, rerollRequested)
+		require.True(t, view.IsFleetPlaced())
+	})
+}
+
+func TestPlacementBoardSize(t *testing.T) {
+	t.Run("board follows the size from the rules", func(t *testing.T) {
+		// 1. Arrange
+		view := NewPlacementView()
+
+		// 2. Act
+		view.Start(domain.Presets[domain.BigOceanPreset])
+		for i := 0; i < 20; i++ {
+			view.Update(tea.KeyMsg{Type: tea.KeyRight})
+		}
+
+		// 3. Assert
+		require.Equal(t, 15, view.board.Size())
+		require.Equal(t, 14, view.cellX)
+	})
+}
@@ -0,0 +1,90 @@
+package views
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var replayListTitleStyle = lipgloss.NewStyle().Bold(true).MarginBottom(1)
+
+// ReplayListView lists journals of recorded matches to pick one for the replay.
+type ReplayListView struct {
+	OpenFunc func(path string)
+	BackFunc func()
+
+	Err      error
+	dir      string
+	paths    []string
+	selected int
+}
+
+func NewReplayListView(dir string) *ReplayListView {
+	return &ReplayListView{dir: dir}
+}
+
+func (v *ReplayListView) Init() tea.Cmd {
+	return nil
+}
+
+func (v *ReplayListView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return v, tea.Quit
+		case tea.KeyEsc:
+			if v.BackFunc != nil {
+				v.BackFunc()
+			}
+		case tea.KeyUp:
+			if v.selected > 0 {
+				v.selected--
+			}
+		case tea.KeyDown:
+			if v.selected < len(v.paths)-1 {
+				v.selected++
+			}
+		case tea.KeyEnter:
+			if len(v.paths) > 0 && v.OpenFunc != nil {
+				v.OpenFunc(v.paths[v.selected])
+			}
+		}
+	}
+	return v, nil
+}
+
+func (v *ReplayListView) FixedUpdate() {
+}
+
+func (v *ReplayListView) View() string {
+	lines := []string{replayListTitleStyle.Render("REPLAYS")}
+	if len(v.paths) == 0 {
+		lines = append(lines, fmt.Sprintf("No replays found in '%s'.", v.dir))
+	}
+
+	for i, path := range v.paths {
+		name := filepath.Base(path)
+		if i == v.selected {
+			name = highlightStyle.Render("> " + name)
+		} else {
+			name = "  " + name
+		}
+		lines = append(lines, name)
+	}
+
+	if v.Err != nil {
+		lines = append(lines, "", highlightForbiddenCell.Render(v.Err.Error()))
+	}
+
+	lines = append(lines, "", helpStyle.Render("Press ↑ ↓ to Navigate\nPress Enter to Watch\nPress Esc to Go Back"))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// SetReplays shows the journals, which are found in the replays directory.
+func (v *ReplayListView) SetReplays(paths []string) {
+	v.paths = paths
+	v.selected = 0
+}
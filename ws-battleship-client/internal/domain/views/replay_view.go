@@ -0,0 +1,142 @@
+package views
+
+import (
+	"fmt"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type ReplayStatus struct {
+	IsPaused   bool
+	Turn       int
+	TurnsCount int
+	ViewName   string
+}
+
+// ReplayView shows the recorded match with the playback controls. The game view is replaced,
+// whenever the replay is rewound.
+type ReplayView struct {
+	TogglePauseFunc func()
+	StepFunc        func(turns int)
+	SeekFunc        func(turn int)
+	SwitchViewFunc  func()
+	BackFunc        func()
+
+	mu       sync.RWMutex
+	gameView *GameView
+	status   ReplayStatus
+}
+
+func NewReplayView() *ReplayView {
+	return &ReplayView{}
+}
+
+func (v *ReplayView) Init() tea.Cmd {
+	if gameView := v.getGameView(); gameView != nil {
+		return gameView.Init()
+	}
+	return nil
+}
+
+func (v *ReplayView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return v, tea.Quit
+		case tea.KeyEsc:
+			if v.BackFunc != nil {
+				v.BackFunc()
+			}
+			return v, nil
+		case tea.KeySpace:
+			if v.TogglePauseFunc != nil {
+				v.TogglePauseFunc()
+			}
+			return v, nil
+		case tea.KeyRight:
+			if v.StepFunc != nil {
+				v.StepFunc(1)
+			}
+			return v, nil
+		case tea.KeyLeft:
+			if v.StepFunc != nil {
+				v.StepFunc(-1)
+			}
+			return v, nil
+		case tea.KeyHome:
+			if v.SeekFunc != nil {
+				v.SeekFunc(0)
+			}
+			return v, nil
+		case tea.KeyEnd:
+			if v.SeekFunc != nil {
+				v.SeekFunc(v.getStatus().TurnsCount + 1)
+			}
+			return v, nil
+		case tea.KeyRunes:
+			if msg.String() == "v" {
+				if v.SwitchViewFunc != nil {
+					v.SwitchViewFunc()
+				}
+				return v, nil
+			}
+		}
+	}
+
+	if gameView := v.getGameView(); gameView != nil {
+		_, cmd := gameView.Update(msg)
+		return v, cmd
+	}
+	return v, nil
+}
+
+func (v *ReplayView) FixedUpdate() {
+}
+
+func (v *ReplayView) View() string {
+	status := v.getStatus()
+
+	playback := highlightAllowedCell.Render(" PLAYING ")
+	if status.IsPaused {
+		playback = highlightForbiddenCell.Render(" PAUSED ")
+	}
+
+	controls := lipgloss.JoinHorizontal(lipgloss.Center,
+		playback,
+		fmt.Sprintf("  TURN %d/%d  ", status.Turn, status.TurnsCount),
+		highlightStyle.Render(" "+status.ViewName+" "))
+	help := helpStyle.Render("Space: Play/Pause  ← →: Step  Home/End: Seek  V: Switch View  Esc: Back")
+
+	var game string
+	if gameView := v.getGameView(); gameView != nil {
+		game = gameView.View()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, game, controls, help)
+}
+
+func (v *ReplayView) SetGameView(gameView *GameView) {
+	v.mu.Lock()
+	v.gameView = gameView
+	v.mu.Unlock()
+}
+
+func (v *ReplayView) SetStatus(status ReplayStatus) {
+	v.mu.Lock()
+	v.status = status
+	v.mu.Unlock()
+}
+
+func (v *ReplayView) getGameView() *GameView {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.gameView
+}
+
+func (v *ReplayView) getStatus() ReplayStatus {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.status
+}
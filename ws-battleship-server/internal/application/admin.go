@@ -0,0 +1,212 @@
+package application
+
+import (
+	"cmp"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"ws-battleship-server/internal/delivery/http/response"
+	"ws-battleship-server/internal/domain"
+)
+
+// Actions recorded into the audit log.
+const (
+	auditDenied       = "denied"
+	auditListMatches  = "list_matches"
+	auditConnections  = "connections"
+	auditCloseMatch   = "close_match"
+	auditKickPlayer   = "kick_player"
+	auditAnnouncement = "announcement"
+)
+
+// closeMatchTimeout limits the wait for the match, which is closed by force.
+const closeMatchTimeout = 5 * time.Second
+
+const (
+	announcementLengthMax = 256
+	// announcementBodyMax leaves room for the JSON around the message.
+	announcementBodyMax = 4 * announcementLengthMax
+)
+
+type AnnouncementRequest struct {
+	Message string `json:"message"`
+}
+
+type AnnouncementResponse struct {
+	// Matches counts matches, which got the announcement.
+	Matches int `json:"matches"`
+}
+
+// authorizeAdmin lets the request through, if it carries the admin token as a bearer token.
+func (a *App) authorizeAdmin(w http.ResponseWriter, r *http.Request) error {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || a.cfg.App.AdminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(a.cfg.App.AdminToken)) != 1 {
+		a.audit(r, auditDenied, r.Method+" "+r.URL.Path, "", ErrUnauthorized)
+		return response.NewHTTPError(http.StatusUnauthorized, ErrUnauthorized)
+	}
+	return nil
+}
+
+// listAdminMatches returns all matches with their players, private, rated and closed ones too.
+func (a *App) listAdminMatches(w http.ResponseWriter, r *http.Request) error {
+	a.mu.RLock()
+	details := make([]domain.MatchDetails, 0, len(a.matches))
+	for _, match := range a.matches {
+		details = append(details, match.Details())
+	}
+	a.mu.RUnlock()
+
+	slices.SortFunc(details, func(lhs, rhs domain.MatchDetails) int {
+		return cmp.Compare(lhs.ID, rhs.ID)
+	})
+
+	a.audit(r, auditListMatches, "", "", nil)
+	response.ResponseWithJSON(w, http.StatusOK, response.Response{
+		Status: http.StatusOK,
+		Data:   details,
+	})
+	return nil
+}
+
+// getConnectionStats shows live connections and how many were rejected by admission control.
+func (a *App) getConnectionStats(w http.ResponseWriter, r *http.Request) error {
+	a.audit(r, auditConnections, "", "", nil)
+	response.ResponseWithJSON(w, http.StatusOK, response.Response{
+		Status: http.StatusOK,
+		Data:   a.wsListener.Stats(),
+	})
+	return nil
+}
+
+func (a *App) closeMatch(w http.ResponseWriter, r *http.Request) error {
+	id := r.PathValue("id")
+
+	a.mu.RLock()
+	match, found := a.matches[id]
+	a.mu.RUnlock()
+
+	if !found {
+		a.audit(r, auditCloseMatch, id, "", domain.ErrMatchNotExist)
+		return response.NewHTTPError(http.StatusNotFound, domain.ErrMatchNotExist)
+	}
+
+	if err := match.TryDispatch(domain.NewCloseMatchCommand(a.logger)); err != nil {
+		a.audit(r, auditCloseMatch, id, "", err)
+		return response.NewHTTPError(dispatchStatus(err), err)
+	}
+
+	// The match is closed apart from the game loop, so the result is awaited here.
+	select {
+	case <-match.Done():
+	case <-time.After(closeMatchTimeout):
+		a.audit(r, auditCloseMatch, id, "", ErrCloseMatchTimeout)
+		return response.NewHTTPError(http.StatusGatewayTimeout, ErrCloseMatchTimeout)
+	}
+
+	if err := match.CloseErr(); err != nil {
+		a.audit(r, auditCloseMatch, id, "", err)
+		return response.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	a.audit(r, auditCloseMatch, id, "", nil)
+	response.ResponseWithStatus(w, http.StatusOK)
+	return nil
+}
+
+func (a *App) kickPlayer(w http.ResponseWriter, r *http.Request) error {
+	playerID := r.PathValue("id")
+
+	match := a.findMatchWithPlayer(playerID)
+	if match == nil {
+		a.audit(r, auditKickPlayer, playerID, "", ErrPlayerNotInAnyMatch)
+		return response.NewHTTPError(http.StatusNotFound, ErrPlayerNotInAnyMatch)
+	}
+
+	if err := match.TryDispatch(domain.NewKickPlayerCommand(playerID)); err != nil {
+		a.audit(r, auditKickPlayer, playerID, "match id="+match.ID(), err)
+		return response.NewHTTPError(dispatchStatus(err), err)
+	}
+
+	a.audit(r, auditKickPlayer, playerID, "match id="+match.ID(), nil)
+	response.ResponseWithStatus(w, http.StatusAccepted)
+	return nil
+}
+
+// announce sends the message into the chat of every match, which isn't closed. Busy matches are
+// skipped, so they aren't counted.
+func (a *App) announce(w http.ResponseWriter, r *http.Request) error {
+	var req AnnouncementRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, announcementBodyMax)).Decode(&req); err != nil {
+		a.audit(r, auditAnnouncement, "", "", ErrInvalidAnnouncement)
+		return response.NewHTTPError(http.StatusBadRequest, ErrInvalidAnnouncement)
+	}
+
+	message := strings.TrimSpace(req.Message)
+	switch {
+	case message == "":
+		a.audit(r, auditAnnouncement, "", "", ErrEmptyAnnouncement)
+		return response.NewHTTPError(http.StatusBadRequest, ErrEmptyAnnouncement)
+	case utf8.RuneCountInString(message) > announcementLengthMax:
+		a.audit(r, auditAnnouncement, "", "", ErrAnnouncementTooLong)
+		return response.NewHTTPError(http.StatusBadRequest, ErrAnnouncementTooLong)
+	}
+
+	var announced int
+	a.mu.RLock()
+	for _, match := range a.matches {
+		if match.TryDispatch(domain.NewAnnounceCommand(message)) == nil {
+			announced++
+		}
+	}
+	a.mu.RUnlock()
+
+	a.audit(r, auditAnnouncement, "", message, nil)
+	response.ResponseWithJSON(w, http.StatusAccepted, response.Response{
+		Status: http.StatusAccepted,
+		Data:   AnnouncementResponse{Matches: announced},
+	})
+	return nil
+}
+
+// dispatchStatus tells the admin why the match didn't take the command.
+func dispatchStatus(err error) int {
+	if errors.Is(err, domain.ErrRoomIsClosed) {
+		return http.StatusGone
+	}
+	return http.StatusConflict
+}
+
+func (a *App) findMatchWithPlayer(playerID string) *domain.Match {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, match := range a.matches {
+		if !match.IsClosed() && match.HasPlayer(playerID) {
+			return match
+		}
+	}
+	return nil
+}
+
+// audit records the admin action. The error is set, if the action was denied or failed.
+func (a *App) audit(r *http.Request, action, target, details string, actionErr error) {
+	entry := domain.AuditEntry{
+		Time:       time.Now().UTC(),
+		Action:     action,
+		Target:     target,
+		Details:    details,
+		RemoteAddr: r.RemoteAddr,
+	}
+	if actionErr != nil {
+		entry.Err = actionErr.Error()
+	}
+
+	if err := a.auditLog.Record(entry); err != nil {
+		a.logger.Errorf("failed to record admin action %s into the audit log: %s", action, err)
+	}
+}
@@ -0,0 +1,15 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUnauthorized        = errors.New("admin token is missing or wrong")
+	ErrInvalidAnnouncement = errors.New("announcement must be a JSON object with a message")
+	ErrEmptyAnnouncement   = errors.New("announcement message is empty")
+	ErrAnnouncementTooLong = fmt.Errorf("announcement message must be at most %d characters", announcementLengthMax)
+	ErrPlayerNotInAnyMatch = errors.New("player isn't connected to any match")
+	ErrCloseMatchTimeout   = errors.New("match didn't close in time")
+)
@@ -0,0 +1,284 @@
+package application
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"ws-battleship-server/internal/config"
+	"ws-battleship-server/internal/delivery/http/routers"
+	"ws-battleship-server/internal/delivery/websocket/handlers"
+	"ws-battleship-server/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testAdminToken = "secret"
+
+func newAdminApp(t *testing.T) (*App, http.Handler, string) {
+	t.Helper()
+
+	auditPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	app, router := newTestApp(t, config.AppConfig{
+		KeepAlivePeriod:       time.Second * 5,
+		RoomCapacityMax:       2,
+		ClientsConnectionsMax: 2,
+		ClientsPerIPMax:       2,
+		AdminToken:            testAdminToken,
+	}, withMatch(), withAuditLog(auditPath))
+	return app, router, auditPath
+}
+
+func newAdminRequest(method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+	return req
+}
+
+func readAuditLog(t *testing.T, path string) []domain.AuditEntry {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var entries []domain.AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry domain.AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuthorizeAdmin(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		authorization string
+		wantCode      int
+	}{
+		{
+			name:     "request without the token is denied",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "request with a wrong token is denied",
+			authorization: "Bearer wrong",
+			wantCode:      http.StatusUnauthorized,
+		},
+		{
+			name:          "token must be a bearer token",
+			authorization: testAdminToken,
+			wantCode:      http.StatusUnauthorized,
+		},
+		{
+			name:          "request with the admin token is allowed",
+			authorization: "Bearer " + testAdminToken,
+			wantCode:      http.StatusOK,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			_, router, auditPath := newAdminApp(t)
+			req := httptest.NewRequest(http.MethodGet, "/admin/matches", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			// 2. Act
+			router.ServeHTTP(rec, req)
+
+			// 3. Assert
+			require.Equal(t, tt.wantCode, rec.Code)
+
+			entries := readAuditLog(t, auditPath)
+			require.Len(t, entries, 1)
+			if tt.wantCode == http.StatusOK {
+				require.Equal(t, auditListMatches, entries[0].Action)
+				require.Empty(t, entries[0].Err)
+			} else {
+				require.Equal(t, auditDenied, entries[0].Action)
+				require.Equal(t, ErrUnauthorized.Error(), entries[0].Err)
+			}
+		})
+	}
+
+	t.Run("admin API is disabled without the token", func(t *testing.T) {
+		// 1. Arrange
+		app, _, _ := newAdminApp(t)
+		app.cfg.App.AdminToken = ""
+		router := routers.NewDefaultRouter(app.logger)
+		app.SetupRoutes(router)
+		rec := httptest.NewRecorder()
+
+		// 2. Act
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/matches", nil))
+
+		// 3. Assert
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestAdminActions(t *testing.T) {
+	t.Run("matches are listed with their state", func(t *testing.T) {
+		// 1. Arrange
+		app, router, _ := newAdminApp(t)
+		rec := httptest.NewRecorder()
+
+		// 2. Act
+		router.ServeHTTP(rec, newAdminRequest(http.MethodGet, "/admin/matches", ""))
+
+		// 3. Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Data []domain.MatchDetails `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Len(t, resp.Data, 1)
+		require.Contains(t, app.matches, resp.Data[0].ID)
+		require.False(t, resp.Data[0].IsClosed)
+		require.Empty(t, resp.Data[0].Players)
+	})
+
+	t.Run("rejected connections are counted by reasons", func(t *testing.T) {
+		// 1. Arrange
+		app, router, _ := newAdminApp(t)
+		app.wsListener.Close()
+		_ = app.wsListener.HandleWebsocketConnection(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ws", nil))
+		rec := httptest.NewRecorder()
+
+		// 2. Act
+		router.ServeHTTP(rec, newAdminRequest(http.MethodGet, "/admin/connections", ""))
+
+		// 3. Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Data handlers.ConnectionStats `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Zero(t, resp.Data.Connections)
+		require.Equal(t, map[handlers.RejectReason]int64{handlers.RejectShutdown: 1}, resp.Data.Rejections)
+	})
+
+	t.Run("match is closed by force", func(t *testing.T) {
+		// 1. Arrange
+		app, router, auditPath := newAdminApp(t)
+		var match *domain.Match
+		for _, m := range app.matches {
+			match = m
+		}
+		rec := httptest.NewRecorder()
+
+		// 2. Act
+		router.ServeHTTP(rec, newAdminRequest(http.MethodDelete, "/admin/matches/"+match.ID(), ""))
+
+		// 3. Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.True(t, match.IsClosed())
+		require.NoError(t, match.CloseErr())
+
+		entries := readAuditLog(t, auditPath)
+		require.Len(t, entries, 1)
+		require.Equal(t, auditCloseMatch, entries[0].Action)
+		require.Equal(t, match.ID(), entries[0].Target)
+	})
+
+	t.Run("closed match is gone", func(t *testing.T) {
+		// 1. Arrange
+		app, router, auditPath := newAdminApp(t)
+		var match *domain.Match
+		for _, m := range app.matches {
+			match = m
+		}
+		require.NoError(t, match.Close())
+		rec := httptest.NewRecorder()
+
+		// 2. Act
+		router.ServeHTTP(rec, newAdminRequest(http.MethodDelete, "/admin/matches/"+match.ID(), ""))
+
+		// 3. Assert
+		require.Equal(t, http.StatusGone, rec.Code)
+
+		entries := readAuditLog(t, auditPath)
+		require.Len(t, entries, 1)
+		require.Equal(t, domain.ErrRoomIsClosed.Error(), entries[0].Err)
+	})
+
+	t.Run("listing is not allowed on the close endpoint", func(t *testing.T) {
+		// 1. Arrange
+		app, router, _ := newAdminApp(t)
+		var match *domain.Match
+		for _, m := range app.matches {
+			match = m
+		}
+		rec := httptest.NewRecorder()
+
+		// 2. Act
+		router.ServeHTTP(rec, newAdminRequest(http.MethodGet, "/admin/matches/"+match.ID(), ""))
+
+		// 3. Assert
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		require.False(t, match.IsClosed())
+	})
+
+	t.Run("unknown match and player aren't found", func(t *testing.T) {
+		for _, target := range []string{"/admin/matches/unknown", "/admin/players/unknown"} {
+			// 1. Arrange
+			_, router, auditPath := newAdminApp(t)
+			rec := httptest.NewRecorder()
+
+			// 2. Act
+			router.ServeHTTP(rec, newAdminRequest(http.MethodDelete, target, ""))
+
+			// 3. Assert
+			require.Equalf(t, http.StatusNotFound, rec.Code, "target %s", target)
+
+			entries := readAuditLog(t, auditPath)
+			require.Len(t, entries, 1)
+			require.NotEmpty(t, entries[0].Err)
+		}
+	})
+
+	t.Run("announcement is sent to every open match", func(t *testing.T) {
+		// 1. Arrange
+		_, router, auditPath := newAdminApp(t)
+		rec := httptest.NewRecorder()
+
+		// 2. Act
+		router.ServeHTTP(rec, newAdminRequest(http.MethodPost, "/admin/announcements", `{"message": " Restart in 5 minutes "}`))
+
+		// 3. Assert
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		var resp struct {
+			Data AnnouncementResponse `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Equal(t, 1, resp.Data.Matches)
+
+		entries := readAuditLog(t, auditPath)
+		require.Len(t, entries, 1)
+		require.Equal(t, auditAnnouncement, entries[0].Action)
+		require.Equal(t, "Restart in 5 minutes", entries[0].Details)
+	})
+
+	t.Run("invalid announcement is rejected", func(t *testing.T) {
+		for _, body := range []string{"", `{"message": "  "}`, `{"message": "` + strings.Repeat("a", announcementLengthMax+1) + `"}`} {
+			// 1. Arrange
+			_, router, _ := newAdminApp(t)
+			rec := httptest.NewRecorder()
+
+			// 2. Act
+			router.ServeHTTP(rec, newAdminRequest(http.MethodPost, "/admin/announcements", body))
+
+			// 3. Assert
+			require.Equalf(t, http.StatusBadRequest, rec.Code, "body %q", body)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"net"
 	"net/http"
 	"sync"
@@ -9,6 +10,7 @@ import (
 	"ws-battleship-server/internal/delivery/http/routers"
 	"ws-battleship-server/internal/delivery/websocket/handlers"
 	"ws-battleship-server/internal/domain"
+	sharedDomain "ws-battleship-shared/domain"
 	"ws-battleship-shared/pkg/logger"
 )
 
@@ -18,19 +20,58 @@ type App struct {
 	wsListener *handlers.WebsocketListener
 	logger     logger.Logger
 
-	mu      sync.RWMutex
-	joinCh  chan *domain.Player
-	matches map[string]*domain.Match
+	mu         sync.RWMutex
+	joinCh     chan *domain.Player
+	spectateCh chan *domain.Spectator
+	matches    map[string]*domain.Match
+
+	profiles domain.ProfileStore
+	history  domain.MatchHistory
+	queue    *domain.MatchmakingQueue
+	// auditLog records actions made through the admin API.
+	auditLog domain.AuditLog
+	// tokens issue session tokens, which clients connect with.
+	tokens *domain.SessionTokens
 }
 
 func NewApp(cfg *config.Config, logger logger.Logger) *App {
 	joinCh := make(chan *domain.Player, cfg.App.ClientsConnectionsMax)
+	spectateCh := make(chan *domain.Spectator, cfg.App.ClientsConnectionsMax)
+	profiles, err := domain.NewFileProfileStore(cfg.App.ProfilesPath)
+	if err != nil {
+		logger.Errorf("profiles won't be saved: %s", err)
+		profiles, _ = domain.NewFileProfileStore("")
+	}
+
+	history, err := domain.NewFileMatchHistory(cfg.App.MatchHistoryPath)
+	if err != nil {
+		logger.Errorf("results of matches won't be saved: %s", err)
+		history, _ = domain.NewFileMatchHistory("")
+	}
+
+	auditLog, err := domain.NewFileAuditLog(cfg.App.AuditLogPath)
+	if err != nil {
+		logger.Errorf("actions of admins won't be recorded: %s", err)
+		auditLog, _ = domain.NewFileAuditLog("")
+	}
+
+	if cfg.App.SessionSecret == "" {
+		logger.Info("session secret isn't set, clients will have to log in again after the restart")
+	}
+	tokens := domain.NewSessionTokens(cfg.App.SessionSecret, cfg.App.SessionTTL)
+
 	return &App{
 		cfg:        cfg,
 		logger:     logger,
-		wsListener: handlers.NewWebsocketListener(&cfg.App, logger, joinCh),
+		wsListener: handlers.NewWebsocketListener(&cfg.App, logger, tokens, joinCh, spectateCh),
 		joinCh:     joinCh,
+		spectateCh: spectateCh,
 		matches:    make(map[string]*domain.Match, cfg.App.ClientsConnectionsMax),
+		profiles:   profiles,
+		history:    history,
+		queue:      domain.NewMatchmakingQueue(&cfg.App, profiles, logger),
+		auditLog:   auditLog,
+		tokens:     tokens,
 	}
 }
 
@@ -46,20 +87,40 @@ func (a *App) Run(ctx context.Context, router routers.Router) {
 		},
 	}
 
-	a.logger.Infof("starting a server :%s", a.cfg.App.Port)
+	tlsConfig, err := a.loadTLSConfig()
+	if err != nil {
+		a.logger.Fatalf("failed to run a server: %s", err)
+	}
+	a.httpServer.TLSConfig = tlsConfig
+
+	a.logger.Infof("starting a server :%s [tls: %t]", a.cfg.App.Port, tlsConfig != nil)
 	go func() {
-		if err := a.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if tlsConfig != nil {
+			err = a.httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = a.httpServer.ListenAndServe()
+		}
+
+		if err != nil && err != http.ErrServerClosed {
 			a.logger.Fatalf("failed to run a server: %s", err)
 		}
 	}()
 
 	var wg sync.WaitGroup
-	wg.Add(1)
+	wg.Add(2)
 	go func() {
 		defer wg.Done()
 		a.handleConnections(ctx)
 	}()
 
+	go func() {
+		defer wg.Done()
+		a.queue.Run(ctx, func(players []*domain.Player) {
+			a.startRatedMatch(ctx, players)
+		})
+	}()
+
 	<-ctx.Done()
 	a.logger.Info("received a signal to shutdown the server")
 	wg.Wait()
@@ -80,16 +141,41 @@ func (a *App) Shutdown() error {
 		}
 	}
 
+	if err := a.history.Close(); err != nil {
+		a.logger.Errorf("failed to close the match history: %s", err)
+	}
+
+	if err := a.auditLog.Close(); err != nil {
+		a.logger.Errorf("failed to close the audit log: %s", err)
+	}
+
 	return a.httpServer.Close()
 }
 
 func (a *App) SetupRoutes(router routers.Router) {
 	router.GET("/ws", a.wsListener.HandleWebsocketConnection)
+	router.POST("/auth/guest", a.loginGuest)
+	router.POST("/auth/refresh", a.refreshSession)
+	router.GET("/matches", a.listMatches)
+	router.GET("/matches/{id}", a.getMatchRecord)
+	router.GET("/players/{handle}/matches", a.listPlayerMatches)
+	router.GET("/leaderboard", a.getLeaderboard)
+
+	if a.cfg.App.AdminToken == "" {
+		a.logger.Info("admin API is disabled, since the admin token isn't set")
+		return
+	}
+
+	router.GET("/admin/matches", a.authorizeAdmin, a.listAdminMatches)
+	router.GET("/admin/connections", a.authorizeAdmin, a.getConnectionStats)
+	router.DELETE("/admin/matches/{id}", a.authorizeAdmin, a.closeMatch)
+	router.DELETE("/admin/players/{id}", a.authorizeAdmin, a.kickPlayer)
+	router.POST("/admin/announcements", a.authorizeAdmin, a.announce)
 }
 
+// handleConnections takes clients from the websocket listener. Channels aren't closed, since
+// the listener sends into them from many handlers, which stop on shutdown by themselves.
 func (r *App) handleConnections(ctx context.Context) {
-	defer close(r.joinCh)
-
 	for {
 		if err := ctx.Err(); err != nil {
 			return
@@ -101,9 +187,33 @@ func (r *App) handleConnections(ctx context.Context) {
 
 		// Register incoming clients, when they establish a connection.
 		case newPlayer, opened := <-r.joinCh:
-			if opened {
+			if !opened {
+				continue
+			}
+
+			r.registerProfile(newPlayer)
+
+			switch {
+			case newPlayer.ResumeToken() != "":
+				r.resumePlayerSession(newPlayer)
+			case newPlayer.BotDifficulty() != "":
+				r.connectPlayerToBots(ctx, newPlayer)
+			case newPlayer.CreatesRoom():
+				r.connectPlayerToNewPrivateRoom(ctx, newPlayer)
+			case newPlayer.RoomCode() != "":
+				r.connectPlayerToPrivateRoom(newPlayer)
+			case newPlayer.MatchID() != "":
+				r.connectPlayerToMatch(newPlayer)
+			case newPlayer.IsRanked():
+				r.queue.Enqueue(ctx, newPlayer)
+			default:
 				r.connectPlayerToFreeRoom(ctx, newPlayer)
 			}
+
+		case newSpectator, opened := <-r.spectateCh:
+			if opened {
+				r.connectSpectatorToMatch(newSpectator)
+			}
 		}
 	}
 }
@@ -111,12 +221,173 @@ func (r *App) handleConnections(ctx context.Context) {
 func (r *App) connectPlayerToFreeRoom(ctx context.Context, newPlayer *domain.Player) {
 	match := r.findFreeMatch()
 	if match == nil {
-		match = r.createNewMatch(ctx)
+		match = domain.NewMatch(ctx, r.cfg, r.logger)
+		// Bots take free seats, if nobody else joins in time.
+		match.SetBotFill(r.cfg.Game.BotWait, r.cfg.Game.BotDifficulty)
+		r.addMatch(match)
+	}
+
+	match.Dispatch(domain.NewJoinCommand(r.logger, newPlayer))
+}
+
+// startRatedMatch starts a new match for players, who were matched by the queue.
+func (r *App) startRatedMatch(ctx context.Context, players []*domain.Player) {
+	match := domain.NewMatch(ctx, r.cfg, r.logger)
+	match.SetRated()
+	r.addMatch(match)
+
+	for _, player := range players {
+		match.Dispatch(domain.NewJoinCommand(r.logger, player))
+	}
+}
+
+// connectPlayerToBots starts a new match, in which all opponents are bots.
+func (r *App) connectPlayerToBots(ctx context.Context, newPlayer *domain.Player) {
+	difficulty := newPlayer.BotDifficulty()
+	if _, err := sharedDomain.NewBotStrategy(difficulty); err != nil {
+		r.logger.Errorf("player %s asked for bots: %s", newPlayer, err)
+		difficulty = r.cfg.Game.BotDifficulty
+	}
+
+	match := r.createNewMatch(ctx)
+	match.Dispatch(domain.NewJoinCommand(r.logger, newPlayer))
+	match.Dispatch(domain.NewFillWithBotsCommand(r.logger, difficulty))
+}
+
+// connectPlayerToNewPrivateRoom creates a private room, which the player shares by its code.
+func (r *App) connectPlayerToNewPrivateRoom(ctx context.Context, newPlayer *domain.Player) {
+	match := domain.NewMatch(ctx, r.cfg, r.logger)
+	match.SetPrivate(r.newRoomCode(), newPlayer.RoomPassword())
+	r.addMatch(match)
+
+	match.Dispatch(domain.NewJoinCommand(r.logger, newPlayer))
+}
+
+func (r *App) connectPlayerToPrivateRoom(newPlayer *domain.Player) {
+	match := r.findMatchByCode(newPlayer.RoomCode())
+
+	err := domain.ErrMatchNotExist
+	if match != nil {
+		if err = match.CheckPassword(newPlayer.RoomPassword()); err == nil {
+			err = match.CheckIsAvailableForJoin()
+		}
+	}
+
+	if err != nil {
+		r.logger.Errorf("player %s failed to join private room code=%s: %s", newPlayer, newPlayer.RoomCode(), err)
+		newPlayer.Close()
+		return
 	}
 
 	match.Dispatch(domain.NewJoinCommand(r.logger, newPlayer))
 }
 
+// connectPlayerToMatch joins the player to the match picked in the lobby.
+func (r *App) connectPlayerToMatch(newPlayer *domain.Player) {
+	r.mu.RLock()
+	match, found := r.matches[newPlayer.MatchID()]
+	r.mu.RUnlock()
+
+	err := domain.ErrMatchNotExist
+	if found && !match.IsPrivate() && !match.IsRated() {
+		err = match.CheckIsAvailableForJoin()
+	}
+
+	if err != nil {
+		r.logger.Errorf("player %s failed to join match id=%s: %s", newPlayer, newPlayer.MatchID(), err)
+		newPlayer.Close()
+		return
+	}
+
+	match.Dispatch(domain.NewJoinCommand(r.logger, newPlayer))
+}
+
+func (r *App) connectSpectatorToMatch(newSpectator *domain.Spectator) {
+	r.mu.RLock()
+	match, found := r.matches[newSpectator.MatchID()]
+	r.mu.RUnlock()
+
+	if !found {
+		r.logger.Errorf("spectator %s failed to join match id=%s: %s", newSpectator, newSpectator.MatchID(), domain.ErrMatchNotExist)
+		newSpectator.Close()
+		return
+	}
+
+	match.Dispatch(domain.NewJoinSpectatorCommand(r.logger, newSpectator))
+}
+
+// registerProfile creates the profile on the first connection of the player and keeps its
+// nickname up to date.
+func (r *App) registerProfile(player *domain.Player) {
+	if player.ProfileID() == "" {
+		return
+	}
+
+	profile, err := r.profiles.Profile(player.ProfileID())
+	switch {
+	case errors.Is(err, domain.ErrProfileNotExist):
+		profile = player.NewProfile()
+	case err != nil:
+		r.logger.Errorf("failed to get the profile of player %s: %s", player, err)
+		return
+	case profile.Nickname == player.Nickname():
+		return
+	default:
+		profile.Nickname = player.Nickname()
+	}
+
+	if err := r.profiles.SaveProfile(profile); err != nil {
+		r.logger.Errorf("failed to save the profile of player %s: %s", player, err)
+	}
+}
+
+func (r *App) resumePlayerSession(newPlayer *domain.Player) {
+	match := r.findMatchAwaitingPlayer(newPlayer.ResumeToken())
+	if match == nil {
+		r.logger.Errorf("player %s failed to resume the session: %s", newPlayer, domain.ErrInvalidResumeToken)
+		newPlayer.Close()
+		return
+	}
+
+	match.Dispatch(domain.NewResumePlayerCommand(r.logger, newPlayer))
+}
+
+func (r *App) findMatchAwaitingPlayer(resumeToken string) *domain.Match {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, match := range r.matches {
+		if match.IsAwaitingPlayer(resumeToken) {
+			return match
+		}
+	}
+	return nil
+}
+
+func (r *App) findMatchByCode(code string) *domain.Match {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, match := range r.matches {
+		if match.IsPrivate() && match.Code() == code {
+			return match
+		}
+	}
+	return nil
+}
+
+// newRoomCode returns a code, which isn't taken by any private room.
+func (r *App) newRoomCode() string {
+	for {
+		code := domain.NewRoomCode()
+		if r.findMatchByCode(code) == nil {
+			return code
+		}
+	}
+}
+
+// findFreeMatch skips private rooms, since they are joined by the code only, and rated matches,
+// since they are filled by the matchmaking queue.
 func (r *App) findFreeMatch() *domain.Match {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -126,7 +397,7 @@ func (r *App) findFreeMatch() *domain.Match {
 	}
 
 	for _, match := range r.matches {
-		if match.CheckIsAvailableForJoin() == nil {
+		if !match.IsPrivate() && !match.IsRated() && match.CheckIsAvailableForJoin() == nil {
 			return match
 		}
 	}
@@ -135,11 +406,17 @@ func (r *App) findFreeMatch() *domain.Match {
 
 func (r *App) createNewMatch(ctx context.Context) *domain.Match {
 	match := domain.NewMatch(ctx, r.cfg, r.logger)
+	r.addMatch(match)
+	return match
+}
+
+func (r *App) addMatch(match *domain.Match) {
+	match.SetProfileStore(r.profiles)
+	match.SetMatchHistory(r.history)
 
 	r.mu.Lock()
 	r.matches[match.ID()] = match
 	r.mu.Unlock()
 
-	r.logger.Infof("new match with id=%s was created [rooms: %d]", match.ID(), len(r.matches))
-	return match
+	r.logger.Infof("new match with id=%s and seed=%d was created [rooms: %d]", match.ID(), match.Seed(), len(r.matches))
 }
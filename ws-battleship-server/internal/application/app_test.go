@@ -1,14 +1,102 @@
 package application
 
 import (
+	"net/http"
 	"testing"
 	"time"
 	"ws-battleship-server/internal/config"
+	"ws-battleship-server/internal/delivery/http/routers"
+	"ws-battleship-server/internal/delivery/websocket/handlers"
 	"ws-battleship-server/internal/domain"
+	sharedDomain "ws-battleship-shared/domain"
+	"ws-battleship-shared/pkg/logger"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+// testSessionTTL is long enough for sessions not to expire during the test.
+const testSessionTTL = time.Minute
+
+// testAppOption adds a part of the app, which the test needs.
+type testAppOption = func(t *testing.T, app *App)
+
+// newTestApp builds the app with routes and a logger, which accepts any message.
+func newTestApp(t *testing.T, cfg config.AppConfig, opts ...testAppOption) (*App, http.Handler) {
+	t.Helper()
+
+	loggerMock := new(logger.MockLogger)
+	for _, method := range []string{"Info", "Infof", "Error", "Errorf"} {
+		loggerMock.On(method, mock.Anything).Maybe()
+		loggerMock.On(method, mock.Anything, mock.Anything).Maybe()
+	}
+
+	appCfg := &config.Config{App: cfg}
+	tokens := domain.NewSessionTokens("secret", testSessionTTL)
+	joinCh := make(chan *domain.Player, cfg.ClientsConnectionsMax)
+	spectateCh := make(chan *domain.Spectator, cfg.ClientsConnectionsMax)
+
+	app := &App{
+		cfg:        appCfg,
+		logger:     loggerMock,
+		wsListener: handlers.NewWebsocketListener(&appCfg.App, loggerMock, tokens, joinCh, spectateCh),
+		matches:    make(map[string]*domain.Match),
+		joinCh:     joinCh,
+		spectateCh: spectateCh,
+		tokens:     tokens,
+	}
+	for _, opt := range opts {
+		opt(t, app)
+	}
+
+	router := routers.NewDefaultRouter(loggerMock)
+	app.SetupRoutes(router)
+	return app, router
+}
+
+// withMatch adds the empty match, which is closed after the test.
+func withMatch() testAppOption {
+	return func(t *testing.T, app *App) {
+		match := domain.NewMatch(t.Context(), app.cfg, app.logger)
+		t.Cleanup(func() { _ = match.Close() })
+		app.matches[match.ID()] = match
+	}
+}
+
+// withAuditLog writes admin actions into the file.
+func withAuditLog(path string) testAppOption {
+	return func(t *testing.T, app *App) {
+		auditLog, err := domain.NewFileAuditLog(path)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = auditLog.Close() })
+		app.auditLog = auditLog
+	}
+}
+
+// withProfiles keeps profiles in memory.
+func withProfiles(profiles ...sharedDomain.Profile) testAppOption {
+	return func(t *testing.T, app *App) {
+		store, err := domain.NewFileProfileStore("")
+		require.NoError(t, err)
+		for _, profile := range profiles {
+			require.NoError(t, store.SaveProfile(profile))
+		}
+		app.profiles = store
+	}
+}
+
+// withHistory keeps finished matches in memory.
+func withHistory(records ...sharedDomain.MatchRecord) testAppOption {
+	return func(t *testing.T, app *App) {
+		history, err := domain.NewFileMatchHistory("")
+		require.NoError(t, err)
+		for _, record := range records {
+			require.NoError(t, history.SaveMatch(record))
+		}
+		app.history = history
+	}
+}
+
 func TestFindFreeMatch(t *testing.T) {
 	t.Run("cannot find a free match if there are no matches at all", func(t *testing.T) {
 		// 1. Arrange
@@ -110,3 +198,90 @@ func TestFindFreeMatch(t *testing.T) {
 		require.Equal(t, newMatch3.ID(), got.ID())
 	})
 }
+
+func TestPrivateRooms(t *testing.T) {
+	newMatch := func(t *testing.T) *domain.Match {
+		return domain.NewMatch(t.Context(), &config.Config{
+			App: config.AppConfig{
+				KeepAlivePeriod: time.Second * 5,
+				RoomCapacityMax: 2,
+			},
+		}, nil)
+	}
+
+	t.Run("private room is skipped by matchmaking", func(t *testing.T) {
+		// 1. Arrange
+		privateMatch := newMatch(t)
+		privateMatch.SetPrivate("ABC234", "")
+
+		app := App{
+			matches: map[string]*domain.Match{
+				privateMatch.ID(): privateMatch,
+			},
+		}
+
+		// 2. Act
+		got := app.findFreeMatch()
+
+		// 3. Assert
+		require.Nil(t, got)
+	})
+
+	t.Run("private room is found by its code", func(t *testing.T) {
+		// 1. Arrange
+		publicMatch := newMatch(t)
+		privateMatch := newMatch(t)
+		privateMatch.SetPrivate("ABC234", "secret")
+
+		app := App{
+			matches: map[string]*domain.Match{
+				publicMatch.ID():  publicMatch,
+				privateMatch.ID(): privateMatch,
+			},
+		}
+
+		// 2. Act
+		got := app.findMatchByCode(domain.NormalizeRoomCode(" abc234 "))
+		notFound := app.findMatchByCode("ZZZZZZ")
+
+		// 3. Assert
+		require.NotNil(t, got)
+		require.Equal(t, privateMatch.ID(), got.ID())
+		require.Nil(t, notFound)
+		require.ErrorIs(t, got.CheckPassword("wrong"), domain.ErrWrongRoomPassword)
+		require.NoError(t, got.CheckPassword("secret"))
+	})
+}
+
+func TestOpenMatches(t *testing.T) {
+	// 1. Arrange
+	cfg := &config.Config{
+		App: config.AppConfig{
+			KeepAlivePeriod: time.Second * 5,
+			RoomCapacityMax: 2,
+		},
+	}
+	publicMatch := domain.NewMatch(t.Context(), cfg, nil)
+	privateMatch := domain.NewMatch(t.Context(), cfg, nil)
+	privateMatch.SetPrivate("ABC234", "")
+	ratedMatch := domain.NewMatch(t.Context(), cfg, nil)
+	ratedMatch.SetRated()
+
+	app := App{
+		matches: map[string]*domain.Match{
+			publicMatch.ID():  publicMatch,
+			privateMatch.ID(): privateMatch,
+			ratedMatch.ID():   ratedMatch,
+		},
+	}
+
+	// 2. Act
+	got := app.openMatches()
+
+	// 3. Assert
+	require.Len(t, got, 1)
+	require.Equal(t, publicMatch.ID(), got[0].ID)
+	require.Equal(t, 2, got[0].Capacity)
+	require.Empty(t, got[0].Players)
+	require.True(t, got[0].IsJoinable())
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+	"ws-battleship-server/internal/delivery/http/response"
+	server "ws-battleship-server/internal/domain"
+	"ws-battleship-shared/domain"
+)
+
+const (
+	nicknameLengthMax  = 32
+	profileIDLengthMax = 64
+	loginBodyMax       = 1 << 10
+)
+
+// loginGuest starts a new session. The client connects with the issued token and refreshes it,
+// before it expires. The profile is logged into by its secret only, otherwise a new one is created.
+func (a *App) loginGuest(w http.ResponseWriter, r *http.Request) error {
+	var req domain.LoginRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, loginBodyMax)).Decode(&req); err != nil {
+		return response.NewHTTPError(http.StatusBadRequest, ErrInvalidLogin)
+	}
+
+	nickname := strings.TrimSpace(req.Nickname)
+	switch {
+	case nickname == "":
+		return response.NewHTTPError(http.StatusBadRequest, ErrEmptyNickname)
+	case utf8.RuneCountInString(nickname) > nicknameLengthMax:
+		return response.NewHTTPError(http.StatusBadRequest, ErrNicknameTooLong)
+	case len(req.ProfileID) > profileIDLengthMax:
+		return response.NewHTTPError(http.StatusBadRequest, ErrInvalidProfileID)
+	}
+
+	profile, credential, err := a.loginProfile(req, nickname)
+	switch {
+	case errors.Is(err, server.ErrInvalidProfileSecret):
+		return response.NewHTTPError(http.StatusUnauthorized, err)
+	case err != nil:
+		return err
+	}
+
+	token, err := a.tokens.Issue(nickname, profile.ID)
+	if err != nil {
+		return err
+	}
+	token.Profile = credential
+
+	a.logger.Infof("client id=%s logged in as '%s'", token.ClientID, nickname)
+	response.ResponseWithJSON(w, http.StatusCreated, response.Response{
+		Status: http.StatusCreated,
+		Data:   token,
+	})
+	return nil
+}
+
+// loginProfile checks the secret of the profile. The credential is returned for a new profile only,
+// since its secret is given out once.
+func (a *App) loginProfile(req domain.LoginRequest, nickname string) (domain.Profile, *domain.ProfileCredential, error) {
+	if req.ProfileID != "" {
+		profile, err := a.profiles.Authenticate(req.ProfileID, req.ProfileSecret)
+		return profile, nil, err
+	}
+
+	profile, secret, err := a.profiles.CreateProfile(nickname)
+	if err != nil {
+		return domain.Profile{}, nil, err
+	}
+	return profile, &domain.ProfileCredential{ID: profile.ID, Secret: secret, Handle: profile.Handle}, nil
+}
+
+// refreshSession extends the session of the bearer token, which must not be expired yet.
+func (a *App) refreshSession(w http.ResponseWriter, r *http.Request) error {
+	metadata := domain.ParseClientMetadataFromHeaders(r)
+
+	token, err := a.tokens.Refresh(metadata.SessionToken)
+	switch {
+	case errors.Is(err, server.ErrInvalidSessionToken), errors.Is(err, server.ErrSessionExpired):
+		return response.NewHTTPError(http.StatusUnauthorized, err)
+	case err != nil:
+		return err
+	}
+
+	response.ResponseWithJSON(w, http.StatusOK, response.Response{
+		Status: http.StatusOK,
+		Data:   token,
+	})
+	return nil
+}
@@ -0,0 +1,13 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidLogin     = errors.New("login must be a JSON object with a nickname")
+	ErrEmptyNickname    = errors.New("nickname is empty")
+	ErrNicknameTooLong  = fmt.Errorf("nickname must be at most %d characters", nicknameLengthMax)
+	ErrInvalidProfileID = fmt.Errorf("profile ID must be at most %d characters", profileIDLengthMax)
+)
@@ -0,0 +1,214 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"ws-battleship-server/internal/config"
+	"ws-battleship-shared/domain"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthApp(t *testing.T) (*App, http.Handler) {
+	t.Helper()
+
+	return newTestApp(t, config.AppConfig{
+		ClientsConnectionsMax: 2,
+		ClientsPerIPMax:       2,
+		MessageBytesMax:       1 << 10,
+		SessionTTL:            testSessionTTL,
+	}, withProfiles())
+}
+
+func login(t *testing.T, router http.Handler, body string) (domain.SessionToken, int) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/auth/guest", strings.NewReader(body)))
+
+	var resp struct {
+		Data domain.SessionToken `json:"data"`
+	}
+	if rec.Code == http.StatusCreated {
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	}
+	return resp.Data, rec.Code
+}
+
+func TestLoginGuest(t *testing.T) {
+	t.Run("guest gets a signed token and a new profile", func(t *testing.T) {
+		// 1. Arrange
+		app, router := newAuthApp(t)
+
+		// 2. Act
+		token, code := login(t, router, `{"nickname": " player "}`)
+
+		// 3. Assert
+		require.Equal(t, http.StatusCreated, code)
+		require.Equal(t, "player", token.Nickname)
+		require.NotNil(t, token.Profile)
+		require.NotEmpty(t, token.Profile.Secret)
+		require.NotEmpty(t, token.Profile.Handle)
+
+		claims, err := app.tokens.Verify(token.Token)
+		require.NoError(t, err)
+		require.Equal(t, token.ClientID, claims.ClientID)
+		require.Equal(t, token.Profile.ID, claims.ProfileID)
+	})
+
+	t.Run("profile is logged into by its secret", func(t *testing.T) {
+		// 1. Arrange
+		app, router := newAuthApp(t)
+		created, _ := login(t, router, `{"nickname": "player"}`)
+
+		// 2. Act
+		token, code := login(t, router, fmt.Sprintf(`{"nickname": "player", "profile_id": %q, "profile_secret": %q}`, created.Profile.ID, created.Profile.Secret))
+
+		// 3. Assert
+		require.Equal(t, http.StatusCreated, code)
+		require.Nilf(t, token.Profile, "secret must be given out once")
+
+		claims, err := app.tokens.Verify(token.Token)
+		require.NoError(t, err)
+		require.Equal(t, created.Profile.ID, claims.ProfileID)
+	})
+
+	t.Run("profile isn't logged into without its secret", func(t *testing.T) {
+		// 1. Arrange
+		_, router := newAuthApp(t)
+		created, _ := login(t, router, `{"nickname": "victim"}`)
+
+		for _, body := range []string{
+			fmt.Sprintf(`{"nickname": "player", "profile_id": %q}`, created.Profile.ID),
+			fmt.Sprintf(`{"nickname": "player", "profile_id": %q, "profile_secret": "forged"}`, created.Profile.ID),
+			fmt.Sprintf(`{"nickname": "player", "profile_id": "unknown", "profile_secret": %q}`, created.Profile.Secret),
+		} {
+			// 2. Act
+			_, code := login(t, router, body)
+
+			// 3. Assert
+			require.Equalf(t, http.StatusUnauthorized, code, "body %s", body)
+		}
+	})
+
+	t.Run("invalid login is rejected", func(t *testing.T) {
+		for _, body := range []string{"", `{"nickname": "  "}`, `{"nickname": "` + strings.Repeat("a", nicknameLengthMax+1) + `"}`} {
+			// 1. Arrange
+			_, router := newAuthApp(t)
+
+			// 2. Act
+			_, code := login(t, router, body)
+
+			// 3. Assert
+			require.Equalf(t, http.StatusBadRequest, code, "body %q", body)
+		}
+	})
+
+	t.Run("token is refreshed with the same client ID", func(t *testing.T) {
+		// 1. Arrange
+		_, router := newAuthApp(t)
+		token, _ := login(t, router, `{"nickname": "player"}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+		req.Header.Set("Authorization", "Bearer "+token.Token)
+		rec := httptest.NewRecorder()
+
+		// 2. Act
+		router.ServeHTTP(rec, req)
+
+		// 3. Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Data domain.SessionToken `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Equal(t, token.ClientID, resp.Data.ClientID)
+	})
+
+	t.Run("forged token isn't refreshed", func(t *testing.T) {
+		// 1. Arrange
+		_, router := newAuthApp(t)
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+		req.Header.Set("Authorization", "Bearer forged.token")
+		rec := httptest.NewRecorder()
+
+		// 2. Act
+		router.ServeHTTP(rec, req)
+
+		// 3. Assert
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestWebsocketSession(t *testing.T) {
+	dial := func(t *testing.T, url string, metadata domain.ClientMetadata) (*websocket.Conn, int) {
+		t.Helper()
+
+		conn, resp, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(url, "http")+"/ws", domain.ParseClientMetadataToHeaders(metadata))
+		if err != nil {
+			require.NotNil(t, resp, err)
+			return nil, resp.StatusCode
+		}
+		t.Cleanup(func() { _ = conn.Close() })
+		return conn, resp.StatusCode
+	}
+
+	t.Run("client without a valid token isn't upgraded", func(t *testing.T) {
+		// 1. Arrange
+		_, router := newAuthApp(t)
+		srv := httptest.NewServer(router)
+		defer srv.Close()
+
+		for _, token := range []string{"", "forged.token"} {
+			// 2. Act
+			_, code := dial(t, srv.URL, domain.ClientMetadata{ClientID: "1", Nickname: "player", SessionToken: token})
+
+			// 3. Assert
+			require.Equalf(t, http.StatusUnauthorized, code, "token %q", token)
+		}
+	})
+
+	t.Run("identity is taken from the token, not from headers", func(t *testing.T) {
+		// 1. Arrange
+		app, router := newAuthApp(t)
+		srv := httptest.NewServer(router)
+		defer srv.Close()
+		token, _ := login(t, router, `{"nickname": "player"}`)
+
+		// 2. Act
+		_, code := dial(t, srv.URL, domain.ClientMetadata{ClientID: "victim", Nickname: "victim", SessionToken: token.Token})
+
+		// 3. Assert
+		require.Equal(t, http.StatusSwitchingProtocols, code)
+		player := <-app.joinCh
+		require.Equal(t, token.ClientID, player.ID())
+		require.Equal(t, "player", player.Nickname())
+	})
+
+	t.Run("second live session of the client is refused", func(t *testing.T) {
+		// 1. Arrange
+		app, router := newAuthApp(t)
+		srv := httptest.NewServer(router)
+		defer srv.Close()
+		token, _ := login(t, router, `{"nickname": "player"}`)
+		metadata := domain.ClientMetadata{SessionToken: token.Token}
+
+		_, code := dial(t, srv.URL, metadata)
+		require.Equal(t, http.StatusSwitchingProtocols, code)
+		player := <-app.joinCh
+
+		// 2. Act
+		_, duplicateCode := dial(t, srv.URL, metadata)
+		player.Close()
+		_, reconnectCode := dial(t, srv.URL, metadata)
+
+		// 3. Assert
+		require.Equal(t, http.StatusConflict, duplicateCode)
+		require.Equal(t, http.StatusSwitchingProtocols, reconnectCode)
+	})
+}
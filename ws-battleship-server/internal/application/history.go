@@ -0,0 +1,128 @@
+package application
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+	"ws-battleship-server/internal/delivery/http/response"
+	"ws-battleship-shared/domain"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// getLeaderboard ranks players, who have played at least once. It's sorted by the rating, unless
+// the sort is given.
+func (a *App) getLeaderboard(w http.ResponseWriter, r *http.Request) error {
+	sortBy := cmp.Or(r.URL.Query().Get("sort"), domain.SortByRating)
+	if !slices.Contains(domain.LeaderboardSorts, sortBy) {
+		return response.NewHTTPError(http.StatusBadRequest, ErrUnknownSort)
+	}
+
+	page, pageSize, err := parsePage(r)
+	if err != nil {
+		return err
+	}
+
+	response.ResponseWithJSON(w, http.StatusOK, response.Response{
+		Status: http.StatusOK,
+		Data:   paginate(a.leaderboard(sortBy), page, pageSize),
+	})
+	return nil
+}
+
+func (a *App) leaderboard(sortBy domain.LeaderboardSort) []domain.LeaderboardEntry {
+	profiles := slices.DeleteFunc(a.profiles.Profiles(), func(profile domain.Profile) bool {
+		return profile.Matches() == 0
+	})
+
+	// Profiles come sorted by IDs, so ties keep the same order between requests.
+	slices.SortStableFunc(profiles, func(lhs, rhs domain.Profile) int {
+		switch sortBy {
+		case domain.SortByWins:
+			return cmp.Compare(rhs.Wins, lhs.Wins)
+		case domain.SortByWinRate:
+			return cmp.Compare(rhs.WinRate(), lhs.WinRate())
+		default:
+			return cmp.Compare(rhs.Rating, lhs.Rating)
+		}
+	})
+
+	entries := make([]domain.LeaderboardEntry, 0, len(profiles))
+	for i, profile := range profiles {
+		entries = append(entries, domain.LeaderboardEntry{
+			Rank:     i + 1,
+			Profile:  profile.Public(),
+			WinRate:  profile.WinRate(),
+			Accuracy: profile.Accuracy(),
+		})
+	}
+	return entries
+}
+
+// listPlayerMatches returns finished matches of the player, the latest go first. The player is
+// found by the handle of the profile.
+func (a *App) listPlayerMatches(w http.ResponseWriter, r *http.Request) error {
+	page, pageSize, err := parsePage(r)
+	if err != nil {
+		return err
+	}
+
+	response.ResponseWithJSON(w, http.StatusOK, response.Response{
+		Status: http.StatusOK,
+		Data:   paginate(a.history.PlayerMatches(r.PathValue("handle")), page, pageSize),
+	})
+	return nil
+}
+
+func (a *App) getMatchRecord(w http.ResponseWriter, r *http.Request) error {
+	record, found := a.history.Match(r.PathValue("id"))
+	if !found {
+		return response.NewHTTPError(http.StatusNotFound, ErrMatchRecordNotExist)
+	}
+
+	response.ResponseWithJSON(w, http.StatusOK, response.Response{
+		Status: http.StatusOK,
+		Data:   record,
+	})
+	return nil
+}
+
+// parsePage reads the page number, which starts from 1, and its size from the query.
+func parsePage(r *http.Request) (page, pageSize int, err error) {
+	query := r.URL.Query()
+
+	page = 1
+	if value := query.Get("page"); value != "" {
+		if page, err = strconv.Atoi(value); err != nil || page < 1 {
+			return 0, 0, response.NewHTTPError(http.StatusBadRequest, ErrInvalidPage)
+		}
+	}
+
+	pageSize = defaultPageSize
+	if value := query.Get("page_size"); value != "" {
+		if pageSize, err = strconv.Atoi(value); err != nil || pageSize < 1 || pageSize > maxPageSize {
+			return 0, 0, response.NewHTTPError(http.StatusBadRequest, ErrInvalidPageSize)
+		}
+	}
+	return page, pageSize, nil
+}
+
+func paginate[T any](items []T, page, pageSize int) domain.Page[T] {
+	// Pages past the end are empty, the check keeps far pages from overflowing.
+	start := len(items)
+	if page-1 <= len(items)/pageSize {
+		start = min((page-1)*pageSize, len(items))
+	}
+	end := min(start+pageSize, len(items))
+
+	return domain.Page[T]{
+		Items:    append([]T{}, items[start:end]...),
+		Page:     page,
+		PageSize: pageSize,
+		Total:    len(items),
+	}
+}
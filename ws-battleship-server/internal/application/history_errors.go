@@ -0,0 +1,13 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidPage         = errors.New("page must be a positive number")
+	ErrInvalidPageSize     = fmt.Errorf("page size must be between 1 and %d", maxPageSize)
+	ErrUnknownSort         = errors.New("unknown sort of the leaderboard")
+	ErrMatchRecordNotExist = errors.New("match doesn't exist or hasn't ended yet")
+)
@@ -0,0 +1,192 @@
+package application
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"ws-battleship-server/internal/config"
+	"ws-battleship-server/internal/delivery/http/response"
+	sharedDomain "ws-battleship-shared/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newHistoryApp(t *testing.T) *App {
+	t.Helper()
+
+	app, _ := newTestApp(t, config.AppConfig{},
+		withProfiles(
+			sharedDomain.Profile{ID: "1", Handle: "h1", Nickname: "veteran", Wins: 10, Losses: 10, Rating: 1600},
+			sharedDomain.Profile{ID: "2", Handle: "h2", Nickname: "rookie", Wins: 3, Losses: 0, Rating: 1550},
+			sharedDomain.Profile{ID: "3", Handle: "h3", Nickname: "newcomer", Rating: sharedDomain.DefaultRating},
+		),
+		withHistory(
+			sharedDomain.MatchRecord{ID: "a", Winner: "veteran", Participants: []sharedDomain.MatchParticipant{{Handle: "h1", IsWinner: true}, {Handle: "h2"}}},
+			sharedDomain.MatchRecord{ID: "b", Winner: "rookie", Participants: []sharedDomain.MatchParticipant{{Handle: "h2", IsWinner: true}, {Nickname: "bot", IsBot: true}}},
+		),
+	)
+	return app
+}
+
+func decodePage[T any](t *testing.T, rec *httptest.ResponseRecorder) sharedDomain.Page[T] {
+	t.Helper()
+
+	var resp struct {
+		Data sharedDomain.Page[T] `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return resp.Data
+}
+
+func serve(handler func(w http.ResponseWriter, r *http.Request) error, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	if err := handler(rec, req); err != nil {
+		httpErr := err.(*response.HTTPError)
+		response.Error(rec, httpErr, httpErr.Code)
+	}
+	return rec
+}
+
+func TestLeaderboard(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		query       string
+		wantHandles []string
+	}{
+		{
+			name:        "players are ranked by rating by default",
+			wantHandles: []string{"h1", "h2"},
+		},
+		{
+			name:        "players are ranked by wins",
+			query:       "?sort=wins",
+			wantHandles: []string{"h1", "h2"},
+		},
+		{
+			name:        "players are ranked by win rate",
+			query:       "?sort=win_rate",
+			wantHandles: []string{"h2", "h1"},
+		},
+		{
+			name:        "leaderboard is paginated",
+			query:       "?page=2&page_size=1",
+			wantHandles: []string{"h2"},
+		},
+		{
+			name:        "far page is empty",
+			query:       "?page=" + strconv.Itoa(math.MaxInt),
+			wantHandles: []string{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			app := newHistoryApp(t)
+			req := httptest.NewRequest(http.MethodGet, "/leaderboard"+tt.query, nil)
+
+			// 2. Act
+			rec := serve(app.getLeaderboard, req)
+
+			// 3. Assert
+			require.Equal(t, http.StatusOK, rec.Code)
+			page := decodePage[sharedDomain.LeaderboardEntry](t, rec)
+			require.Equalf(t, 2, page.Total, "players without matches aren't ranked")
+
+			gotHandles := make([]string, 0, len(page.Items))
+			for _, entry := range page.Items {
+				gotHandles = append(gotHandles, entry.Profile.Handle)
+			}
+			require.Equal(t, tt.wantHandles, gotHandles)
+		})
+	}
+
+	t.Run("profile IDs aren't published", func(t *testing.T) {
+		// 1. Arrange
+		app := newHistoryApp(t)
+		req := httptest.NewRequest(http.MethodGet, "/leaderboard", nil)
+
+		// 2. Act
+		rec := serve(app.getLeaderboard, req)
+
+		// 3. Assert
+		var resp struct {
+			Data struct {
+				Items []struct {
+					Profile map[string]any `json:"profile"`
+				} `json:"items"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.NotEmpty(t, resp.Data.Items)
+		for _, entry := range resp.Data.Items {
+			require.NotContains(t, entry.Profile, "id")
+		}
+	})
+
+	t.Run("invalid query is rejected", func(t *testing.T) {
+		for _, query := range []string{"?sort=losses", "?page=0", "?page_size=1000", "?page=first"} {
+			// 1. Arrange
+			app := newHistoryApp(t)
+			req := httptest.NewRequest(http.MethodGet, "/leaderboard"+query, nil)
+
+			// 2. Act
+			rec := serve(app.getLeaderboard, req)
+
+			// 3. Assert
+			require.Equalf(t, http.StatusBadRequest, rec.Code, "query %s", query)
+		}
+	})
+}
+
+func TestMatchHistory(t *testing.T) {
+	t.Run("player's matches go from the latest", func(t *testing.T) {
+		// 1. Arrange
+		app := newHistoryApp(t)
+		req := httptest.NewRequest(http.MethodGet, "/players/h2/matches", nil)
+		req.SetPathValue("handle", "h2")
+
+		// 2. Act
+		rec := serve(app.listPlayerMatches, req)
+
+		// 3. Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		page := decodePage[sharedDomain.MatchRecord](t, rec)
+		require.Len(t, page.Items, 2)
+		require.Equal(t, "b", page.Items[0].ID)
+		require.Equal(t, "a", page.Items[1].ID)
+	})
+
+	t.Run("summary of the finished match", func(t *testing.T) {
+		// 1. Arrange
+		app := newHistoryApp(t)
+		req := httptest.NewRequest(http.MethodGet, "/matches/a", nil)
+		req.SetPathValue("id", "a")
+
+		// 2. Act
+		rec := serve(app.getMatchRecord, req)
+
+		// 3. Assert
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Data sharedDomain.MatchRecord `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		require.Equal(t, "veteran", resp.Data.Winner)
+		require.Len(t, resp.Data.Participants, 2)
+	})
+
+	t.Run("unknown match isn't found", func(t *testing.T) {
+		// 1. Arrange
+		app := newHistoryApp(t)
+		req := httptest.NewRequest(http.MethodGet, "/matches/c", nil)
+		req.SetPathValue("id", "c")
+
+		// 2. Act
+		rec := serve(app.getMatchRecord, req)
+
+		// 3. Assert
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
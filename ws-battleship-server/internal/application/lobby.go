@@ -0,0 +1,41 @@
+package application
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"ws-battleship-server/internal/delivery/http/response"
+	"ws-battleship-shared/domain"
+)
+
+// listMatches returns public matches, which aren't closed. Rated matches are filled by the
+// matchmaking queue, so they aren't listed. Joinable matches go first.
+func (a *App) listMatches(w http.ResponseWriter, r *http.Request) error {
+	response.ResponseWithJSON(w, http.StatusOK, response.Response{
+		Status: http.StatusOK,
+		Data:   a.openMatches(),
+	})
+	return nil
+}
+
+func (a *App) openMatches() []domain.MatchSummary {
+	a.mu.RLock()
+	summaries := make([]domain.MatchSummary, 0, len(a.matches))
+	for _, match := range a.matches {
+		if !match.IsPrivate() && !match.IsRated() && !match.IsClosed() {
+			summaries = append(summaries, match.Summary())
+		}
+	}
+	a.mu.RUnlock()
+
+	slices.SortFunc(summaries, func(lhs, rhs domain.MatchSummary) int {
+		if lhs.IsJoinable() != rhs.IsJoinable() {
+			if lhs.IsJoinable() {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(lhs.ID, rhs.ID)
+	})
+	return summaries
+}
@@ -0,0 +1,123 @@
+package application
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+	"ws-battleship-shared/pkg/certs"
+)
+
+const (
+	selfSignedValidity = 365 * 24 * time.Hour
+	selfSignedSubject  = "ws-battleship"
+)
+
+// loadTLSConfig returns nil, if TLS is disabled. Players compare the logged fingerprint with the
+// one, which their client pins on the first connection.
+func (a *App) loadTLSConfig() (*tls.Config, error) {
+	cfg := a.cfg.App
+	if !cfg.IsTLS() {
+		return nil, nil
+	}
+
+	var cert tls.Certificate
+	var err error
+	if _, statErr := os.Stat(cfg.TLSCertPath); cfg.TLSSelfSigned && statErr != nil {
+		cert, err = newSelfSignedCertificate(cfg.TLSCertPath, cfg.TLSKeyPath)
+	} else {
+		cert, err = tls.LoadX509KeyPair(cfg.TLSCertPath, cfg.TLSKeyPath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	a.logger.Infof("TLS certificate fingerprint is %s", certs.Fingerprint(cert.Leaf))
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// newSelfSignedCertificate generates the certificate for addresses of this machine. It's saved,
+// if paths are given, so clients, which have pinned it, keep trusting the server after restarts.
+func newSelfSignedCertificate(certPath, keyPath string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: selfSignedSubject},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(selfSignedValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  localIPs(),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	if certPath != "" {
+		if err := writeFile(certPath, certPEM, 0o644); err != nil {
+			return tls.Certificate{}, err
+		}
+		if err := writeFile(keyPath, keyPEM, 0o600); err != nil {
+			return tls.Certificate{}, err
+		}
+	}
+
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, perm)
+}
+
+// localIPs are addresses, which players in LAN may connect by.
+func localIPs() []net.IP {
+	ips := []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return ips
+	}
+
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() {
+			ips = append(ips, ipNet.IP)
+		}
+	}
+	return ips
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"path/filepath"
+	"testing"
+	"ws-battleship-server/internal/config"
+	"ws-battleship-shared/pkg/certs"
+	"ws-battleship-shared/pkg/logger"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTLSConfig(t *testing.T) {
+	newTLSApp := func(appCfg config.AppConfig) *App {
+		loggerMock := new(logger.MockLogger)
+		loggerMock.On("Infof", mock.Anything, mock.Anything).Maybe()
+		return &App{cfg: &config.Config{App: appCfg}, logger: loggerMock}
+	}
+
+	t.Run("TLS is disabled without a certificate", func(t *testing.T) {
+		// 1. Arrange
+		app := newTLSApp(config.AppConfig{})
+
+		// 2. Act
+		tlsConfig, err := app.loadTLSConfig()
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Nil(t, tlsConfig)
+	})
+
+	t.Run("self-signed certificate is generated once and kept", func(t *testing.T) {
+		// 1. Arrange
+		dir := t.TempDir()
+		app := newTLSApp(config.AppConfig{
+			TLSCertPath:   filepath.Join(dir, "cert.pem"),
+			TLSKeyPath:    filepath.Join(dir, "key.pem"),
+			TLSSelfSigned: true,
+		})
+
+		// 2. Act
+		generated, generateErr := app.loadTLSConfig()
+		loaded, loadErr := app.loadTLSConfig()
+
+		// 3. Assert
+		require.NoError(t, generateErr)
+		require.NoError(t, loadErr)
+		require.Equal(t,
+			certs.Fingerprint(generated.Certificates[0].Leaf),
+			certs.Fingerprint(loaded.Certificates[0].Leaf))
+	})
+
+	t.Run("self-signed certificate is kept in memory without paths", func(t *testing.T) {
+		// 1. Arrange
+		app := newTLSApp(config.AppConfig{TLSSelfSigned: true})
+
+		// 2. Act
+		tlsConfig, err := app.loadTLSConfig()
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Len(t, tlsConfig.Certificates, 1)
+		require.Contains(t, tlsConfig.Certificates[0].Leaf.DNSNames, "localhost")
+	})
+
+	t.Run("missing certificate fails the server", func(t *testing.T) {
+		// 1. Arrange
+		dir := t.TempDir()
+		app := newTLSApp(config.AppConfig{
+			TLSCertPath: filepath.Join(dir, "cert.pem"),
+			TLSKeyPath:  filepath.Join(dir, "key.pem"),
+		})
+
+		// 2. Act
+		_, err := app.loadTLSConfig()
+
+		// 3. Assert
+		require.Error(t, err)
+	})
+}
@@ -1,11 +1,26 @@
 package config
 
 import (
+	"fmt"
+	"slices"
 	"time"
+	"ws-battleship-shared/domain"
 
 	"github.com/kelseyhightower/envconfig"
 )
 
+const (
+	MinRoomCapacity = 2
+	// MaxRoomCapacity limits free-for-all matches, since more boards don't fit into a terminal.
+	MaxRoomCapacity = 6
+)
+
+// Policies applied to a player, who didn't fire before the turn timer expired.
+const (
+	SkipTurnPolicy = "skip"
+	AutoFirePolicy = "auto_fire"
+)
+
 type Config struct {
 	App  AppConfig
 	Game GameConfig
@@ -15,12 +30,90 @@ type AppConfig struct {
 	Port                  string        `envconfig:"SERVER_PORT" default:"8080"`
 	IsDebugMode           bool          `envconfig:"DEBUG" default:"true"`
 	ClientsConnectionsMax int32         `envconfig:"CLIENTS_CONN_MAX" default:"10"`
+	ClientsPerIPMax       int32         `envconfig:"CLIENTS_PER_IP_MAX" default:"4"`
 	RoomCapacityMax       int32         `envconfig:"ROOM_CAPACITY_MAX" default:"2"`
 	KeepAlivePeriod       time.Duration `envconfig:"KEEP_ALIVE_PERIOD" default:"5s"`
+	SpectatorsMax         int32         `envconfig:"SPECTATORS_MAX" default:"10"`
+	// AllowedOrigins are origins of browsers, which may connect. Only the origin of the server
+	// itself is allowed, if it's empty, and any origin is allowed by "*".
+	AllowedOrigins []string `envconfig:"ALLOWED_ORIGINS"`
+	// MessageBytesMax closes the connection, which sends a larger frame.
+	MessageBytesMax int64 `envconfig:"MESSAGE_BYTES_MAX" default:"4096"`
+	// The matchmaking queue pairs players, whose ratings differ by RatingGap at most. The gap
+	// grows by RatingGapGrowth for each second of waiting.
+	RatingGap           float64       `envconfig:"MATCHMAKING_RATING_GAP" default:"100"`
+	RatingGapGrowth     float64       `envconfig:"MATCHMAKING_RATING_GAP_GROWTH" default:"10"`
+	MatchmakingInterval time.Duration `envconfig:"MATCHMAKING_INTERVAL" default:"1s"`
+	// ProfilesPath is the file with profiles of players. They are lost on restart, if it's empty.
+	ProfilesPath string `envconfig:"PROFILES_PATH" default:"profiles.json"`
+	// MatchHistoryPath is the file with results of finished matches in JSON Lines.
+	MatchHistoryPath string `envconfig:"MATCH_HISTORY_PATH" default:"matches.jsonl"`
+	// AdminToken grants access to the admin API. The API is disabled, if it's empty.
+	AdminToken string `envconfig:"ADMIN_TOKEN"`
+	// AuditLogPath is the file, which records actions of admins in JSON Lines.
+	AuditLogPath string `envconfig:"AUDIT_LOG_PATH" default:"audit.jsonl"`
+	// SessionSecret signs session tokens. A random one is generated, if it's empty, so clients
+	// log in again after the restart.
+	SessionSecret string        `envconfig:"SESSION_SECRET"`
+	SessionTTL    time.Duration `envconfig:"SESSION_TTL" default:"1h"`
+	// TLS is served with the certificate and its key. TLSSelfSigned generates them for LAN games,
+	// unless the files exist. The generated certificate is kept in memory, if paths are empty.
+	TLSCertPath   string `envconfig:"TLS_CERT_PATH"`
+	TLSKeyPath    string `envconfig:"TLS_KEY_PATH"`
+	TLSSelfSigned bool   `envconfig:"TLS_SELF_SIGNED" default:"false"`
+}
+
+// IsTLS reports, whether the server is served over TLS.
+func (c AppConfig) IsTLS() bool {
+	return c.TLSCertPath != "" || c.TLSSelfSigned
 }
 
 type GameConfig struct {
-	GameTurnTime time.Duration `envconfig:"GAME_TURN_TIME" default:"30s"`
+	GameTurnTime      time.Duration `envconfig:"GAME_TURN_TIME" default:"30s"`
+	GamePlacementTime time.Duration `envconfig:"GAME_PLACEMENT_TIME" default:"90s"`
+	HitGrantsShot     bool          `envconfig:"GAME_HIT_GRANTS_SHOT" default:"false"`
+	Preset            string        `envconfig:"GAME_PRESET" default:"classic"`
+	BoardSize         int           `envconfig:"GAME_BOARD_SIZE"`
+	Fleet             []int         `envconfig:"GAME_FLEET"`
+	Mode              string        `envconfig:"GAME_MODE" default:"classic"`
+	TeamMode          bool          `envconfig:"GAME_TEAM_MODE" default:"false"`
+	TimeoutPolicy     string        `envconfig:"GAME_TIMEOUT_POLICY" default:"skip"`
+	TimeoutsToForfeit int           `envconfig:"GAME_TIMEOUTS_TO_FORFEIT" default:"3"`
+	ReconnectTime     time.Duration `envconfig:"GAME_RECONNECT_TIME" default:"60s"`
+	SpectatorDelay    time.Duration `envconfig:"GAME_SPECTATOR_DELAY" default:"0s"`
+	// JournalDir holds journals of all matches to replay them. Journals keep boards unmasked, so
+	// matches are recorded only if GAME_JOURNAL_DIR is set, e.g. GAME_JOURNAL_DIR=replays.
+	JournalDir string `envconfig:"GAME_JOURNAL_DIR"`
+	// Seed is forced for all matches, otherwise each match gets a random one.
+	Seed int64 `envconfig:"GAME_SEED"`
+	// BotWait is how long a player waits for opponents, before bots take the free seats.
+	// Bots are never added, if it's zero.
+	BotWait       time.Duration `envconfig:"GAME_BOT_WAIT" default:"0s"`
+	BotDifficulty string        `envconfig:"GAME_BOT_DIFFICULTY" default:"medium"`
+}
+
+// Rules resolves the preset and applies the board size and fleet overrides on top of it.
+func (c GameConfig) Rules() (domain.GameRules, error) {
+	rules := domain.DefaultRules()
+	if c.Preset != "" {
+		preset, err := domain.GetPreset(c.Preset)
+		if err != nil {
+			return rules, err
+		}
+		rules = preset
+	}
+
+	if c.BoardSize != 0 {
+		rules.BoardSize = c.BoardSize
+	}
+	if len(c.Fleet) > 0 {
+		rules.Fleet = c.Fleet
+	}
+	rules.Mode = domain.GameMode(c.Mode)
+	rules.IsTeamMatch = c.TeamMode
+	rules.HitGrantsShot = c.HitGrantsShot
+
+	return rules, rules.Validate()
 }
 
 func NewConfig() (*Config, error) {
@@ -30,5 +123,46 @@ func NewConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.App.RoomCapacityMax < MinRoomCapacity || cfg.App.RoomCapacityMax > MaxRoomCapacity {
+		return nil, fmt.Errorf("room capacity must be between %d and %d", MinRoomCapacity, MaxRoomCapacity)
+	}
+
+	if (cfg.App.TLSCertPath == "") != (cfg.App.TLSKeyPath == "") {
+		return nil, fmt.Errorf("TLS certificate and key must be given together")
+	}
+
+	if cfg.App.ClientsConnectionsMax <= 0 || cfg.App.ClientsPerIPMax <= 0 {
+		return nil, fmt.Errorf("connection limits must be positive")
+	}
+
+	if cfg.App.MessageBytesMax <= 0 {
+		return nil, fmt.Errorf("message size limit must be positive")
+	}
+
+	if cfg.App.SessionTTL <= 0 {
+		return nil, fmt.Errorf("session TTL must be positive")
+	}
+
+	if cfg.App.MatchmakingInterval <= 0 {
+		return nil, fmt.Errorf("matchmaking interval must be positive")
+	}
+
+	if cfg.Game.TimeoutPolicy != SkipTurnPolicy && cfg.Game.TimeoutPolicy != AutoFirePolicy {
+		return nil, fmt.Errorf("unknown timeout policy %q", cfg.Game.TimeoutPolicy)
+	}
+
+	// Team match needs two teams of the same size with at least two players each.
+	if cfg.Game.TeamMode && (cfg.App.RoomCapacityMax%2 != 0 || cfg.App.RoomCapacityMax < 2*MinRoomCapacity) {
+		return nil, fmt.Errorf("team mode requires an even room capacity of at least %d", 2*MinRoomCapacity)
+	}
+
+	if !slices.Contains(domain.BotDifficulties, cfg.Game.BotDifficulty) {
+		return nil, fmt.Errorf("unknown bot difficulty %q", cfg.Game.BotDifficulty)
+	}
+
+	if _, err := cfg.Game.Rules(); err != nil {
+		return nil, fmt.Errorf("invalid game rules: %w", err)
+	}
+
 	return &cfg, nil
 }
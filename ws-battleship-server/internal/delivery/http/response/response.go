@@ -10,6 +10,24 @@ type Response struct {
 	Data   any `json:"data"`
 }
 
+// HTTPError is returned by handlers to respond with the code other than 500.
+type HTTPError struct {
+	Code int
+	Err  error
+}
+
+func NewHTTPError(code int, err error) *HTTPError {
+	return &HTTPError{Code: code, Err: err}
+}
+
+func (e *HTTPError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
 type ErrorResponse struct {
 	Status int    `json:"status"`
 	Error  string `json:"error"`
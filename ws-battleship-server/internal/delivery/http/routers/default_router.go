@@ -39,23 +39,23 @@ func (r *DefaultRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 }
 
 func (r *DefaultRouter) GET(relativePath string, handlers ...Handler) {
-	r.listener.HandleFunc(relativePath, r.makeMiddlewareChain(handlers...))
+	r.listener.HandleFunc(http.MethodGet+" "+relativePath, r.makeMiddlewareChain(handlers...))
 }
 
 func (r *DefaultRouter) POST(relativePath string, handlers ...Handler) {
-	r.listener.HandleFunc(relativePath, r.makeMiddlewareChain(handlers...))
+	r.listener.HandleFunc(http.MethodPost+" "+relativePath, r.makeMiddlewareChain(handlers...))
 }
 
 func (r *DefaultRouter) DELETE(relativePath string, handlers ...Handler) {
-	r.listener.HandleFunc(relativePath, r.makeMiddlewareChain(handlers...))
+	r.listener.HandleFunc(http.MethodDelete+" "+relativePath, r.makeMiddlewareChain(handlers...))
 }
 
 func (r *DefaultRouter) PATCH(relativePath string, handlers ...Handler) {
-	r.listener.HandleFunc(relativePath, r.makeMiddlewareChain(handlers...))
+	r.listener.HandleFunc(http.MethodPatch+" "+relativePath, r.makeMiddlewareChain(handlers...))
 }
 
 func (r *DefaultRouter) PUT(relativePath string, handlers ...Handler) {
-	r.listener.HandleFunc(relativePath, r.makeMiddlewareChain(handlers...))
+	r.listener.HandleFunc(http.MethodPut+" "+relativePath, r.makeMiddlewareChain(handlers...))
 }
 
 func (r *DefaultRouter) makeMiddlewareChain(handlers ...Handler) http.HandlerFunc {
@@ -72,7 +72,9 @@ func (r *DefaultRouter) makeMiddlewareChain(handlers ...Handler) http.HandlerFun
 }
 
 func handleError(w http.ResponseWriter, err error) {
-	switch err.(type) {
+	switch err := err.(type) {
+	case *response.HTTPError:
+		response.Error(w, err, err.Code)
 	default:
 		response.Error(w, err, http.StatusInternalServerError)
 	}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"ws-battleship-server/internal/delivery/http/response"
+	server "ws-battleship-server/internal/domain"
+	"ws-battleship-shared/domain"
+)
+
+// RejectReason tells, why the connection was refused or closed by the server.
+type RejectReason = string
+
+const (
+	RejectShutdown        RejectReason = "shutdown"
+	RejectOrigin          RejectReason = "origin"
+	RejectUnauthorized    RejectReason = "unauthorized"
+	RejectServerFull      RejectReason = "server_full"
+	RejectIPLimit         RejectReason = "ip_limit"
+	RejectSessionInUse    RejectReason = "session_in_use"
+	RejectMessageTooLarge RejectReason = "message_too_large"
+)
+
+// allOrigins in the allow-list lets browsers connect from anywhere.
+const allOrigins = "*"
+
+// ConnectionStats is the state of admission control, which is shown to admins.
+type ConnectionStats struct {
+	Connections int                    `json:"connections"`
+	Rejections  map[RejectReason]int64 `json:"rejections"`
+}
+
+func (l *WebsocketListener) Stats() ConnectionStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return ConnectionStats{
+		Connections: len(l.sessions),
+		Rejections:  maps.Clone(l.rejections),
+	}
+}
+
+// admit takes a seat for the client, unless the server or its IP-address is full, or the client
+// is already connected.
+func (l *WebsocketListener) admit(r *http.Request, ip string, clientID domain.ClientID) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var reason RejectReason
+	var code int
+	var err error
+	switch _, isConnected := l.sessions[clientID]; {
+	case len(l.sessions) >= int(l.cfg.ClientsConnectionsMax):
+		reason, code, err = RejectServerFull, http.StatusServiceUnavailable, ErrServerFull
+	case l.connections[ip] >= l.cfg.ClientsPerIPMax:
+		reason, code, err = RejectIPLimit, http.StatusTooManyRequests, ErrTooManyConnections
+	case isConnected:
+		reason, code, err = RejectSessionInUse, http.StatusConflict, server.ErrSessionInUse
+	default:
+		l.sessions[clientID] = struct{}{}
+		l.connections[ip]++
+		return nil
+	}
+
+	l.rejections[reason]++
+	l.logger.Infof("connection from %s was rejected: %s", r.RemoteAddr, err)
+	return response.NewHTTPError(code, err)
+}
+
+func (l *WebsocketListener) release(ip string, clientID domain.ClientID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.sessions, clientID)
+	if l.connections[ip]--; l.connections[ip] <= 0 {
+		delete(l.connections, ip)
+	}
+}
+
+func (l *WebsocketListener) reject(r *http.Request, reason RejectReason, code int, err error) error {
+	l.countRejection(reason)
+	l.logger.Infof("connection from %s was rejected: %s", r.RemoteAddr, err)
+	return response.NewHTTPError(code, err)
+}
+
+func (l *WebsocketListener) countRejection(reason RejectReason) {
+	l.mu.Lock()
+	l.rejections[reason]++
+	l.mu.Unlock()
+}
+
+// checkOrigin lets clients without the origin through, since only browsers send it.
+func (l *WebsocketListener) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	if len(l.cfg.AllowedOrigins) > 0 {
+		return slices.Contains(l.cfg.AllowedOrigins, allOrigins) || slices.Contains(l.cfg.AllowedOrigins, origin)
+	}
+
+	originURL, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(originURL.Host, r.Host)
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"strings"
 	"sync"
 	"time"
@@ -22,6 +23,10 @@ type WebsocketClient struct {
 	writeCh chan []byte
 
 	clientID domain.ClientID
+	// onClose ends the session of the client, so it may connect again.
+	onClose func()
+	// onMessageTooLarge is called, when the connection is closed for a frame above the read limit.
+	onMessageTooLarge func()
 }
 
 func NewWebsocketClient(conn *websocket.Conn, logger logger.Logger, metadata domain.ClientMetadata) *WebsocketClient {
@@ -77,6 +82,9 @@ func (c *WebsocketClient) Close() {
 		if err := c.conn.Close(); err != nil {
 			c.logger.Errorf("failed to close a client id=%s: %s", c.ID(), err)
 		}
+		if c.onClose != nil {
+			c.onClose()
+		}
 	})
 }
 
@@ -103,6 +111,13 @@ func (c *WebsocketClient) ReadMessages(ctx context.Context, messagesCh chan<- ev
 				}
 
 				switch {
+				case errors.Is(err, websocket.ErrReadLimit):
+					c.logger.Errorf("client id=%s sent a message above the limit, closing the connection", c.ID())
+					if c.onMessageTooLarge != nil {
+						c.onMessageTooLarge()
+					}
+					c.Close()
+					return
 				case websocket.IsUnexpectedCloseError(err,
 					websocket.CloseGoingAway,
 					websocket.CloseAbnormalClosure,
@@ -136,9 +151,9 @@ func (c *WebsocketClient) ReadMessages(ctx context.Context, messagesCh chan<- ev
 	}
 }
 
+// WriteMessages keeps writeCh open, when the context is done, so another writer can take over
+// the connection. The matchmaking queue hands its players over to the match this way.
 func (c *WebsocketClient) WriteMessages(ctx context.Context) {
-	defer close(c.writeCh)
-
 	for {
 		if err := ctx.Err(); err != nil {
 			return
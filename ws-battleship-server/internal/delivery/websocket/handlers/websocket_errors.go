@@ -0,0 +1,10 @@
+package handlers
+
+import "errors"
+
+var (
+	ErrListenerClosed     = errors.New("listener is closed")
+	ErrOriginNotAllowed   = errors.New("origin isn't allowed")
+	ErrServerFull         = errors.New("server has no free connections")
+	ErrTooManyConnections = errors.New("too many connections from the address")
+)
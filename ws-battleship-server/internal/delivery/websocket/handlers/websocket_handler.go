@@ -1,12 +1,10 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 	"sync"
 	"sync/atomic"
 	"ws-battleship-server/internal/config"
-	"ws-battleship-server/internal/delivery/http/response"
 	server "ws-battleship-server/internal/domain"
 	"ws-battleship-shared/domain"
 	"ws-battleship-shared/events"
@@ -19,48 +17,112 @@ type WebsocketListener struct {
 	upgrader   *websocket.Upgrader
 	once       sync.Once
 	isShutdown atomic.Bool
+	// closeCh releases connections, which wait to be taken by the app, on shutdown.
+	closeCh chan struct{}
 
-	joinCh chan *server.Player
-	logger logger.Logger
+	cfg    *config.AppConfig
+	tokens *server.SessionTokens
+	mu     sync.Mutex
+	// sessions holds IDs of clients, which are connected now.
+	sessions map[domain.ClientID]struct{}
+	// connections counts live connections by IP-addresses of clients.
+	connections map[string]int32
+	// rejections counts refused connections by reasons.
+	rejections map[RejectReason]int64
+
+	joinCh     chan *server.Player
+	spectateCh chan *server.Spectator
+	logger     logger.Logger
 }
 
-func NewWebsocketListener(cfg *config.AppConfig, logger logger.Logger, joinCh chan *server.Player) *WebsocketListener {
-	websocketUpgrader := websocket.Upgrader{
-		ReadBufferSize:  events.ReadBufferBytesMax,
-		WriteBufferSize: events.WriteBufferBytesMax,
-		CheckOrigin: func(r *http.Request) bool {
-			return true
-		},
+func NewWebsocketListener(cfg *config.AppConfig, logger logger.Logger, tokens *server.SessionTokens, joinCh chan *server.Player, spectateCh chan *server.Spectator) *WebsocketListener {
+	listener := &WebsocketListener{
+		cfg:         cfg,
+		tokens:      tokens,
+		sessions:    make(map[domain.ClientID]struct{}, cfg.ClientsConnectionsMax),
+		connections: make(map[string]int32, cfg.ClientsConnectionsMax),
+		rejections:  make(map[RejectReason]int64),
+		closeCh:     make(chan struct{}),
+		logger:      logger,
+		joinCh:      joinCh,
+		spectateCh:  spectateCh,
 	}
 
-	return &WebsocketListener{
-		upgrader: &websocketUpgrader,
-		logger:   logger,
-		joinCh:   joinCh,
+	listener.upgrader = &websocket.Upgrader{
+		ReadBufferSize:  events.ReadBufferBytesMax,
+		WriteBufferSize: events.WriteBufferBytesMax,
+		CheckOrigin:     listener.checkOrigin,
 	}
+	return listener
 }
 
 func (l *WebsocketListener) Close() {
 	l.isShutdown.Store(true)
 
 	l.once.Do(func() {
+		close(l.closeCh)
 		l.logger.Info("websocket listener is closed")
 	})
 }
 
 func (l *WebsocketListener) HandleWebsocketConnection(w http.ResponseWriter, r *http.Request) error {
 	if l.isShutdown.Load() {
-		response.Error(w, errors.New("listener is closed"), 499)
-		return nil
+		return l.reject(r, RejectShutdown, http.StatusServiceUnavailable, ErrListenerClosed)
+	}
+
+	// The origin is checked before the upgrade, so the browser gets the error in JSON.
+	if !l.checkOrigin(r) {
+		return l.reject(r, RejectOrigin, http.StatusForbidden, ErrOriginNotAllowed)
+	}
+
+	// The identity is taken from the session token only, so nobody connects on behalf of another client.
+	metadata := domain.ParseClientMetadataFromHeaders(r)
+	claims, err := l.tokens.Verify(metadata.SessionToken)
+	if err != nil {
+		return l.reject(r, RejectUnauthorized, http.StatusUnauthorized, err)
+	}
+	metadata.ClientID = claims.ClientID
+	metadata.Nickname = claims.Nickname
+	metadata.ProfileID = claims.ProfileID
+
+	ip := remoteIP(r)
+	if err := l.admit(r, ip, claims.ClientID); err != nil {
+		return err
 	}
 
 	conn, err := l.upgrader.Upgrade(w, r, nil)
 	if err != nil {
+		l.release(ip, claims.ClientID)
 		return nil
 	}
+	conn.SetReadLimit(l.cfg.MessageBytesMax)
 
-	metadata := domain.ParseClientMetadataFromHeaders(r)
 	newClient := NewWebsocketClient(conn, l.logger, metadata)
-	l.joinCh <- server.NewPlayer(newClient, metadata)
+	newClient.onClose = func() {
+		l.release(ip, claims.ClientID)
+	}
+	newClient.onMessageTooLarge = func() {
+		l.countRejection(RejectMessageTooLarge)
+	}
+
+	if metadata.IsSpectator() {
+		select {
+		case l.spectateCh <- server.NewSpectator(newClient, metadata):
+			return nil
+		case <-r.Context().Done():
+		case <-l.closeCh:
+		}
+	} else {
+		select {
+		case l.joinCh <- server.NewPlayer(newClient, metadata):
+			return nil
+		case <-r.Context().Done():
+		case <-l.closeCh:
+		}
+	}
+
+	// The server is shutting down and nobody takes the client, so its slot is released on close.
+	l.countRejection(RejectShutdown)
+	newClient.Close()
 	return nil
 }
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"ws-battleship-server/internal/config"
+	"ws-battleship-server/internal/delivery/http/response"
+	"ws-battleship-server/internal/delivery/http/routers"
+	server "ws-battleship-server/internal/domain"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+	"ws-battleship-shared/pkg/logger"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testListener struct {
+	*WebsocketListener
+	url    string
+	tokens *server.SessionTokens
+	joinCh chan *server.Player
+}
+
+func newTestListener(t *testing.T, cfg config.AppConfig) *testListener {
+	t.Helper()
+
+	loggerMock := new(logger.MockLogger)
+	for _, method := range []string{"Info", "Infof", "Error", "Errorf"} {
+		loggerMock.On(method, mock.Anything).Maybe()
+		loggerMock.On(method, mock.Anything, mock.Anything).Maybe()
+	}
+
+	tokens := server.NewSessionTokens("secret", time.Minute)
+	joinCh := make(chan *server.Player, 10)
+	listener := NewWebsocketListener(&cfg, loggerMock, tokens, joinCh, make(chan *server.Spectator, 10))
+
+	router := routers.NewDefaultRouter(loggerMock)
+	router.GET("/ws", listener.HandleWebsocketConnection)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	return &testListener{
+		WebsocketListener: listener,
+		url:               "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws",
+		tokens:            tokens,
+		joinCh:            joinCh,
+	}
+}
+
+// dial connects a new guest. The response body is decoded, if the connection is rejected.
+func (l *testListener) dial(t *testing.T, header http.Header) (*websocket.Conn, int, response.ErrorResponse) {
+	t.Helper()
+
+	token, err := l.tokens.Issue("player", "")
+	require.NoError(t, err)
+
+	headers := domain.ParseClientMetadataToHeaders(domain.ClientMetadata{SessionToken: token.Token})
+	for key, values := range header {
+		headers[key] = values
+	}
+
+	conn, resp, err := websocket.DefaultDialer.Dial(l.url, headers)
+	require.NotNil(t, resp, err)
+
+	var body response.ErrorResponse
+	if err != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return nil, resp.StatusCode, body
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, resp.StatusCode, body
+}
+
+func TestConnectionLimits(t *testing.T) {
+	t.Run("server refuses connections above the global cap", func(t *testing.T) {
+		// 1. Arrange
+		listener := newTestListener(t, config.AppConfig{ClientsConnectionsMax: 1, ClientsPerIPMax: 5})
+		_, code, _ := listener.dial(t, nil)
+		require.Equal(t, http.StatusSwitchingProtocols, code)
+
+		// 2. Act
+		_, code, body := listener.dial(t, nil)
+
+		// 3. Assert
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.Equal(t, http.StatusServiceUnavailable, body.Status)
+		require.Equal(t, ErrServerFull.Error(), body.Error)
+		require.Equal(t, ConnectionStats{
+			Connections: 1,
+			Rejections:  map[RejectReason]int64{RejectServerFull: 1},
+		}, listener.Stats())
+	})
+
+	t.Run("server refuses connections above the cap of the address", func(t *testing.T) {
+		// 1. Arrange
+		listener := newTestListener(t, config.AppConfig{ClientsConnectionsMax: 5, ClientsPerIPMax: 1})
+		_, code, _ := listener.dial(t, nil)
+		require.Equal(t, http.StatusSwitchingProtocols, code)
+
+		// 2. Act
+		_, code, body := listener.dial(t, nil)
+
+		// 3. Assert
+		require.Equal(t, http.StatusTooManyRequests, code)
+		require.Equal(t, ErrTooManyConnections.Error(), body.Error)
+		require.Equal(t, int64(1), listener.Stats().Rejections[RejectIPLimit])
+	})
+
+	t.Run("closed connection frees its seat", func(t *testing.T) {
+		// 1. Arrange
+		listener := newTestListener(t, config.AppConfig{ClientsConnectionsMax: 1, ClientsPerIPMax: 1})
+		_, code, _ := listener.dial(t, nil)
+		require.Equal(t, http.StatusSwitchingProtocols, code)
+
+		// 2. Act
+		(<-listener.joinCh).Close()
+		_, code, _ = listener.dial(t, nil)
+
+		// 3. Assert
+		require.Equal(t, http.StatusSwitchingProtocols, code)
+		require.Empty(t, listener.Stats().Rejections)
+	})
+
+	t.Run("closed listener refuses connections as unavailable", func(t *testing.T) {
+		// 1. Arrange
+		listener := newTestListener(t, config.AppConfig{ClientsConnectionsMax: 1, ClientsPerIPMax: 1})
+		listener.Close()
+
+		// 2. Act
+		_, code, body := listener.dial(t, nil)
+
+		// 3. Assert
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.Equal(t, ErrListenerClosed.Error(), body.Error)
+		require.Equal(t, int64(1), listener.Stats().Rejections[RejectShutdown])
+	})
+
+	t.Run("client, which isn't taken before shutdown, is released", func(t *testing.T) {
+		// 1. Arrange
+		listener := newTestListener(t, config.AppConfig{ClientsConnectionsMax: 1, ClientsPerIPMax: 1})
+		for range cap(listener.joinCh) {
+			listener.joinCh <- nil
+		}
+		conn, code, _ := listener.dial(t, nil)
+		require.Equal(t, http.StatusSwitchingProtocols, code)
+
+		// 2. Act
+		listener.Close()
+
+		// 3. Assert
+		require.Eventually(t, func() bool {
+			return listener.Stats().Connections == 0
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, int64(1), listener.Stats().Rejections[RejectShutdown])
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		_, _, err := conn.ReadMessage()
+		require.Error(t, err)
+	})
+
+	t.Run("client without a token is counted as unauthorized", func(t *testing.T) {
+		// 1. Arrange
+		listener := newTestListener(t, config.AppConfig{ClientsConnectionsMax: 1, ClientsPerIPMax: 1})
+
+		// 2. Act
+		_, resp, err := websocket.DefaultDialer.Dial(listener.url, nil)
+
+		// 3. Assert
+		require.Error(t, err)
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Equal(t, int64(1), listener.Stats().Rejections[RejectUnauthorized])
+	})
+}
+
+func TestClientIdentity(t *testing.T) {
+	t.Run("guest can't claim a profile by headers", func(t *testing.T) {
+		// 1. Arrange
+		listener := newTestListener(t, config.AppConfig{ClientsConnectionsMax: 1, ClientsPerIPMax: 1})
+
+		// 2. Act
+		_, code, _ := listener.dial(t, http.Header{"X-Profile-ID": {"someone-else"}})
+
+		// 3. Assert
+		require.Equal(t, http.StatusSwitchingProtocols, code)
+		player := <-listener.joinCh
+		require.Equal(t, "player", player.Nickname())
+		require.Empty(t, player.ProfileID())
+	})
+}
+
+func TestCheckOrigin(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		wantCode       int
+	}{
+		{
+			name:     "client without the origin is allowed",
+			wantCode: http.StatusSwitchingProtocols,
+		},
+		{
+			name:     "foreign origin is refused by default",
+			origin:   "https://evil.example",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:           "allowed origin is let through",
+			allowedOrigins: []string{"https://good.example"},
+			origin:         "https://good.example",
+			wantCode:       http.StatusSwitchingProtocols,
+		},
+		{
+			name:           "origin out of the allow-list is refused",
+			allowedOrigins: []string{"https://good.example"},
+			origin:         "https://evil.example",
+			wantCode:       http.StatusForbidden,
+		},
+		{
+			name:           "any origin is allowed by the wildcard",
+			allowedOrigins: []string{allOrigins},
+			origin:         "https://evil.example",
+			wantCode:       http.StatusSwitchingProtocols,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			listener := newTestListener(t, config.AppConfig{
+				ClientsConnectionsMax: 1,
+				ClientsPerIPMax:       1,
+				AllowedOrigins:        tt.allowedOrigins,
+			})
+			header := http.Header{}
+			if tt.origin != "" {
+				header.Set("Origin", tt.origin)
+			}
+
+			// 2. Act
+			_, code, body := listener.dial(t, header)
+
+			// 3. Assert
+			require.Equal(t, tt.wantCode, code)
+			if tt.wantCode == http.StatusForbidden {
+				require.Equal(t, ErrOriginNotAllowed.Error(), body.Error)
+				require.Equal(t, int64(1), listener.Stats().Rejections[RejectOrigin])
+			}
+		})
+	}
+}
+
+func TestReadLimit(t *testing.T) {
+	t.Run("connection is closed after a frame above the limit", func(t *testing.T) {
+		// 1. Arrange
+		listener := newTestListener(t, config.AppConfig{ClientsConnectionsMax: 1, ClientsPerIPMax: 1, MessageBytesMax: 64})
+		conn, code, _ := listener.dial(t, nil)
+		require.Equal(t, http.StatusSwitchingProtocols, code)
+
+		player := <-listener.joinCh
+		messagesCh := make(chan events.Event, 1)
+		go player.ReadMessages(t.Context(), messagesCh)
+
+		// 2. Act
+		require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, []byte(strings.Repeat("a", 128))))
+
+		// 3. Assert
+		require.Eventually(t, func() bool {
+			stats := listener.Stats()
+			return stats.Connections == 0 && stats.Rejections[RejectMessageTooLarge] == 1
+		}, time.Second, 10*time.Millisecond)
+		require.Empty(t, messagesCh)
+	})
+}
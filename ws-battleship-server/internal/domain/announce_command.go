@@ -0,0 +1,13 @@
+package domain
+
+type AnnounceCommand struct {
+	message string
+}
+
+func NewAnnounceCommand(message string) *AnnounceCommand {
+	return &AnnounceCommand{message: message}
+}
+
+func (c *AnnounceCommand) Execute(executor CommandExecutor) error {
+	return executor.Announce(c.message)
+}
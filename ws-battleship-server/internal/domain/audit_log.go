@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AuditEntry is a single action of the admin.
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Action string    `json:"action"`
+	// Target is the match or the player, which the action was applied to.
+	Target     string `json:"target,omitempty"`
+	Details    string `json:"details,omitempty"`
+	RemoteAddr string `json:"remote_addr"`
+	// Err is set, if the action was denied or failed.
+	Err string `json:"error,omitempty"`
+}
+
+// AuditLog records actions of admins.
+type AuditLog interface {
+	Record(entry AuditEntry) error
+	Close() error
+}
+
+// FileAuditLog appends entries to the file in JSON Lines.
+type FileAuditLog struct {
+	mu sync.Mutex
+	// file is nil, if entries are discarded.
+	file    *os.File
+	encoder *json.Encoder
+}
+
+func NewFileAuditLog(path string) (*FileAuditLog, error) {
+	auditLog := &FileAuditLog{}
+	if path == "" {
+		return auditLog, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	auditLog.file = file
+	auditLog.encoder = json.NewEncoder(file)
+	return auditLog, nil
+}
+
+func (l *FileAuditLog) Record(entry AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.encoder == nil {
+		return nil
+	}
+	return l.encoder.Encode(entry)
+}
+
+func (l *FileAuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file, l.encoder = nil, nil
+	return err
+}
@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+	"ws-battleship-shared/pkg/logger"
+)
+
+// botThinkTime delays shots of bots, so players can follow the game.
+const botThinkTime = 700 * time.Millisecond
+
+// botInboxCapacity is the number of events, which wait for the bot. Events beyond it are dropped.
+const botInboxCapacity = 64
+
+// Bot is a client, which lives on the server without a socket. It places its fleet right away
+// and answers its turns with shots picked by the strategy.
+type Bot struct {
+	once    sync.Once
+	inboxCh chan events.Event
+	closeCh chan struct{}
+
+	id       string
+	strategy domain.BotStrategy
+	rng      domain.RNG
+	logger   logger.Logger
+
+	rules     domain.GameRules
+	gameModel *domain.GameModel
+}
+
+func NewBot(id string, strategy domain.BotStrategy, rng domain.RNG, logger logger.Logger) *Bot {
+	return &Bot{
+		inboxCh:  make(chan events.Event, botInboxCapacity),
+		closeCh:  make(chan struct{}),
+		id:       id,
+		strategy: strategy,
+		rng:      rng,
+		logger:   logger,
+		rules:    domain.DefaultRules(),
+	}
+}
+
+func (b *Bot) ID() domain.ClientID {
+	return b.id
+}
+
+func (b *Bot) Ping() error {
+	return nil
+}
+
+func (b *Bot) Close() {
+	b.once.Do(func() {
+		close(b.closeCh)
+	})
+}
+
+func (b *Bot) SendMessage(e events.Event) error {
+	select {
+	case <-b.closeCh:
+	case b.inboxCh <- e:
+	default:
+		b.logger.Errorf("inbox of bot id=%s is full, event type=%s is dropped", b.ID(), e.Type)
+	}
+	return nil
+}
+
+// ReadMessages handles events sent to the bot and puts its answers into the room.
+func (b *Bot) ReadMessages(ctx context.Context, messagesCh chan<- events.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.closeCh:
+			return
+		case e := <-b.inboxCh:
+			answer, ok := b.handleEvent(e)
+			if !ok {
+				continue
+			}
+
+			if answer.Type == events.PlayerFireEventType {
+				select {
+				case <-ctx.Done():
+					return
+				case <-b.closeCh:
+					return
+				case <-time.After(botThinkTime):
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-b.closeCh:
+				return
+			case messagesCh <- answer:
+			}
+		}
+	}
+}
+
+// WriteMessages has nothing to write, since the bot gets events right away.
+func (b *Bot) WriteMessages(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-b.closeCh:
+	}
+}
+
+func (b *Bot) handleEvent(e events.Event) (answer events.Event, ok bool) {
+	var err error
+
+	switch e.Type {
+	case events.PlayerUpdateStateEventType:
+		var updateStateEvent events.PlayerUpdateStateEvent
+		if updateStateEvent, err = events.CastTo[events.PlayerUpdateStateEvent](e); err == nil {
+			b.gameModel = updateStateEvent.GameState.Model()
+		}
+
+	case events.PlacementStartEventType:
+		var placementStartEvent events.PlacementStartEvent
+		if placementStartEvent, err = events.CastTo[events.PlacementStartEvent](e); err == nil {
+			b.rules = placementStartEvent.Rules
+			answer, ok, err = b.placeFleet()
+		}
+
+	case events.PlayerTurnEventType:
+		var playerTurnEvent events.PlayerTurnEvent
+		if playerTurnEvent, err = events.CastTo[events.PlayerTurnEvent](e); err == nil && playerTurnEvent.TurningPlayerID == b.ID() {
+			answer, ok, err = b.fire(max(playerTurnEvent.Shots, 1))
+		}
+	}
+
+	if err != nil {
+		b.logger.Errorf("bot id=%s failed to handle event type=%s: %s", b.ID(), e.Type, err)
+		return events.Event{}, false
+	}
+	return answer, ok
+}
+
+// placeFleet keeps the board, which the bot got on join.
+func (b *Bot) placeFleet() (events.Event, bool, error) {
+	me := b.me()
+	if me == nil {
+		return events.Event{}, false, nil
+	}
+
+	event, err := events.NewPlaceFleetEvent(events.PlaceFleetCommandArgs{
+		PlayerID: b.ID(),
+		Ships:    domain.ExtractShips(me.Board),
+	})
+	return event, err == nil, err
+}
+
+func (b *Bot) fire(shots int) (events.Event, bool, error) {
+	target := b.pickTarget()
+	if target == nil {
+		return events.Event{}, false, nil
+	}
+
+	cells := b.strategy.NextShots(b.rng, target.Board, b.rules.AfloatShips(target), shots)
+	if len(cells) == 0 {
+		return events.Event{}, false, nil
+	}
+
+	event, err := events.NewPlayerFireEvent(events.FireCommandArgs{
+		FiringPlayerID: b.ID(),
+		TargetPlayerID: target.ID,
+		Shots:          cells,
+	})
+	return event, err == nil, err
+}
+
+// pickTarget prefers opponents with wounded ships, so they are finished first.
+func (b *Bot) pickTarget() *domain.PlayerModel {
+	me := b.me()
+	if me == nil {
+		return nil
+	}
+
+	var opponents []*domain.PlayerModel
+	for _, player := range b.gameModel.Players {
+		if !player.Equal(me) && !player.IsAllyOf(me) && !player.IsEliminated {
+			opponents = append(opponents, player)
+		}
+	}
+	if len(opponents) == 0 {
+		return nil
+	}
+	slices.SortFunc(opponents, (*domain.PlayerModel).Compare)
+
+	for _, opponent := range opponents {
+		if len(domain.TargetCells(opponent.Board)) > 0 {
+			return opponent
+		}
+	}
+	return opponents[b.rng.Intn(len(opponents))]
+}
+
+func (b *Bot) me() *domain.PlayerModel {
+	if b.gameModel == nil {
+		return nil
+	}
+	return b.gameModel.Players[b.ID()]
+}
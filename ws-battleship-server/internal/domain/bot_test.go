@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"testing"
+	"time"
+	"ws-battleship-server/internal/config"
+	"ws-battleship-server/internal/delivery/websocket"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBotAnswers(t *testing.T) {
+	newGameModel := func() *domain.GameModel {
+		return &domain.GameModel{Players: map[string]*domain.PlayerModel{
+			"bot":   domain.NewPlayerModel(domain.RandomizeBoard(domain.DefaultRules()), domain.ClientMetadata{ClientID: "bot"}),
+			"human": domain.NewPlayerModel(domain.NewBoard(domain.DefaultBoardSize), domain.ClientMetadata{ClientID: "human"}),
+		}}
+	}
+
+	t.Run("bot places the fleet it got on join", func(t *testing.T) {
+		// 1. Arrange
+		bot := NewBot("bot", domain.RandomStrategy{}, domain.NewRNG(1), nil)
+		bot.gameModel = newGameModel()
+		event, err := events.NewPlacementStartEvent(domain.DefaultRules(), 0)
+		require.NoError(t, err)
+
+		// 2. Act
+		answer, ok := bot.handleEvent(event)
+
+		// 3. Assert
+		require.True(t, ok)
+		placeFleetEvent, err := events.CastTo[events.PlaceFleetEvent](answer)
+		require.NoError(t, err)
+		_, err = domain.BuildBoard(placeFleetEvent.Ships, domain.DefaultRules())
+		require.NoError(t, err)
+	})
+
+	t.Run("bot fires at the opponent on its turn only", func(t *testing.T) {
+		// 1. Arrange
+		bot := NewBot("bot", domain.RandomStrategy{}, domain.NewRNG(1), nil)
+		bot.gameModel = newGameModel()
+		humanTurnEvent, err := events.NewPlayerTurnEvent(1, "human", 0, false, 1)
+		require.NoError(t, err)
+		botTurnEvent, err := events.NewPlayerTurnEvent(2, "bot", 0, false, 1)
+		require.NoError(t, err)
+
+		// 2. Act
+		_, isHumanTurnAnswered := bot.handleEvent(humanTurnEvent)
+		answer, isBotTurnAnswered := bot.handleEvent(botTurnEvent)
+
+		// 3. Assert
+		require.False(t, isHumanTurnAnswered)
+		require.True(t, isBotTurnAnswered)
+		playerFireEvent, err := events.CastTo[events.PlayerFireEvent](answer)
+		require.NoError(t, err)
+		require.Equal(t, "human", playerFireEvent.TargetPlayerID)
+		require.Len(t, playerFireEvent.Targets(), 1)
+	})
+}
+
+func TestFillWithBots(t *testing.T) {
+	newWaitingMatch := func(t *testing.T) (*Match, *Player) {
+		human := newTestPlayer(t, "human")
+		match := newPlacingMatch(human)
+		match.isPlacing.Store(false)
+		match.cfg.App.RoomCapacityMax = 3
+		match.room.cfg = &config.AppConfig{RoomCapacityMax: 3}
+		match.room.joinCh = make(chan websocket.Client, 3)
+		match.room.closeCh = make(chan struct{})
+		return match, human
+	}
+
+	t.Run("bots take all free seats", func(t *testing.T) {
+		// 1. Arrange
+		match, _ := newWaitingMatch(t)
+
+		// 2. Act
+		err := match.FillWithBots(domain.HardBot)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Len(t, match.players, 3)
+		require.True(t, match.players["bot-1"].IsBot())
+		require.True(t, match.players["bot-2"].IsBot())
+	})
+
+	t.Run("bots are awaited from the first player until the placement", func(t *testing.T) {
+		// 1. Arrange
+		match, human := newWaitingMatch(t)
+		delete(match.players, human.ID())
+		match.SetBotFill(time.Minute, domain.EasyBot)
+		match.botTimer = time.NewTimer(time.Minute)
+		match.botTimer.Stop()
+		match.gameTurnTimer = time.NewTimer(time.Minute)
+		t.Cleanup(func() {
+			match.botTimer.Stop()
+			match.gameTurnTimer.Stop()
+		})
+
+		// 2. Act
+		require.NoError(t, match.JoinNewPlayer(human))
+		isAwaited := match.botTimer.Reset(time.Minute)
+		require.NoError(t, match.StartPlacement())
+
+		// 3. Assert
+		require.Truef(t, isAwaited, "first player must start the wait for bots")
+		require.Falsef(t, match.botTimer.Stop(), "placement must stop the wait for bots")
+	})
+
+	t.Run("match is closed, when only bots are left", func(t *testing.T) {
+		// 1. Arrange
+		match, human := newWaitingMatch(t)
+		require.NoError(t, match.FillWithBots(domain.EasyBot))
+
+		// 2. Act
+		err := match.RemovePlayer(human)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.IsType(t, &CloseMatchCommand{}, <-match.cmds)
+	})
+}
@@ -1,11 +1,22 @@
 package domain
 
-type CloseMatchCommand struct{}
+import "ws-battleship-shared/pkg/logger"
 
-func NewCloseMatchCommand() *CloseMatchCommand {
-	return &CloseMatchCommand{}
+type CloseMatchCommand struct {
+	logger logger.Logger
 }
 
+func NewCloseMatchCommand(logger logger.Logger) *CloseMatchCommand {
+	return &CloseMatchCommand{logger: logger}
+}
+
+// Execute closes the match apart from the game loop, since the match waits for the loop on close.
+// Whoever needs the match closed waits for [Match.Done].
 func (c *CloseMatchCommand) Execute(executor CommandExecutor) error {
-	return executor.Close()
+	go func() {
+		if err := executor.Close(); err != nil {
+			c.logger.Errorf("failed to close match id=%s: %s", executor.ID(), err)
+		}
+	}()
+	return nil
 }
@@ -1,6 +1,9 @@
 package domain
 
-import "ws-battleship-shared/events"
+import (
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+)
 
 type Command interface {
 	Execute(CommandExecutor) error
@@ -10,8 +13,21 @@ type CommandExecutor interface {
 	ID() string
 	Fire(args events.FireCommandArgs) error
 	GiveTurnToNextPlayer() error
+	ContinueTurn() error
+	TimeoutTurn() error
 	JoinNewPlayer(joinedPlayer *Player) error
+	RemovePlayer(leftPlayer *Player) error
+	DisconnectPlayer(player *Player) error
+	ResumePlayer(reconnectedPlayer *Player) error
+	ExpireSession(player *Player) error
+	KickPlayer(playerID string) error
+	JoinSpectator(spectator *Spectator) error
+	FillWithBots(difficulty domain.BotDifficulty) error
+	StartPlacement() error
+	PlaceFleet(args events.PlaceFleetCommandArgs) error
+	RerollFleet(playerID string) error
 	StartMatch() error
 	EndMatch(winningPlayer *Player) error
+	Announce(message string) error
 	Close() error
 }
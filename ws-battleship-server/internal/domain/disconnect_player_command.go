@@ -0,0 +1,13 @@
+package domain
+
+type DisconnectPlayerCommand struct {
+	player *Player
+}
+
+func NewDisconnectPlayerCommand(player *Player) *DisconnectPlayerCommand {
+	return &DisconnectPlayerCommand{player: player}
+}
+
+func (c *DisconnectPlayerCommand) Execute(executor CommandExecutor) error {
+	return executor.DisconnectPlayer(c.player)
+}
@@ -0,0 +1,13 @@
+package domain
+
+type ExpireSessionCommand struct {
+	player *Player
+}
+
+func NewExpireSessionCommand(player *Player) *ExpireSessionCommand {
+	return &ExpireSessionCommand{player: player}
+}
+
+func (c *ExpireSessionCommand) Execute(executor CommandExecutor) error {
+	return executor.ExpireSession(c.player)
+}
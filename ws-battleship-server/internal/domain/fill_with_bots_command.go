@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/pkg/logger"
+)
+
+type FillWithBotsCommand struct {
+	logger     logger.Logger
+	difficulty domain.BotDifficulty
+}
+
+func NewFillWithBotsCommand(logger logger.Logger, difficulty domain.BotDifficulty) *FillWithBotsCommand {
+	return &FillWithBotsCommand{logger: logger, difficulty: difficulty}
+}
+
+func (c *FillWithBotsCommand) Execute(executor CommandExecutor) error {
+	c.logger.Infof("free seats in match id=%s are taken by %s bots...", executor.ID(), c.difficulty)
+	return executor.FillWithBots(c.difficulty)
+}
@@ -0,0 +1,11 @@
+package domain
+
+type GameContinueTurnCommand struct{}
+
+func NewGameContinueTurnCommand() *GameContinueTurnCommand {
+	return &GameContinueTurnCommand{}
+}
+
+func (c *GameContinueTurnCommand) Execute(executor CommandExecutor) error {
+	return executor.ContinueTurn()
+}
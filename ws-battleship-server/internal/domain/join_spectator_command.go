@@ -0,0 +1,17 @@
+package domain
+
+import "ws-battleship-shared/pkg/logger"
+
+type JoinSpectatorCommand struct {
+	logger    logger.Logger
+	spectator *Spectator
+}
+
+func NewJoinSpectatorCommand(logger logger.Logger, spectator *Spectator) *JoinSpectatorCommand {
+	return &JoinSpectatorCommand{logger: logger, spectator: spectator}
+}
+
+func (c *JoinSpectatorCommand) Execute(executor CommandExecutor) error {
+	c.logger.Infof("spectator %s is joining...", c.spectator)
+	return executor.JoinSpectator(c.spectator)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"ws-battleship-shared/events"
+)
+
+// Journal records events of the match, so it can be replayed later.
+type Journal interface {
+	Record(recipientID string, e events.Event) error
+	Close() error
+}
+
+// FileJournal writes the journal of the match into its own file in JSON Lines.
+type FileJournal struct {
+	mu       sync.Mutex
+	file     *os.File
+	encoder  *json.Encoder
+	isClosed bool
+}
+
+func NewFileJournal(dir, matchID string) (*FileJournal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	file, err := os.Create(filepath.Join(dir, matchID+".jsonl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create journal file: %w", err)
+	}
+
+	return &FileJournal{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (j *FileJournal) Record(recipientID string, e events.Event) error {
+	// Resume tokens are secrets of players, they must not be shared with a replay.
+	if e.Type == events.SessionEventType {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.encoder.Encode(events.JournalEntry{
+		Time:        time.Now(),
+		RecipientID: recipientID,
+		Event:       e,
+	})
+}
+
+func (j *FileJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.isClosed {
+		return nil
+	}
+	j.isClosed = true
+	return j.file.Close()
+}
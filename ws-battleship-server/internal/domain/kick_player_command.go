@@ -0,0 +1,13 @@
+package domain
+
+type KickPlayerCommand struct {
+	playerID string
+}
+
+func NewKickPlayerCommand(playerID string) *KickPlayerCommand {
+	return &KickPlayerCommand{playerID: playerID}
+}
+
+func (c *KickPlayerCommand) Execute(executor CommandExecutor) error {
+	return executor.KickPlayer(c.playerID)
+}
@@ -1,37 +1,100 @@
 package domain
 
 import (
+	"cmp"
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
-	"math/rand"
+	"maps"
+	"math"
 	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 	"ws-battleship-server/internal/config"
+	"ws-battleship-server/internal/delivery/websocket"
 	"ws-battleship-shared/domain"
 	"ws-battleship-shared/events"
 	"ws-battleship-shared/pkg/logger"
+
+	"github.com/google/uuid"
 )
 
+type spectatorEvent struct {
+	event events.Event
+	// spectatorID is empty, if the event is sent to all spectators.
+	spectatorID string
+	sendAt      time.Time
+}
+
+// chatHistoryMax limits the chat, which is sent back to the player, who resumed the session.
+const chatHistoryMax = 100
+
 type Match struct {
 	closeCh chan struct{}
 	cancel  context.CancelFunc
 	wg      sync.WaitGroup
 	once    sync.Once
 	mu      sync.RWMutex
-
-	room   *Room
-	cfg    *config.Config
-	logger logger.Logger
+	// doneCh is closed with closeErr, once the match has closed.
+	doneCh   chan struct{}
+	doneOnce sync.Once
+	closeErr error
+
+	room *Room
+	// audience holds spectators apart from players, so they can't take part in the game.
+	audience *Room
+	// journal records the match, it's nil if recording is disabled.
+	journal Journal
+	cfg     *config.Config
+	logger  logger.Logger
 
 	isStarted        atomic.Bool
+	isPlacing        atomic.Bool
 	isClosed         atomic.Bool
+	isEnded          bool
 	gameTurnTimer    *time.Timer
+	closeTimer       *time.Timer
+	botTimer         *time.Timer
+	turnDeadline     time.Time
+	pausedTurnTime   time.Duration
+	isTurnPaused     bool
 	players          map[string]*Player
 	turningPlayer    *Player
 	turningPlayerIdx int
+	teamTurnIdx      map[int]int
 	gameModel        domain.GameModel
+	rules            domain.GameRules
+	// rng draws boards and the turn order. The match is reproduced with the same seed and inputs.
+	rng  domain.RNG
+	seed int64
+	// seats counts joined players, bots included.
+	seats int
+	// bots counts bots, which have ever joined, to give them unique IDs.
+	bots int
+	// botWait is set for public rooms, whose free seats are taken by bots, if nobody joins in time.
+	botWait       time.Duration
+	botDifficulty domain.BotDifficulty
+	// code is set for private rooms, which are skipped by the matchmaking.
+	code     string
+	password string
+	// profiles get the result of the match. Players, who started the match, are recorded at
+	// its end, even if they left before.
+	profiles       ProfileStore
+	history        MatchHistory
+	startedPlayers []*Player
+	startedAt      time.Time
+	isRated        bool
+
+	// resumeTokens maps tokens of disconnected players to their IDs.
+	resumeTokens map[string]string
+	chatHistory  []events.Event
+
+	spectatorQueue    []spectatorEvent
+	spectatorNotifyCh chan struct{}
 
 	cmds     chan Command
 	eventBus *events.EventBus
@@ -40,27 +103,70 @@ type Match struct {
 func NewMatch(ctx context.Context, cfg *config.Config, logger logger.Logger) *Match {
 	matchCtx, cancel := context.WithCancel(ctx)
 
+	rules, err := cfg.Game.Rules()
+	if err != nil {
+		logger.Errorf("invalid game rules, the classic ones are used instead: %s", err)
+		rules = domain.DefaultRules()
+	}
+
+	seed := cfg.Game.Seed
+	if seed == 0 {
+		seed = domain.NewSeed()
+	}
+
+	audienceCfg := cfg.App
+	audienceCfg.RoomCapacityMax = cfg.App.SpectatorsMax
+
 	match := &Match{
-		closeCh:       make(chan struct{}),
-		cancel:        cancel,
-		room:          NewRoom(matchCtx, &cfg.App, logger),
-		cfg:           cfg,
-		logger:        logger,
-		gameTurnTimer: time.NewTimer(0),
-		players:       make(map[string]*Player, cfg.App.ClientsConnectionsMax),
-		cmds:          make(chan Command, 10),
-		eventBus:      events.NewEventBus(),
+		closeCh:           make(chan struct{}),
+		doneCh:            make(chan struct{}),
+		cancel:            cancel,
+		room:              NewRoom(matchCtx, &cfg.App, logger),
+		audience:          NewRoom(matchCtx, &audienceCfg, logger),
+		spectatorNotifyCh: make(chan struct{}, 1),
+		cfg:               cfg,
+		logger:            logger,
+		gameTurnTimer:     time.NewTimer(0),
+		closeTimer:        time.NewTimer(0),
+		botTimer:          time.NewTimer(0),
+		players:           make(map[string]*Player, cfg.App.ClientsConnectionsMax),
+		rules:             rules,
+		rng:               domain.NewRNG(seed),
+		seed:              seed,
+		teamTurnIdx:       make(map[int]int),
+		resumeTokens:      make(map[string]string),
+		cmds:              make(chan Command, 10),
+		eventBus:          events.NewEventBus(),
+	}
+
+	if dir := cfg.Game.JournalDir; dir != "" {
+		journal, err := NewFileJournal(dir, match.ID())
+		if err != nil {
+			logger.Errorf("match id=%s won't be recorded: %s", match.ID(), err)
+		} else {
+			match.journal = journal
+			match.room.SetJournal(journal)
+		}
 	}
 
 	match.room.SetClientJoinedHandler(match.onPlayerJoinedHandler)
 	match.room.SetClientLeftHandler(match.onPlayerLeftHandler)
 	match.eventBus.Subscribe(events.SendMessageType, match.onPlayerSentMessageHandler)
 	match.eventBus.Subscribe(events.PlayerFireEventType, match.onPlayerFiredHandler)
+	match.eventBus.Subscribe(events.PlaceFleetEventType, match.onPlayerPlacedFleetHandler)
+	match.eventBus.Subscribe(events.RerollFleetEventType, match.onPlayerRerolledFleetHandler)
 
 	<-match.gameTurnTimer.C
+	<-match.closeTimer.C
+	<-match.botTimer.C
 	match.wg.Add(1)
 	go match.gameLoop(ctx)
 
+	if cfg.Game.SpectatorDelay > 0 {
+		match.wg.Add(1)
+		go match.streamToSpectators(ctx)
+	}
+
 	return match
 }
 
@@ -68,6 +174,156 @@ func (m *Match) ID() string {
 	return m.room.ID()
 }
 
+// Seed returns the seed, which reproduces the match.
+func (m *Match) Seed() int64 {
+	return m.seed
+}
+
+// SetPrivate hides the match from the matchmaking. Players join it by the code and the password,
+// if it's set. It must be called before the match is shared.
+func (m *Match) SetPrivate(code, password string) {
+	m.code = code
+	m.password = password
+}
+
+func (m *Match) IsPrivate() bool {
+	return m.code != ""
+}
+
+func (m *Match) Code() string {
+	return m.code
+}
+
+// SetBotFill makes bots take free seats, if nobody joins the first player in time. It must be
+// called before players join.
+func (m *Match) SetBotFill(wait time.Duration, difficulty domain.BotDifficulty) {
+	m.botWait = wait
+	m.botDifficulty = difficulty
+}
+
+// SetProfileStore makes the match record its result into profiles of players. It must be called
+// before players join.
+func (m *Match) SetProfileStore(profiles ProfileStore) {
+	m.profiles = profiles
+}
+
+// SetMatchHistory makes the match save its result into the history. It must be called before
+// the match starts.
+func (m *Match) SetMatchHistory(history MatchHistory) {
+	m.history = history
+}
+
+// SetRated makes the match update ratings of players at its end. It must be called before
+// players join.
+func (m *Match) SetRated() {
+	m.isRated = true
+}
+
+func (m *Match) IsRated() bool {
+	return m.isRated
+}
+
+func (m *Match) CheckPassword(password string) error {
+	if subtle.ConstantTimeCompare([]byte(m.password), []byte(password)) != 1 {
+		return ErrWrongRoomPassword
+	}
+	return nil
+}
+
+// Summary describes the match for the lobby. It's safe to call from other goroutines.
+func (m *Match) Summary() domain.MatchSummary {
+	summary := domain.MatchSummary{
+		ID:       m.ID(),
+		Players:  make([]string, 0, m.room.Capacity()),
+		Capacity: int(m.cfg.App.RoomCapacityMax),
+		Rules:    m.rules,
+		Status:   m.status(),
+	}
+
+	for _, client := range m.room.GetClients() {
+		if player, ok := client.(*Player); ok {
+			summary.Players = append(summary.Players, player.Nickname())
+		}
+	}
+	slices.Sort(summary.Players)
+	return summary
+}
+
+func (m *Match) status() domain.MatchStatus {
+	switch {
+	case m.isStarted.Load():
+		return domain.RunningMatch
+	case m.isPlacing.Load():
+		return domain.PlacingMatch
+	}
+	return domain.WaitingMatch
+}
+
+// PlayerDetails describes the connected player for the admin.
+type PlayerDetails struct {
+	ID        string `json:"id"`
+	Nickname  string `json:"nickname"`
+	ProfileID string `json:"profile_id,omitempty"`
+	IsBot     bool   `json:"is_bot"`
+}
+
+// MatchDetails describes the match for the admin, private and rated matches included.
+type MatchDetails struct {
+	ID         string             `json:"id"`
+	Status     domain.MatchStatus `json:"status"`
+	Seed       int64              `json:"seed"`
+	Code       string             `json:"code,omitempty"`
+	IsRated    bool               `json:"is_rated"`
+	IsClosed   bool               `json:"is_closed"`
+	Capacity   int                `json:"capacity"`
+	Players    []PlayerDetails    `json:"players"`
+	Spectators int                `json:"spectators"`
+}
+
+// Details describes the match for the admin. It's safe to call from other goroutines.
+func (m *Match) Details() MatchDetails {
+	details := MatchDetails{
+		ID:         m.ID(),
+		Status:     m.status(),
+		Seed:       m.Seed(),
+		Code:       m.Code(),
+		IsRated:    m.IsRated(),
+		IsClosed:   m.IsClosed(),
+		Capacity:   int(m.cfg.App.RoomCapacityMax),
+		Players:    make([]PlayerDetails, 0, m.room.Capacity()),
+		Spectators: m.audience.Capacity(),
+	}
+
+	for _, client := range m.room.GetClients() {
+		if player, ok := client.(*Player); ok {
+			details.Players = append(details.Players, PlayerDetails{
+				ID:        player.ID(),
+				Nickname:  player.Nickname(),
+				ProfileID: player.ProfileID(),
+				IsBot:     player.IsBot(),
+			})
+		}
+	}
+
+	slices.SortFunc(details.Players, func(lhs, rhs PlayerDetails) int {
+		return strings.Compare(lhs.ID, rhs.ID)
+	})
+	return details
+}
+
+// HasPlayer reports whether the player is connected to the match. It's safe to call from other
+// goroutines.
+func (m *Match) HasPlayer(playerID string) bool {
+	return slices.ContainsFunc(m.room.GetClients(), func(client websocket.Client) bool {
+		_, isPlayer := client.(*Player)
+		return isPlayer && client.ID() == playerID
+	})
+}
+
+func (m *Match) IsClosed() bool {
+	return m.isClosed.Load()
+}
+
 func (m *Match) Equal(rhs *Match) bool {
 	if rhs == nil {
 		return false
@@ -83,11 +339,33 @@ func (m *Match) Compare(rhs *Match) int {
 }
 
 func (m *Match) Close() error {
+	err := m.shutdown()
+	m.doneOnce.Do(func() {
+		m.closeErr = err
+		close(m.doneCh)
+	})
+	return err
+}
+
+// Done is closed, once the match has closed. [Match.CloseErr] tells then, whether it failed.
+func (m *Match) Done() <-chan struct{} {
+	return m.doneCh
+}
+
+func (m *Match) CloseErr() error {
+	select {
+	case <-m.doneCh:
+		return m.closeErr
+	default:
+		return nil
+	}
+}
+
+func (m *Match) shutdown() error {
 	m.once.Do(func() {
 		m.isClosed.Store(true)
 		m.cancel()
 		close(m.closeCh)
-		close(m.cmds)
 		m.logger.Infof("match id=%s is closing...", m.ID())
 	})
 
@@ -95,7 +373,21 @@ func (m *Match) Close() error {
 		return err
 	}
 
+	if err := m.audience.Close(); err != nil {
+		return err
+	}
+
 	m.wg.Wait()
+
+	// The game loop is over, so players can't be changed anymore.
+	m.stopResumeTimers()
+
+	if m.journal != nil {
+		if err := m.journal.Close(); err != nil {
+			return err
+		}
+	}
+
 	m.logger.Infof("match id=%s is closed", m.ID())
 	return nil
 }
@@ -105,262 +397,1369 @@ func (m *Match) JoinNewPlayer(newPlayer *Player) error {
 		return err
 	}
 
+	newPlayer.SetBoard(domain.RandomizeBoardWithRNG(m.rng, m.rules))
+	newPlayer.resumeToken = uuid.New().String()
+	m.takeSeat(newPlayer)
 	m.players[newPlayer.ID()] = newPlayer
 
-	return m.room.JoinNewClient(newPlayer)
-}
-
-func (m *Match) CheckIsAvailableForJoin() error {
-	switch {
-	case m.isClosed.Load():
-		return ErrRoomIsClosed
-	case m.isStarted.Load():
-		return ErrAlreadyStarted
-	case m.room.IsFull():
-		return ErrRoomIsFull
+	// The wait for bots starts once per match, with the first player.
+	if m.botWait > 0 && len(m.players) == 1 {
+		m.botTimer.Reset(m.botWait)
 	}
-	return nil
-}
 
-func (m *Match) IsReadyToStart() bool {
-	return !m.isClosed.Load() && !m.isStarted.Load() && m.room.IsFull()
+	return m.room.JoinNewClient(newPlayer)
 }
 
-func (m *Match) StartMatch() error {
-	m.isStarted.Store(true)
-
-	event, err := events.NewGameStartEvent()
-	if err != nil {
-		return err
+// FillWithBots takes the free seats with bots, unless the match has already begun.
+func (m *Match) FillWithBots(difficulty domain.BotDifficulty) error {
+	if m.isClosed.Load() || m.isStarted.Load() || m.isPlacing.Load() || len(m.players) == 0 {
+		return nil
 	}
 
-	if err := m.room.Broadcast(event); err != nil {
+	strategy, err := domain.NewBotStrategy(difficulty)
+	if err != nil {
 		return err
 	}
 
-	m.Dispatch(NewGameTurnCommand())
+	for len(m.players) < int(m.cfg.App.RoomCapacityMax) {
+		m.bots++
+		bot := NewBot(fmt.Sprintf("bot-%d", m.bots), strategy, domain.NewRNG(int64(m.rng.Intn(math.MaxInt32))), m.logger)
+		player := NewPlayer(bot, domain.ClientMetadata{
+			ClientID: bot.ID(),
+			Nickname: fmt.Sprintf("Bot %d (%s)", m.bots, difficulty),
+		})
 
-	return m.SendNotification("Game started!", events.RoomNotificationType)
+		if err := m.JoinNewPlayer(player); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (m *Match) EndMatch(winningPlayer *Player) error {
-	event, err := events.NewGameEndEvent(winningPlayer.Model)
-	if err != nil {
-		return err
+// RemovePlayer takes the left player out of the match. If only one fleet remains afloat,
+// its owner wins.
+func (m *Match) RemovePlayer(leftPlayer *Player) error {
+	if leftPlayer == nil {
+		return nil
 	}
 
-	if err := m.room.Broadcast(event); err != nil {
-		return err
+	delete(m.players, leftPlayer.ID())
+
+	// Bots don't play on their own.
+	if len(m.players) > 0 && !m.hasHumanPlayers() {
+		m.Dispatch(NewCloseMatchCommand(m.logger))
+		return nil
 	}
 
-	_ = m.SendNotification(fmt.Sprintf("Player '%s' has won!", winningPlayer.Nickname()), events.RoomNotificationType)
+	if !m.isStarted.Load() || m.isEnded {
+		return nil
+	}
 
-	m.Dispatch(NewCloseMatchCommand())
+	alivePlayers := m.getAlivePlayers()
+	switch {
+	case len(alivePlayers) == 0:
+		m.Dispatch(NewCloseMatchCommand(m.logger))
+	case m.isOver():
+		m.Dispatch(NewGameEndCommand(m.logger, alivePlayers[0]))
+	case leftPlayer.Equal(m.turningPlayer):
+		m.Dispatch(NewGameTurnCommand())
+	}
 	return nil
 }
 
-func (m *Match) GiveTurnToNextPlayer() error {
-	defer m.resetGameTurnTimer()
+// DisconnectPlayer keeps the player, whose connection dropped in the middle of the match, for the
+// reconnect time. Meanwhile the turn timer is paused.
+func (m *Match) DisconnectPlayer(player *Player) error {
+	if player == nil {
+		return nil
+	}
 
-	m.gameModel.TurnCount++
+	// The player might have been removed already, like the kicked one.
+	if _, found := m.players[player.ID()]; !found {
+		return nil
+	}
 
-	if m.turningPlayer == nil {
-		return m.GiveTurnToPlayer(m.getRandomPlayer())
-	} else {
-		return m.GiveTurnToPlayer(m.getNextTarget())
+	if !m.isStarted.Load() || m.isEnded || m.cfg.Game.ReconnectTime <= 0 || player.IsEliminated() || player.isKicked {
+		m.announcePlayerLeft(player)
+		return m.RemovePlayer(player)
 	}
-}
 
-func (m *Match) GiveTurnToPlayer(turningPlayer *Player) error {
-	m.turningPlayer = turningPlayer
+	player.isDisconnected = true
+	player.resumeDeadline = time.Now().Add(m.cfg.Game.ReconnectTime)
+	player.resumeTimer = time.AfterFunc(m.cfg.Game.ReconnectTime, func() {
+		m.Dispatch(NewExpireSessionCommand(player))
+	})
+
+	m.mu.Lock()
+	if m.resumeTokens == nil {
+		m.resumeTokens = make(map[string]string)
+	}
+	m.resumeTokens[player.resumeToken] = player.ID()
+	m.mu.Unlock()
 
-	event, err := events.NewPlayerTurnEvent(m.gameModel.TurnCount, turningPlayer.ID(), m.cfg.Game.GameTurnTime)
+	m.pauseGameTurnTimer()
+
+	event, err := events.NewPlayerDisconnectedEvent(player.ID(), m.cfg.Game.ReconnectTime)
 	if err != nil {
 		return err
 	}
 
-	if err := m.room.Broadcast(event); err != nil {
+	if err := m.broadcast(event); err != nil {
 		return err
 	}
 
-	return m.SendNotification(fmt.Sprintf("Player '%s' turns now.", turningPlayer.Nickname()), events.GameNotificationType)
+	return m.SendNotification(fmt.Sprintf("Player '%s' lost connection. Waiting %s for them to reconnect...", player.Nickname(), m.cfg.Game.ReconnectTime), events.RoomNotificationType)
 }
 
-func (m *Match) SendNotification(msg string, notificationType events.ChatMessageType) error {
-	event, err := events.NewChatNotificationEvent(msg, notificationType)
-	if err != nil {
-		return fmt.Errorf("failed to send a chat notification: %w", err)
+// ResumePlayer gives the dropped session back to the reconnected player, if the resume token matches.
+func (m *Match) ResumePlayer(reconnectedPlayer *Player) error {
+	player, found := m.players[reconnectedPlayer.ID()]
+	if !found || !player.isDisconnected || subtle.ConstantTimeCompare([]byte(player.resumeToken), []byte(reconnectedPlayer.resumeToken)) != 1 {
+		m.logger.Errorf("player %s failed to resume the session in match id=%s: %s", reconnectedPlayer, m.ID(), ErrInvalidResumeToken)
+		reconnectedPlayer.Close()
+		return nil
 	}
-	return m.room.Broadcast(event)
-}
 
-func (m *Match) Fire(args events.FireCommandArgs) error {
-	if m.turningPlayer.ID() != args.FiringPlayerID {
-		return ErrNotYourTurn
+	if player.resumeTimer != nil {
+		player.resumeTimer.Stop()
 	}
+	player.isDisconnected = false
+	m.forgetResumeToken(player)
 
-	firingPlayer := m.players[args.FiringPlayerID]
-	targetPlayer := m.players[args.TargetPlayerID]
-
-	if err := m.fireAtCell(targetPlayer.Model, args.CellX, args.CellY); err != nil {
+	if err := m.room.ResumeClient(player, func() { player.Client = reconnectedPlayer.Client }); err != nil {
 		return err
 	}
-	firingPlayer.RevealCell(args.CellX, args.CellY)
 
-	_ = m.SendNotification(fmt.Sprintf("Player '%s' fired at cell (%s).", firingPlayer.Nickname(), targetPlayer.Model.Board.CellString(args.CellX, args.CellY)), events.GameNotificationType)
+	if !m.hasDisconnectedPlayers() {
+		m.resumeGameTurnTimer()
+	}
 
-	if err := m.allPlayersUpdate(); err != nil {
+	if err := m.sendResumedSession(player); err != nil {
 		return err
 	}
 
-	if targetPlayer.Model.IsDead() {
-		m.Dispatch(NewGameEndCommand(m.logger, m.turningPlayer))
-	} else {
-		m.Dispatch(NewGameTurnCommand())
+	event, err := events.NewPlayerReconnectedEvent(player.ID(), m.remainingTurnTime())
+	if err != nil {
+		return err
 	}
-	return nil
-}
 
-func (m *Match) Dispatch(cmd Command) {
-	if m.isClosed.Load() {
-		return
+	if err := m.broadcast(event); err != nil {
+		return err
 	}
-	m.cmds <- cmd
+
+	m.logger.Infof("player %s resumed the session in match id=%s", player, m.ID())
+	return m.SendNotification(fmt.Sprintf("Player '%s' reconnected.", player.Nickname()), events.RoomNotificationType)
 }
 
-func (m *Match) GetPlayers() []*Player {
-	players := make([]*Player, 0, len(m.players))
+// stopResumeTimers keeps sessions of disconnected players from expiring in the ended or closed match.
+func (m *Match) stopResumeTimers() {
 	for _, player := range m.players {
-		players = append(players, player)
+		if player.resumeTimer != nil {
+			player.resumeTimer.Stop()
+		}
 	}
-
-	slices.SortFunc(players, func(lhs, rhs *Player) int {
-		return lhs.Compare(rhs)
-	})
-
-	return players
 }
 
-func (m *Match) getRandomPlayer() *Player {
-	if len(m.players) == 0 {
+// ExpireSession removes the disconnected player, who didn't manage to reconnect in time.
+func (m *Match) ExpireSession(player *Player) error {
+	if _, found := m.players[player.ID()]; !found {
 		return nil
 	}
 
-	return m.GetPlayers()[rand.Intn(len(m.players))]
-}
-
-func (m *Match) getNextTarget() *Player {
-	if m.turningPlayerIdx+1 >= len(m.players) {
-		m.turningPlayerIdx = 0
-	} else {
-		m.turningPlayerIdx++
+	// The player might have reconnected right before the timer fired.
+	if !player.isDisconnected || time.Now().Before(player.resumeDeadline) {
+		return nil
 	}
-	return m.GetPlayers()[m.turningPlayerIdx]
-}
 
-func (m *Match) gameLoop(ctx context.Context) {
-	defer func() {
-		m.gameTurnTimer.Stop()
-		m.wg.Done()
-	}()
+	m.forgetResumeToken(player)
+	m.announcePlayerLeft(player)
 
-	for {
-		if err := ctx.Err(); err != nil {
-			return
-		}
+	if err := m.RemovePlayer(player); err != nil {
+		return err
+	}
 
-		select {
-		case <-ctx.Done():
-			return
+	if !m.hasDisconnectedPlayers() {
+		m.resumeGameTurnTimer()
+	}
+	return nil
+}
 
-		case <-m.closeCh:
-			m.logger.Infof("stoping game loop in match id=%s", m.ID())
-			return
+// KickPlayer removes the player by the admin. The connection is closed and the session can't be
+// resumed.
+func (m *Match) KickPlayer(playerID string) error {
+	player, found := m.players[playerID]
+	if !found {
+		m.logger.Errorf("failed to kick player id=%s from match id=%s: %s", playerID, m.ID(), ErrPlayerNotExist)
+		return nil
+	}
 
-		case <-m.gameTurnTimer.C:
-			m.Dispatch(NewGameTurnCommand())
+	player.isKicked = true
+	m.logger.Infof("player %s is kicked from match id=%s", player, m.ID())
+	if err := m.SendNotification(fmt.Sprintf("Player '%s' was kicked by the server.", player.Nickname()), events.RoomNotificationType); err != nil {
+		m.logger.Error(err)
+	}
 
-		case cmd, opened := <-m.cmds:
-			if !opened {
-				return
-			}
-			if err := cmd.Execute(m); err != nil {
-				m.logger.Errorf("failed to execute a command: %s", err)
-				m.Dispatch(NewCloseMatchCommand())
-			}
+	if !player.isDisconnected {
+		// The room closes the connection and the player is removed, once it has left.
+		m.room.LeaveClient(player)
+		return nil
+	}
 
-		case msg, opened := <-m.room.Events():
-			if !opened {
-				return
-			}
+	if player.resumeTimer != nil {
+		player.resumeTimer.Stop()
+	}
+	player.resumeDeadline = time.Now()
+	return m.ExpireSession(player)
+}
 
-			if err := m.eventBus.Invoke(msg); err != nil {
-				m.logger.Errorf("error while invoking event: %s", err)
-			}
-		}
+// IsAwaitingPlayer reports whether a disconnected player with the resume token can come back.
+func (m *Match) IsAwaitingPlayer(resumeToken string) bool {
+	if m.isClosed.Load() {
+		return false
 	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, found := m.resumeTokens[resumeToken]
+	return found
 }
 
-func (m *Match) resetGameTurnTimer() {
-	m.gameTurnTimer.Reset(m.cfg.Game.GameTurnTime)
+func (m *Match) CheckIsAvailableForJoin() error {
+	switch {
+	case m.isClosed.Load():
+		return ErrRoomIsClosed
+	case m.isStarted.Load(), m.isPlacing.Load():
+		return ErrAlreadyStarted
+	case m.room.IsFull():
+		return ErrRoomIsFull
+	}
+	return nil
 }
 
-func (m *Match) allPlayersUpdate() error {
-	for playerID, player := range m.players {
-		gameModel := m.buildGameModelForPlayer(player)
+func (m *Match) IsReadyToStart() bool {
+	return !m.isClosed.Load() && !m.isStarted.Load() && !m.isPlacing.Load() && m.room.IsFull()
+}
 
-		event, err := events.NewPlayerUpdateStateEvent(&gameModel)
-		if err != nil {
-			return err
-		}
+func (m *Match) StartPlacement() error {
+	m.isPlacing.Store(true)
+	m.botTimer.Stop()
+	m.turnDeadline = time.Now().Add(m.cfg.Game.GamePlacementTime)
+	m.gameTurnTimer.Reset(m.cfg.Game.GamePlacementTime)
 
-		if err := m.room.SendMessageToClient(playerID, event); err != nil {
+	if m.rules.IsTeamMatch {
+		if err := m.assignTeams(); err != nil {
 			return err
 		}
 	}
 
-	return nil
-}
-
-func (m *Match) buildGameModelForPlayer(targetPlayer *Player) domain.GameModel {
-	maskedGameModel := domain.GameModel{
-		TurnCount: m.gameModel.TurnCount,
-		Players:   make(map[string]*domain.PlayerModel, len(m.players)),
+	event, err := events.NewPlacementStartEvent(m.rules, m.cfg.Game.GamePlacementTime)
+	if err != nil {
+		return err
 	}
 
-	for playerID, player := range m.players {
-		playerModel := domain.NewPlayerModel(player.Model.Board, domain.ClientMetadata{
-			ClientID: player.Model.ID,
-			Nickname: player.Model.Nickname,
+	if err := m.broadcast(event); err != nil {
+		return err
+	}
+
+	return m.SendNotification("Place your fleet!", events.RoomNotificationType)
+}
+
+func (m *Match) PlaceFleet(args events.PlaceFleetCommandArgs) error {
+	player, found := m.players[args.PlayerID]
+	if !found {
+		return ErrPlayerNotExist
+	}
+
+	switch {
+	case !m.isPlacing.Load():
+		return m.SendNotificationToPlayer(player.ID(), ErrNotPlacing.Error(), events.GameNotificationType)
+	case player.IsReady():
+		return m.SendNotificationToPlayer(player.ID(), ErrAlreadyReady.Error(), events.GameNotificationType)
+	}
+
+	board, err := domain.BuildBoard(args.Ships, m.rules)
+	if err != nil {
+		return m.SendNotificationToPlayer(player.ID(), fmt.Sprintf("Invalid fleet layout: %s.", err), events.GameNotificationType)
+	}
+
+	player.SetBoard(board)
+	player.SetReady(true)
+
+	if err := m.playerUpdate(player); err != nil {
+		return err
+	}
+
+	event, err := events.NewPlayerReadyEvent(player.ID())
+	if err != nil {
+		return err
+	}
+
+	if err := m.broadcast(event); err != nil {
+		return err
+	}
+
+	_ = m.SendNotification(fmt.Sprintf("Player '%s' is ready.", player.Nickname()), events.RoomNotificationType)
+
+	if m.allPlayersReady() {
+		m.Dispatch(NewGameStartCommand(m.logger))
+	}
+	return nil
+}
+
+func (m *Match) RerollFleet(playerID string) error {
+	player, found := m.players[playerID]
+	if !found {
+		return ErrPlayerNotExist
+	}
+
+	switch {
+	case !m.isPlacing.Load():
+		return m.SendNotificationToPlayer(player.ID(), ErrNotPlacing.Error(), events.GameNotificationType)
+	case player.IsReady():
+		return m.SendNotificationToPlayer(player.ID(), ErrAlreadyReady.Error(), events.GameNotificationType)
+	}
+
+	player.SetBoard(domain.RandomizeBoardWithRNG(m.rng, m.rules))
+	return m.playerUpdate(player)
+}
+
+func (m *Match) StartMatch() error {
+	// The placement timer may expire right after the last player got ready, so the match
+	// could be requested to start twice.
+	if m.isStarted.Load() {
+		return nil
+	}
+
+	m.isStarted.Store(true)
+	m.isPlacing.Store(false)
+
+	m.startedPlayers = slices.SortedFunc(maps.Values(m.players), (*Player).Compare)
+	m.startedAt = time.Now()
+
+	// Fleets are placed, so the journal gets them before the first shot.
+	if err := m.recordGameModel(); err != nil {
+		return err
+	}
+
+	event, err := events.NewGameStartEvent(m.seed)
+	if err != nil {
+		return err
+	}
+
+	if err := m.broadcast(event); err != nil {
+		return err
+	}
+	m.logger.Infof("match id=%s started with seed=%d", m.ID(), m.seed)
+
+	m.Dispatch(NewGameTurnCommand())
+
+	return m.SendNotification(fmt.Sprintf("Game started! Seed: %d.", m.seed), events.RoomNotificationType)
+}
+
+func (m *Match) EndMatch(winningPlayer *Player) error {
+	m.isEnded = true
+	m.gameTurnTimer.Stop()
+	// Sessions aren't resumed in the ended match.
+	m.stopResumeTimers()
+
+	// Spectators get all boards revealed.
+	if err := m.spectatorsUpdate(); err != nil {
+		return err
+	}
+
+	event, err := events.NewGameEndEvent(winningPlayer.Model)
+	if err != nil {
+		return err
+	}
+
+	if err := m.broadcast(event); err != nil {
+		return err
+	}
+
+	if winningPlayer.Model.Team != domain.NoTeam {
+		_ = m.SendNotification(fmt.Sprintf("Team %d has won!", winningPlayer.Model.Team), events.RoomNotificationType)
+	} else {
+		_ = m.SendNotification(fmt.Sprintf("Player '%s' has won!", winningPlayer.Nickname()), events.RoomNotificationType)
+	}
+
+	m.updateProfiles(winningPlayer)
+	m.recordResult(winningPlayer)
+
+	// Delayed spectators see the end of the match later, so it's closed only then.
+	if delay := m.cfg.Game.SpectatorDelay; delay > 0 {
+		m.closeTimer.Reset(delay)
+	} else {
+		m.Dispatch(NewCloseMatchCommand(m.logger))
+	}
+	return nil
+}
+
+// JoinSpectator lets the spectator watch the match. The spectator gets the current state of the
+// match, which is delayed like all other events.
+func (m *Match) JoinSpectator(spectator *Spectator) error {
+	if err := m.audience.RegisterClient(spectator); err != nil {
+		m.logger.Errorf("failed to register spectator %s in match id=%s: %s", spectator, m.ID(), err)
+		spectator.Close()
+		return nil
+	}
+
+	gameState := m.buildGameStateForPlayer(nil)
+	event, err := events.NewPlayerUpdateStateEvent(gameState)
+	if err != nil {
+		return err
+	}
+	m.spectateTo(spectator.ID(), event)
+
+	for _, msg := range m.getChatHistory() {
+		m.spectateTo(spectator.ID(), msg)
+	}
+
+	if m.isStarted.Load() && !m.isEnded && m.turningPlayer != nil {
+		gameStartEvent, err := events.NewGameStartEvent(m.seed)
+		if err != nil {
+			return err
+		}
+		m.spectateTo(spectator.ID(), gameStartEvent)
+
+		playerTurnEvent, err := events.NewPlayerTurnEvent(m.gameModel.TurnCount, m.turningPlayer.ID(), m.remainingTurnTime(), false, m.rules.ShotsPerTurn(m.turningPlayer.Model))
+		if err != nil {
+			return err
+		}
+		m.spectateTo(spectator.ID(), playerTurnEvent)
+	}
+
+	m.logger.Infof("spectator %s joined the match id=%s [spectators: %d]", spectator, m.ID(), m.audience.Capacity())
+	return m.SendNotification(fmt.Sprintf("Spectator '%s' is watching the game.", spectator.Nickname()), events.RoomNotificationType)
+}
+
+func (m *Match) GiveTurnToNextPlayer() error {
+	defer m.resetGameTurnTimer()
+
+	m.gameModel.TurnCount++
+
+	if m.turningPlayer == nil {
+		return m.GiveTurnToPlayer(m.getRandomPlayer())
+	} else {
+		return m.GiveTurnToPlayer(m.getNextTarget())
+	}
+}
+
+// ContinueTurn keeps the turn with the current player, e.g. after a hit when the
+// "hit grants another shot" rule is on.
+func (m *Match) ContinueTurn() error {
+	if m.turningPlayer == nil {
+		return ErrNotStarted
+	}
+
+	defer m.resetGameTurnTimer()
+
+	m.gameModel.TurnCount++
+
+	if err := m.broadcastPlayerTurn(m.turningPlayer, true); err != nil {
+		return err
+	}
+
+	return m.SendNotification(fmt.Sprintf("Player '%s' hit and fires again.", m.turningPlayer.Nickname()), events.GameNotificationType)
+}
+
+func (m *Match) GiveTurnToPlayer(turningPlayer *Player) error {
+	m.turningPlayer = turningPlayer
+
+	if err := m.broadcastPlayerTurn(turningPlayer, false); err != nil {
+		return err
+	}
+
+	return m.SendNotification(fmt.Sprintf("Player '%s' turns now.", turningPlayer.Nickname()), events.GameNotificationType)
+}
+
+// Announce sends the message of the server to everybody in the match.
+func (m *Match) Announce(message string) error {
+	return m.SendNotification(fmt.Sprintf("Server announcement: %s", message), events.RoomNotificationType)
+}
+
+func (m *Match) SendNotification(msg string, notificationType events.ChatMessageType) error {
+	event, err := events.NewChatNotificationEvent(msg, notificationType)
+	if err != nil {
+		return fmt.Errorf("failed to send a chat notification: %w", err)
+	}
+
+	m.appendChatHistory(event)
+	return m.broadcast(event)
+}
+
+func (m *Match) SendNotificationToPlayer(playerID string, msg string, notificationType events.ChatMessageType) error {
+	event, err := events.NewChatNotificationEvent(msg, notificationType)
+	if err != nil {
+		return fmt.Errorf("failed to send a chat notification: %w", err)
+	}
+	return m.room.SendMessageToClient(playerID, event)
+}
+
+func (m *Match) Fire(args events.FireCommandArgs) error {
+	if !m.isStarted.Load() || m.turningPlayer == nil {
+		return m.refuseShot(args.FiringPlayerID, ErrNotStarted)
+	}
+
+	if m.isEnded {
+		return m.refuseShot(args.FiringPlayerID, ErrMatchIsOver)
+	}
+
+	// The shot might have been sent right before the turn ran out.
+	if m.turningPlayer.ID() != args.FiringPlayerID {
+		return m.refuseShot(args.FiringPlayerID, ErrNotYourTurn)
+	}
+
+	m.turningPlayer.timeouts = 0
+	return m.fire(args)
+}
+
+// refuseShot tells the shooter why the shot is not taken. The match goes on, since the shot might
+// just come late, and the shooter might have already left.
+func (m *Match) refuseShot(playerID string, reason error) error {
+	err := m.SendNotificationToPlayer(playerID, reason.Error(), events.GameNotificationType)
+	if errors.Is(err, ErrPlayerNotExist) {
+		return nil
+	}
+	return err
+}
+
+// TimeoutTurn applies the timeout policy to the player, who didn't fire in time. After too many
+// timeouts in a row the player forfeits.
+func (m *Match) TimeoutTurn() error {
+	player := m.turningPlayer
+	if !m.isStarted.Load() || player == nil || m.isTurnPaused || m.isEnded {
+		return nil
+	}
+
+	player.timeouts++
+	limit := m.cfg.Game.TimeoutsToForfeit
+
+	if limit > 0 && player.timeouts >= limit {
+		return m.forfeit(player)
+	}
+
+	if limit > 0 {
+		_ = m.SendNotification(fmt.Sprintf("Player '%s' ran out of time (%d/%d).", player.Nickname(), player.timeouts, limit), events.GameNotificationType)
+	} else {
+		_ = m.SendNotification(fmt.Sprintf("Player '%s' ran out of time.", player.Nickname()), events.GameNotificationType)
+	}
+
+	if limit > 0 && player.timeouts == limit-1 {
+		_ = m.SendNotificationToPlayer(player.ID(), "Warning: you will forfeit if you run out of time once more!", events.GameNotificationType)
+	}
+
+	if m.cfg.Game.TimeoutPolicy == config.AutoFirePolicy {
+		if args, ok := m.buildRandomShot(player); ok {
+			return m.fire(args)
+		}
+	}
+
+	m.Dispatch(NewGameTurnCommand())
+	return nil
+}
+
+func (m *Match) fire(args events.FireCommandArgs) error {
+	firingPlayer := m.players[args.FiringPlayerID]
+	targetPlayer, found := m.players[args.TargetPlayerID]
+	if !found || targetPlayer.Equal(firingPlayer) || targetPlayer.IsEliminated() || targetPlayer.Model.IsAllyOf(firingPlayer.Model) {
+		return m.refuseShot(args.FiringPlayerID, ErrInvalidTarget)
+	}
+
+	// All shots of a salvo are checked in advance, so it's resolved either completely or not at all.
+	targets := args.Targets()
+	if err := m.rules.CheckShots(firingPlayer.Model, targetPlayer.Model, targets); err != nil {
+		return m.refuseShot(args.FiringPlayerID, err)
+	}
+
+	if fireEvent, err := events.NewPlayerFireEvent(args); err == nil {
+		m.record(fireEvent)
+	}
+
+	salvo, err := targetPlayer.Model.TakeSalvo(targets)
+	if err != nil {
+		return err
+	}
+	for _, target := range targets {
+		firingPlayer.RevealCell(targetPlayer.ID(), target.X, target.Y)
+	}
+	firingPlayer.shotsFired += len(targets)
+	firingPlayer.hits += salvo.Hits
+
+	_ = m.SendNotification(salvo.Describe(firingPlayer.Nickname()), events.GameNotificationType)
+
+	for _, sunkShip := range salvo.SunkShips {
+		if err := m.sinkShip(firingPlayer, targetPlayer, sunkShip); err != nil {
+			return err
+		}
+	}
+
+	if targetPlayer.IsEliminated() {
+		_ = m.SendNotification(fmt.Sprintf("Player '%s' was eliminated by '%s'!", targetPlayer.Nickname(), firingPlayer.Nickname()), events.GameNotificationType)
+	}
+
+	if err := m.allPlayersUpdate(); err != nil {
+		return err
+	}
+
+	// The winner is the last fleet, or the last team, standing.
+	switch {
+	case m.isOver():
+		m.Dispatch(NewGameEndCommand(m.logger, m.turningPlayer))
+	case m.rules.KeepsTurn(salvo):
+		m.Dispatch(NewGameContinueTurnCommand())
+	default:
+		m.Dispatch(NewGameTurnCommand())
+	}
+	return nil
+}
+
+// Dispatch queues the command for the game loop. The command is dropped, once the match is closed,
+// so timers and other goroutines may dispatch at any time.
+func (m *Match) Dispatch(cmd Command) {
+	if m.isClosed.Load() {
+		return
+	}
+
+	select {
+	case <-m.closeCh:
+	case m.cmds <- cmd:
+	}
+}
+
+// TryDispatch queues the command without waiting for the game loop. It's meant for callers from
+// outside, e.g. the admin API, which mustn't hang on a busy or closed match.
+func (m *Match) TryDispatch(cmd Command) error {
+	if m.isClosed.Load() {
+		return ErrRoomIsClosed
+	}
+
+	select {
+	case <-m.closeCh:
+		return ErrRoomIsClosed
+	case m.cmds <- cmd:
+		return nil
+	default:
+		return ErrMatchIsBusy
+	}
+}
+
+// GetPlayers returns players in the join order, so the same seed draws the same turn order.
+func (m *Match) GetPlayers() []*Player {
+	players := make([]*Player, 0, len(m.players))
+	for _, player := range m.players {
+		players = append(players, player)
+	}
+
+	slices.SortFunc(players, func(lhs, rhs *Player) int {
+		return cmp.Or(cmp.Compare(lhs.seat, rhs.seat), lhs.Compare(rhs))
+	})
+
+	return players
+}
+
+func (m *Match) takeSeat(player *Player) {
+	m.seats++
+	player.seat = m.seats
+}
+
+func (m *Match) getRandomPlayer() *Player {
+	if len(m.players) == 0 {
+		return nil
+	}
+
+	m.turningPlayerIdx = m.rng.Intn(len(m.players))
+	return m.GetPlayers()[m.turningPlayerIdx]
+}
+
+// getNextTarget returns the next player in the turn order. Eliminated players are skipped.
+func (m *Match) getNextTarget() *Player {
+	if m.rules.IsTeamMatch && m.turningPlayer != nil {
+		return m.getNextTeamPlayer()
+	}
+
+	players := m.GetPlayers()
+	if len(players) == 0 {
+		return nil
+	}
+
+	for range players {
+		m.turningPlayerIdx = (m.turningPlayerIdx + 1) % len(players)
+		if !players[m.turningPlayerIdx].IsEliminated() {
+			break
+		}
+	}
+	return players[m.turningPlayerIdx]
+}
+
+// getNextTeamPlayer passes the turn to the opposing team, whose members take turns in rotation.
+func (m *Match) getNextTeamPlayer() *Player {
+	var opponents []*Player
+	for _, player := range m.getAlivePlayers() {
+		if !player.Equal(m.turningPlayer) && !player.Model.IsAllyOf(m.turningPlayer.Model) {
+			opponents = append(opponents, player)
+		}
+	}
+
+	if len(opponents) == 0 {
+		return m.turningPlayer
+	}
+
+	if m.teamTurnIdx == nil {
+		m.teamTurnIdx = make(map[int]int)
+	}
+
+	team := opponents[0].Model.Team
+	idx, found := m.teamTurnIdx[team]
+	if found {
+		idx = (idx + 1) % len(opponents)
+	} else {
+		idx = m.rng.Intn(len(opponents))
+	}
+	m.teamTurnIdx[team] = idx
+
+	return opponents[idx]
+}
+
+// assignTeams splits players into two teams at random.
+func (m *Match) assignTeams() error {
+	players := m.GetPlayers()
+	m.rng.Shuffle(len(players), func(i, j int) {
+		players[i], players[j] = players[j], players[i]
+	})
+
+	teams := make(map[int][]string, 2)
+	for i, player := range players {
+		team := i%2 + 1
+		player.Model.Team = team
+		teams[team] = append(teams[team], player.Nickname())
+	}
+
+	if err := m.allPlayersUpdate(); err != nil {
+		return err
+	}
+
+	for team := 1; team <= len(teams); team++ {
+		_ = m.SendNotification(fmt.Sprintf("Team %d: %s.", team, strings.Join(teams[team], ", ")), events.RoomNotificationType)
+	}
+	return nil
+}
+
+// isOver reports whether at most one fleet, or one team in a team match, is left afloat.
+func (m *Match) isOver() bool {
+	sides := make(map[string]struct{})
+	for _, player := range m.getAlivePlayers() {
+		if player.Model.Team != domain.NoTeam {
+			sides[strconv.Itoa(player.Model.Team)] = struct{}{}
+		} else {
+			sides[player.ID()] = struct{}{}
+		}
+	}
+	return len(sides) <= 1
+}
+
+func (m *Match) getAlivePlayers() []*Player {
+	players := m.GetPlayers()
+	return slices.DeleteFunc(players, func(player *Player) bool {
+		return player.IsEliminated()
+	})
+}
+
+func (m *Match) gameLoop(ctx context.Context) {
+	defer func() {
+		m.gameTurnTimer.Stop()
+		m.closeTimer.Stop()
+		m.botTimer.Stop()
+		m.wg.Done()
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-m.closeCh:
+			m.logger.Infof("stoping game loop in match id=%s", m.ID())
+			return
+
+		case <-m.gameTurnTimer.C:
+			// Players who didn't manage to place their fleet in time play with a random one.
+			if m.isPlacing.Load() {
+				m.Dispatch(NewGameStartCommand(m.logger))
+			} else {
+				m.Dispatch(NewTurnTimeoutCommand())
+			}
+
+		case <-m.closeTimer.C:
+			m.Dispatch(NewCloseMatchCommand(m.logger))
+
+		case <-m.botTimer.C:
+			m.Dispatch(NewFillWithBotsCommand(m.logger, m.botDifficulty))
+
+		case cmd := <-m.cmds:
+			if err := cmd.Execute(m); err != nil {
+				m.logger.Errorf("failed to execute a command: %s", err)
+				m.Dispatch(NewCloseMatchCommand(m.logger))
+			}
+
+		case msg, opened := <-m.room.Events():
+			if !opened {
+				return
+			}
+
+			if err := m.eventBus.Invoke(msg); err != nil {
+				m.logger.Errorf("error while invoking event: %s", err)
+			}
+
+		// Spectators are read-only, whatever they send is dropped.
+		case msg, opened := <-m.audience.Events():
+			if !opened {
+				return
+			}
+			m.logger.Infof("event type=%s from a spectator in match id=%s is discarded", msg.Type, m.ID())
+		}
+	}
+}
+
+func (m *Match) broadcastPlayerTurn(turningPlayer *Player, isContinuation bool) error {
+	shots := m.rules.ShotsPerTurn(turningPlayer.Model)
+
+	event, err := events.NewPlayerTurnEvent(m.gameModel.TurnCount, turningPlayer.ID(), m.cfg.Game.GameTurnTime, isContinuation, shots)
+	if err != nil {
+		return err
+	}
+
+	return m.broadcast(event)
+}
+
+func (m *Match) resetGameTurnTimer() {
+	// The new turn waits for disconnected players as well.
+	if m.isTurnPaused {
+		m.pausedTurnTime = m.cfg.Game.GameTurnTime
+		return
+	}
+
+	m.turnDeadline = time.Now().Add(m.cfg.Game.GameTurnTime)
+	m.gameTurnTimer.Reset(m.cfg.Game.GameTurnTime)
+}
+
+func (m *Match) pauseGameTurnTimer() {
+	if m.isTurnPaused {
+		return
+	}
+
+	m.isTurnPaused = true
+	m.pausedTurnTime = max(time.Until(m.turnDeadline), 0)
+	m.gameTurnTimer.Stop()
+}
+
+func (m *Match) resumeGameTurnTimer() {
+	if !m.isTurnPaused {
+		return
+	}
+
+	m.isTurnPaused = false
+	m.turnDeadline = time.Now().Add(m.pausedTurnTime)
+	m.gameTurnTimer.Reset(m.pausedTurnTime)
+}
+
+func (m *Match) remainingTurnTime() time.Duration {
+	if m.isTurnPaused {
+		return m.pausedTurnTime
+	}
+	return max(time.Until(m.turnDeadline), 0)
+}
+
+// updateProfiles records the result of the match into profiles of players. Ratings change in
+// rated matches only. Bots and players without a profile aren't recorded.
+func (m *Match) updateProfiles(winningPlayer *Player) {
+	if m.profiles == nil {
+		return
+	}
+
+	var winnerRatings, loserRatings []float64
+	profiles := make(map[string]domain.Profile, len(m.startedPlayers))
+	for _, player := range m.startedPlayers {
+		if player.IsBot() || player.ProfileID() == "" {
+			continue
+		}
+
+		profile, err := m.profiles.Profile(player.ProfileID())
+		if errors.Is(err, ErrProfileNotExist) {
+			profile = player.NewProfile()
+		} else if err != nil {
+			m.logger.Errorf("failed to get the profile of player %s: %s", player, err)
+			continue
+		}
+
+		profiles[player.ID()] = profile
+		if isOnWinningSide(player, winningPlayer) {
+			winnerRatings = append(winnerRatings, profile.Rating)
+		} else {
+			loserRatings = append(loserRatings, profile.Rating)
+		}
+	}
+
+	for _, player := range m.startedPlayers {
+		profile, found := profiles[player.ID()]
+		if !found {
+			continue
+		}
+
+		profile.ShotsFired += player.shotsFired
+		profile.Hits += player.hits
+		if isOnWinningSide(player, winningPlayer) {
+			profile.Wins++
+		} else {
+			profile.Losses++
+		}
+
+		if m.isRated {
+			rating := profile.Rating
+			if isOnWinningSide(player, winningPlayer) {
+				profile.Rating = NewEloRating(rating, 1, loserRatings...)
+			} else {
+				profile.Rating = NewEloRating(rating, 0, winnerRatings...)
+			}
+
+			msg := fmt.Sprintf("Your rating: %.0f -> %.0f", rating, profile.Rating)
+			_ = m.SendNotificationToPlayer(player.ID(), msg, events.RoomNotificationType)
+		}
+
+		if err := m.profiles.SaveProfile(profile); err != nil {
+			m.logger.Errorf("failed to save the profile of player %s: %s", player, err)
+		}
+	}
+}
+
+// profileHandle publishes the profile of the player in the history instead of its ID.
+func (m *Match) profileHandle(player *Player) string {
+	if m.profiles == nil || player.ProfileID() == "" {
+		return ""
+	}
+
+	profile, err := m.profiles.Profile(player.ProfileID())
+	if err != nil {
+		return ""
+	}
+	return profile.Handle
+}
+
+// recordResult saves the result of the match into the history.
+func (m *Match) recordResult(winningPlayer *Player) {
+	if m.history == nil {
+		return
+	}
+
+	winner := winningPlayer.Nickname()
+	if team := winningPlayer.Model.Team; team != domain.NoTeam {
+		winner = fmt.Sprintf("Team %d", team)
+	}
+
+	endedAt := time.Now()
+	record := domain.MatchRecord{
+		ID:           m.ID(),
+		Rules:        m.rules,
+		IsRated:      m.isRated,
+		StartedAt:    m.startedAt,
+		EndedAt:      endedAt,
+		Duration:     endedAt.Sub(m.startedAt),
+		Turns:        m.gameModel.TurnCount,
+		Winner:       winner,
+		Participants: make([]domain.MatchParticipant, 0, len(m.startedPlayers)),
+	}
+
+	for _, player := range m.startedPlayers {
+		record.Participants = append(record.Participants, domain.MatchParticipant{
+			Handle:     m.profileHandle(player),
+			Nickname:   player.Nickname(),
+			Team:       player.Model.Team,
+			IsBot:      player.IsBot(),
+			IsWinner:   isOnWinningSide(player, winningPlayer),
+			ShotsFired: player.shotsFired,
+			Hits:       player.hits,
+			Accuracy:   domain.Accuracy(player.shotsFired, player.hits),
 		})
+	}
+
+	if err := m.history.SaveMatch(record); err != nil {
+		m.logger.Errorf("failed to save the result of match id=%s: %s", m.ID(), err)
+	}
+}
+
+func isOnWinningSide(player, winningPlayer *Player) bool {
+	return player.Equal(winningPlayer) || player.Model.IsAllyOf(winningPlayer.Model)
+}
 
-		if !playerModel.Equal(targetPlayer.Model) {
-			playerModel.Board = player.maskBoardForPlayer(targetPlayer)
+func (m *Match) hasHumanPlayers() bool {
+	for _, player := range m.players {
+		if !player.IsBot() {
+			return true
 		}
+	}
+	return false
+}
 
-		maskedGameModel.Players[playerID] = playerModel
+func (m *Match) hasDisconnectedPlayers() bool {
+	for _, player := range m.players {
+		if player.isDisconnected {
+			return true
+		}
 	}
+	return false
+}
 
-	return maskedGameModel
+func (m *Match) forgetResumeToken(player *Player) {
+	m.mu.Lock()
+	delete(m.resumeTokens, player.resumeToken)
+	m.mu.Unlock()
 }
 
-func (m *Match) fireAtCell(targetPlayer *domain.PlayerModel, cellX, cellY byte) error {
-	var newType domain.CellType
-	switch {
-	// First case: we missed.
-	case targetPlayer.Board.IsCellEmpty(cellX, cellY):
-		newType = domain.Miss
+func (m *Match) sendResumedSession(player *Player) error {
+	gameState := m.buildGameStateForPlayer(player)
+
+	var turn *events.PlayerTurnEvent
+	if m.turningPlayer != nil {
+		turn = &events.PlayerTurnEvent{
+			TurnCount:       m.gameModel.TurnCount,
+			TurningPlayerID: m.turningPlayer.ID(),
+			RemainingTime:   m.remainingTurnTime(),
+			Shots:           m.rules.ShotsPerTurn(m.turningPlayer.Model),
+		}
+	}
+
+	event, err := events.NewSessionResumedEvent(gameState, m.getChatHistory(), turn)
+	if err != nil {
+		return err
+	}
+
+	return m.room.SendMessageToClient(player.ID(), event)
+}
 
-	// Second case: we hit a ship cell.
-	case targetPlayer.Board.GetCellType(cellX, cellY) == domain.Ship:
-		targetPlayer.Hit()
-		newType = domain.Dead
+func (m *Match) announcePlayerLeft(player *Player) {
+	event, err := events.NewPlayerLeftEvent(player.Model)
+	if err != nil {
+		m.logger.Error(err)
+		return
+	}
+
+	if err = m.broadcast(event); err != nil {
+		m.logger.Error(err)
+		return
+	}
+
+	m.logger.Infof("player %s left the match id=%s [players: %d]", player, m.ID(), m.room.Capacity())
+	if err := m.SendNotification(fmt.Sprintf("Player '%s' left the game.", player.Nickname()), events.RoomNotificationType); err != nil {
+		m.logger.Error(err)
+	}
+}
+
+// broadcast sends the event to all players and spectators.
+func (m *Match) broadcast(e events.Event) error {
+	m.spectate(e)
+	return m.room.Broadcast(e)
+}
+
+func (m *Match) spectate(e events.Event) {
+	m.spectateTo("", e)
+}
+
+// spectateTo sends the event to the spectator, or to all of them if the ID is empty. Events are
+// queued, if spectators watch the match with a delay.
+func (m *Match) spectateTo(spectatorID string, e events.Event) {
+	if m.audience == nil {
+		return
+	}
 
-	// Otherwise, we return an error.
+	event := spectatorEvent{event: e, spectatorID: spectatorID}
+	if m.cfg.Game.SpectatorDelay <= 0 {
+		m.sendToSpectators(event)
+		return
+	}
+
+	event.sendAt = time.Now().Add(m.cfg.Game.SpectatorDelay)
+
+	m.mu.Lock()
+	m.spectatorQueue = append(m.spectatorQueue, event)
+	m.mu.Unlock()
+
+	select {
+	case m.spectatorNotifyCh <- struct{}{}:
 	default:
-		return ErrInvalidTarget
+	}
+}
+
+func (m *Match) sendToSpectators(event spectatorEvent) {
+	var err error
+	if event.spectatorID == "" {
+		err = m.audience.Broadcast(event.event)
+	} else {
+		err = m.audience.SendMessageToClient(event.spectatorID, event.event)
+	}
+
+	if err != nil {
+		m.logger.Errorf("failed to send an event to spectators in match id=%s: %s", m.ID(), err)
+	}
+}
+
+// streamToSpectators sends queued events to spectators after the delay, so they can't tell
+// players anything about the current state of the match.
+func (m *Match) streamToSpectators(ctx context.Context) {
+	defer m.wg.Done()
+
+	for {
+		m.mu.RLock()
+		isEmpty := len(m.spectatorQueue) == 0
+		var next spectatorEvent
+		if !isEmpty {
+			next = m.spectatorQueue[0]
+		}
+		m.mu.RUnlock()
+
+		if isEmpty {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.closeCh:
+				return
+			case <-m.spectatorNotifyCh:
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.closeCh:
+			return
+		case <-time.After(time.Until(next.sendAt)):
+		}
+
+		m.mu.Lock()
+		m.spectatorQueue = m.spectatorQueue[1:]
+		m.mu.Unlock()
+
+		m.sendToSpectators(next)
+	}
+}
+
+func (m *Match) appendChatHistory(e events.Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.chatHistory = append(m.chatHistory, e)
+	if len(m.chatHistory) > chatHistoryMax {
+		m.chatHistory = m.chatHistory[len(m.chatHistory)-chatHistoryMax:]
+	}
+}
+
+func (m *Match) getChatHistory() []events.Event {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Clone(m.chatHistory)
+}
+
+func (m *Match) allPlayersUpdate() error {
+	for _, player := range m.players {
+		if err := m.playerUpdate(player); err != nil {
+			return err
+		}
+	}
+
+	if err := m.recordGameModel(); err != nil {
+		return err
+	}
+
+	return m.spectatorsUpdate()
+}
+
+// record writes the event into the journal without sending it to anyone.
+func (m *Match) record(e events.Event) {
+	if m.journal == nil {
+		return
+	}
+
+	if err := m.journal.Record(events.JournalOmniscient, e); err != nil {
+		m.logger.Errorf("failed to record an event to the journal of match id=%s: %s", m.ID(), err)
+	}
+}
+
+// recordGameModel writes the unmasked state of the match into the journal.
+func (m *Match) recordGameModel() error {
+	if m.journal == nil {
+		return nil
+	}
+
+	gameState := m.buildRevealedGameState()
+
+	event, err := events.NewPlayerUpdateStateEvent(gameState)
+	if err != nil {
+		return err
+	}
+
+	m.record(event)
+	return nil
+}
+
+func (m *Match) spectatorsUpdate() error {
+	gameState := m.buildGameStateForPlayer(nil)
+
+	event, err := events.NewPlayerUpdateStateEvent(gameState)
+	if err != nil {
+		return err
+	}
+
+	m.spectate(event)
+	return nil
+}
+
+func (m *Match) playerUpdate(player *Player) error {
+	gameState := m.buildGameStateForPlayer(player)
+
+	event, err := events.NewPlayerUpdateStateEvent(gameState)
+	if err != nil {
+		return err
 	}
 
-	targetPlayer.Board.SetCell(cellX, cellY, newType)
+	return m.room.SendMessageToClient(player.ID(), event)
+}
+
+func (m *Match) allPlayersReady() bool {
+	for _, player := range m.players {
+		if !player.IsReady() {
+			return false
+		}
+	}
+	return true
+}
+
+// buildGameStateForPlayer masks boards of opponents. A nil target player stands for a spectator,
+// who sees only the cells revealed by shots until the match is over.
+func (m *Match) buildGameStateForPlayer(targetPlayer *Player) *events.GameState {
+	if targetPlayer == nil && m.isEnded {
+		return m.buildRevealedGameState()
+	}
+
+	gameState := events.NewGameState(m.gameModel.TurnCount, len(m.players))
+
+	for playerID, player := range m.players {
+		var playerState *events.PlayerState
+		if targetPlayer == nil {
+			playerState = events.NewMaskedPlayerState(player.Model, player.maskBoardForPlayer(nil, slices.DeleteFunc(m.GetPlayers(), player.Equal)...))
+		} else {
+			// Teammates see each other's boards and share everything they have revealed.
+			playerState = events.NewPlayerStateFor(targetPlayer.Model, player.Model, player.maskBoardForPlayer(targetPlayer, m.getAllies(targetPlayer)...))
+		}
+
+		playerState.IsEliminated = player.IsEliminated()
+		gameState.Players[playerID] = playerState
+	}
+
+	return gameState
+}
+
+// buildRevealedGameState reveals boards of all players for the journal and the ended match.
+func (m *Match) buildRevealedGameState() *events.GameState {
+	models := make([]*domain.PlayerModel, 0, len(m.players))
+	for _, player := range m.players {
+		models = append(models, player.Model)
+	}
+
+	gameState := events.NewRevealedGameState(m.gameModel.TurnCount, models...)
+	for playerID, player := range m.players {
+		gameState.Players[playerID].IsEliminated = player.IsEliminated()
+	}
+	return gameState
+}
+
+func (m *Match) forfeit(player *Player) error {
+	player.Forfeit()
+	_ = m.SendNotification(fmt.Sprintf("Player '%s' forfeits after %d timeouts in a row.", player.Nickname(), player.timeouts), events.GameNotificationType)
+
+	if err := m.allPlayersUpdate(); err != nil {
+		return err
+	}
+
+	alivePlayers := m.getAlivePlayers()
+	switch {
+	case len(alivePlayers) == 0:
+		m.Dispatch(NewCloseMatchCommand(m.logger))
+	case m.isOver():
+		m.Dispatch(NewGameEndCommand(m.logger, alivePlayers[0]))
+	default:
+		m.Dispatch(NewGameTurnCommand())
+	}
 	return nil
 }
+
+// buildRandomShot fires on behalf of the player at random cells of a random opponent. Only cells
+// unknown to the player and its allies are chosen.
+func (m *Match) buildRandomShot(player *Player) (events.FireCommandArgs, bool) {
+	var opponents []*Player
+	for _, opponent := range m.getAlivePlayers() {
+		if !opponent.Equal(player) && !opponent.Model.IsAllyOf(player.Model) {
+			opponents = append(opponents, opponent)
+		}
+	}
+
+	if len(opponents) == 0 {
+		return events.FireCommandArgs{}, false
+	}
+
+	targetPlayer := opponents[m.rng.Intn(len(opponents))]
+	maskedBoard := targetPlayer.maskBoardForPlayer(player, m.getAllies(player)...)
+
+	var cells []domain.Coordinate
+	for y := 0; y < maskedBoard.Size(); y++ {
+		for x := 0; x < maskedBoard.Size(); x++ {
+			if maskedBoard.IsCellEmpty(byte(x), byte(y)) {
+				cells = append(cells, domain.Coordinate{X: byte(x), Y: byte(y)})
+			}
+		}
+	}
+
+	if len(cells) == 0 {
+		return events.FireCommandArgs{}, false
+	}
+
+	m.rng.Shuffle(len(cells), func(i, j int) {
+		cells[i], cells[j] = cells[j], cells[i]
+	})
+
+	return events.FireCommandArgs{
+		FiringPlayerID: player.ID(),
+		TargetPlayerID: targetPlayer.ID(),
+		Shots:          cells[:min(len(cells), m.rules.ShotsPerTurn(player.Model))],
+	}, true
+}
+
+func (m *Match) getAllies(player *Player) []*Player {
+	var allies []*Player
+	for _, ally := range m.players {
+		if !ally.Equal(player) && ally.Model.IsAllyOf(player.Model) {
+			allies = append(allies, ally)
+		}
+	}
+	return allies
+}
+
+func (m *Match) sinkShip(firingPlayer, targetPlayer *Player, sunkShip *domain.ShipModel) error {
+	// There can't be any ship next to the sunk one, so the shooter gets all surroundings for free.
+	for _, cell := range sunkShip.Surroundings(&targetPlayer.Model.Board) {
+		firingPlayer.RevealCell(targetPlayer.ID(), cell.X, cell.Y)
+	}
+
+	event, err := events.NewShipSunkEvent(firingPlayer.ID(), targetPlayer.ID(), sunkShip)
+	if err != nil {
+		return err
+	}
+
+	if err := m.broadcast(event); err != nil {
+		return err
+	}
+
+	return m.SendNotification(fmt.Sprintf("Player '%s' sunk a %d-deck ship of player '%s'!", firingPlayer.Nickname(), sunkShip.Length, targetPlayer.Nickname()), events.GameNotificationType)
+}
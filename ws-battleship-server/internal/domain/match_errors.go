@@ -1,8 +1,20 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"ws-battleship-shared/domain"
+)
 
 var (
-	ErrInvalidTarget = errors.New("invalid target")
-	ErrNotYourTurn   = errors.New("this player doesn't have permission to fire")
+	ErrInvalidTarget      = domain.ErrInvalidTarget
+	ErrInvalidSalvo       = domain.ErrInvalidSalvo
+	ErrNotYourTurn        = errors.New("this player doesn't have permission to fire")
+	ErrNotStarted         = errors.New("match is not started yet")
+	ErrNotPlacing         = errors.New("fleet placement is over")
+	ErrAlreadyReady       = errors.New("player has already placed the fleet")
+	ErrInvalidResumeToken = errors.New("resume token is invalid or expired")
+	ErrMatchIsOver        = errors.New("match is over")
+	ErrMatchIsBusy        = errors.New("match is busy, try again later")
+	ErrSpoofedIdentity    = errors.New("event claims the identity of another player")
+	ErrUnknownSender      = errors.New("event came from a client, which isn't a player of the match")
 )
@@ -12,17 +12,50 @@ func (m *Match) onPlayerFiredHandler(e events.Event) error {
 		return err
 	}
 
+	if err := checkSender(e, playerFiredEvent.FiringPlayerID); err != nil {
+		return err
+	}
+
 	args := events.FireCommandArgs{
 		FiringPlayerID: playerFiredEvent.FiringPlayerID,
 		TargetPlayerID: playerFiredEvent.TargetPlayerID,
 		CellX:          playerFiredEvent.CellX,
 		CellY:          playerFiredEvent.CellY,
+		Shots:          playerFiredEvent.Shots,
 	}
 
 	m.Dispatch(NewFireCommand(args))
 	return nil
 }
 
+func (m *Match) onPlayerPlacedFleetHandler(e events.Event) error {
+	placeFleetEvent, err := events.CastTo[events.PlaceFleetEvent](e)
+	if err != nil {
+		return err
+	}
+
+	if err := checkSender(e, placeFleetEvent.PlayerID); err != nil {
+		return err
+	}
+
+	m.Dispatch(NewPlaceFleetCommand(placeFleetEvent.PlaceFleetCommandArgs))
+	return nil
+}
+
+func (m *Match) onPlayerRerolledFleetHandler(e events.Event) error {
+	rerollFleetEvent, err := events.CastTo[events.RerollFleetEvent](e)
+	if err != nil {
+		return err
+	}
+
+	if err := checkSender(e, rerollFleetEvent.PlayerID); err != nil {
+		return err
+	}
+
+	m.Dispatch(NewRerollFleetCommand(rerollFleetEvent.PlayerID))
+	return nil
+}
+
 func (m *Match) onPlayerJoinedHandler(joinedClient websocket.Client) {
 	player := m.players[joinedClient.ID()]
 
@@ -36,43 +69,75 @@ func (m *Match) onPlayerJoinedHandler(joinedClient websocket.Client) {
 		return
 	}
 
-	if err = m.room.Broadcast(event); err != nil {
+	if err = m.broadcast(event); err != nil {
 		m.logger.Error(err)
 		return
 	}
 
+	sessionEvent, err := events.NewSessionEvent(player.resumeToken)
+	if err != nil {
+		m.logger.Error(err)
+		return
+	}
+
+	if err = m.room.SendMessageToClient(player.ID(), sessionEvent); err != nil {
+		m.logger.Error(err)
+		return
+	}
+
+	if err := m.SendNotificationToPlayer(player.ID(), fmt.Sprintf("Match ID: %s. Share it to let others spectate.", m.ID()), events.RoomNotificationType); err != nil {
+		m.logger.Error(err)
+	}
+
+	if m.IsPrivate() {
+		if err := m.SendNotificationToPlayer(player.ID(), fmt.Sprintf("Room code: %s. Share it to let your friends join.", m.Code()), events.RoomNotificationType); err != nil {
+			m.logger.Error(err)
+		}
+	}
+
 	m.room.logger.Infof("player %s joined the match id=%s [players: %d]", player, m.ID(), m.room.Capacity())
 	if err := m.SendNotification(fmt.Sprintf("Player '%s' joined the game.", player.Nickname()), events.RoomNotificationType); err != nil {
 		m.logger.Error(err)
 	}
 
 	if m.IsReadyToStart() {
-		m.Dispatch(NewGameStartCommand(m.logger))
+		m.Dispatch(NewPlacementStartCommand(m.logger))
 	}
 }
 
+// onPlayerLeftHandler is called by the room, so players are read on the game loop only.
 func (m *Match) onPlayerLeftHandler(leftClient websocket.Client) {
-	defer delete(m.players, leftClient.ID())
+	if player, ok := leftClient.(*Player); ok {
+		m.Dispatch(NewDisconnectPlayerCommand(player))
+	}
+}
 
-	player := m.players[leftClient.ID()]
+// onPlayerSentMessageHandler signs the message with the nickname of the sender. Players can't
+// send notifications, which come from the server only.
+func (m *Match) onPlayerSentMessageHandler(e events.Event) error {
+	player, found := m.players[e.SenderID]
+	if !found {
+		return fmt.Errorf("failed to send a chat message from client id=%s: %w", e.SenderID, ErrUnknownSender)
+	}
 
-	event, err := events.NewPlayerLeftEvent(player.Model)
+	sendMessageEvent, err := events.CastTo[events.SendMessageEvent](e)
 	if err != nil {
-		m.logger.Error(err)
-		return
+		return err
 	}
 
-	if err = m.room.Broadcast(event); err != nil {
-		m.logger.Error(err)
-		return
+	event, err := events.NewSendMessageEvent(player.Nickname(), sendMessageEvent.Message)
+	if err != nil {
+		return err
 	}
 
-	m.room.logger.Infof("player %s left the match id=%s [players: %d]", player, m.ID(), m.room.Capacity())
-	if err := m.SendNotification(fmt.Sprintf("Player '%s' left the game.", player.Nickname()), events.RoomNotificationType); err != nil {
-		m.logger.Error(err)
-	}
+	m.appendChatHistory(event)
+	return m.broadcast(event)
 }
 
-func (m *Match) onPlayerSentMessageHandler(e events.Event) error {
-	return m.room.Broadcast(e)
+// checkSender rejects the event, which claims to come from another player than its connection.
+func checkSender(e events.Event, claimedID string) error {
+	if e.SenderID != claimedID {
+		return fmt.Errorf("client id=%s claims to be player id=%s: %w", e.SenderID, claimedID, ErrSpoofedIdentity)
+	}
+	return nil
 }
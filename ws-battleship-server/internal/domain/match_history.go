@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"ws-battleship-shared/domain"
+)
+
+// MatchHistory keeps results of finished matches.
+type MatchHistory interface {
+	SaveMatch(record domain.MatchRecord) error
+	Match(id string) (domain.MatchRecord, bool)
+	// PlayerMatches returns matches of the player, the latest go first.
+	PlayerMatches(handle string) []domain.MatchRecord
+	Close() error
+}
+
+// FileMatchHistory holds all results in memory and appends new ones to the file in JSON Lines.
+type FileMatchHistory struct {
+	mu sync.RWMutex
+	// file is nil, if results aren't saved between restarts.
+	file    *os.File
+	encoder *json.Encoder
+	records []domain.MatchRecord
+}
+
+func NewFileMatchHistory(path string) (*FileMatchHistory, error) {
+	history := &FileMatchHistory{}
+	if path == "" {
+		return history, nil
+	}
+
+	records, err := readMatchRecords(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create match history directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open match history: %w", err)
+	}
+
+	history.file = file
+	history.encoder = json.NewEncoder(file)
+	history.records = records
+	return history, nil
+}
+
+func readMatchRecords(path string) ([]domain.MatchRecord, error) {
+	file, err := os.Open(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read match history: %w", err)
+	}
+	defer file.Close()
+
+	var records []domain.MatchRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var record domain.MatchRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("failed to parse match history: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+func (h *FileMatchHistory) SaveMatch(record domain.MatchRecord) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.records = append(h.records, record)
+	if h.encoder == nil {
+		return nil
+	}
+	return h.encoder.Encode(record)
+}
+
+func (h *FileMatchHistory) Match(id string) (domain.MatchRecord, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	idx := slices.IndexFunc(h.records, func(record domain.MatchRecord) bool {
+		return record.ID == id
+	})
+	if idx < 0 {
+		return domain.MatchRecord{}, false
+	}
+	return h.records[idx], true
+}
+
+func (h *FileMatchHistory) PlayerMatches(handle string) []domain.MatchRecord {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var records []domain.MatchRecord
+	for i := len(h.records) - 1; i >= 0; i-- {
+		if h.records[i].HasPlayer(handle) {
+			records = append(records, h.records[i])
+		}
+	}
+	return records
+}
+
+func (h *FileMatchHistory) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.file == nil {
+		return nil
+	}
+
+	err := h.file.Close()
+	h.file, h.encoder = nil, nil
+	return err
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"path/filepath"
+	"testing"
+	"ws-battleship-shared/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMatchHistory(t *testing.T) {
+	// 1. Arrange
+	path := filepath.Join(t.TempDir(), "matches.jsonl")
+	history, err := NewFileMatchHistory(path)
+	require.NoError(t, err)
+
+	// 2. Act
+	require.NoError(t, history.SaveMatch(domain.MatchRecord{ID: "a", Participants: []domain.MatchParticipant{{Handle: "1"}}}))
+	require.NoError(t, history.SaveMatch(domain.MatchRecord{ID: "b", Participants: []domain.MatchParticipant{{Handle: "2"}}}))
+	require.NoError(t, history.Close())
+	restarted, err := NewFileMatchHistory(path)
+
+	// 3. Assert
+	require.NoError(t, err)
+	record, found := restarted.Match("b")
+	require.True(t, found)
+	require.Equal(t, "2", record.Participants[0].Handle)
+	require.Len(t, restarted.PlayerMatches("1"), 1)
+	require.Empty(t, restarted.PlayerMatches(""))
+}
+
+func TestRecordResult(t *testing.T) {
+	// 1. Arrange
+	players := []*Player{newTestPlayer(t, "1"), newTestPlayer(t, "2")}
+	players[0].profileID = "profile 1"
+	players[0].shotsFired, players[0].hits = 10, 4
+
+	profiles, err := NewFileProfileStore("")
+	require.NoError(t, err)
+	require.NoError(t, profiles.SaveProfile(domain.Profile{ID: "profile 1", Handle: "handle 1"}))
+
+	history, err := NewFileMatchHistory("")
+	require.NoError(t, err)
+
+	match := newPlacingMatch(players...)
+	match.SetMatchHistory(history)
+	match.SetProfileStore(profiles)
+	match.startedPlayers = players
+	match.gameModel.TurnCount = 12
+
+	// 2. Act
+	match.recordResult(players[0])
+
+	// 3. Assert
+	record, found := history.Match(match.ID())
+	require.True(t, found)
+	require.Equal(t, "player 1", record.Winner)
+	require.Equal(t, 12, record.Turns)
+	require.Len(t, record.Participants, 2)
+	require.True(t, record.Participants[0].IsWinner)
+	require.InDelta(t, 0.4, record.Participants[0].Accuracy, 0.01)
+	require.False(t, record.Participants[1].IsWinner)
+	require.Equal(t, "handle 1", record.Participants[0].Handle)
+	require.Empty(t, record.Participants[1].Handle)
+	require.Len(t, history.PlayerMatches("handle 1"), 1)
+	require.Empty(t, history.PlayerMatches("profile 1"))
+}
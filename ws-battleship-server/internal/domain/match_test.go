@@ -1,12 +1,19 @@
 package domain
 
 import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 	"ws-battleship-server/internal/config"
+	"ws-battleship-server/internal/delivery/websocket"
 	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
 	"ws-battleship-shared/pkg/logger"
 
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -132,6 +139,19 @@ func TestIsMatchReadyToStart(t *testing.T) {
 	})
 }
 
+var errJournalClosed = errors.New("journal is closed")
+
+// failingJournal fails to close.
+type failingJournal struct{}
+
+func (failingJournal) Record(string, events.Event) error {
+	return nil
+}
+
+func (failingJournal) Close() error {
+	return errJournalClosed
+}
+
 func TestCloseMatch(t *testing.T) {
 	t.Run("idempotent close", func(t *testing.T) {
 		// 1. Arrange
@@ -150,6 +170,123 @@ func TestCloseMatch(t *testing.T) {
 		require.NoError(t, match.Close())
 		require.NoError(t, match.Close())
 	})
+
+	t.Run("close command closes the match and reports the failure", func(t *testing.T) {
+		// 1. Arrange
+		loggerMock := new(logger.MockLogger)
+		loggerMock.On("Infof", mock.Anything, mock.Anything).Maybe()
+		loggedCh := make(chan struct{})
+		loggerMock.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+			close(loggedCh)
+		}).Once()
+
+		match := NewMatch(t.Context(), &config.Config{
+			App: config.AppConfig{
+				KeepAlivePeriod: time.Second * 5,
+				RoomCapacityMax: 5,
+			},
+		}, loggerMock)
+		match.journal = failingJournal{}
+
+		// 2. Act
+		match.Dispatch(NewCloseMatchCommand(loggerMock))
+
+		// 3. Assert
+		select {
+		case <-match.Done():
+		case <-time.After(time.Second):
+			t.Fatal("match isn't closed")
+		}
+		require.ErrorIs(t, match.CloseErr(), errJournalClosed)
+
+		select {
+		case <-loggedCh:
+		case <-time.After(time.Second):
+			t.Fatal("close error isn't logged")
+		}
+	})
+
+	t.Run("commands dispatched during and after close are dropped", func(t *testing.T) {
+		// 1. Arrange
+		loggerMock := new(logger.MockLogger)
+		loggerMock.On("Infof", mock.Anything, mock.Anything).Maybe()
+
+		match := NewMatch(t.Context(), &config.Config{
+			App: config.AppConfig{
+				KeepAlivePeriod: time.Second * 5,
+				RoomCapacityMax: 5,
+			},
+		}, loggerMock)
+
+		var wg sync.WaitGroup
+		for range 5 {
+			wg.Go(func() {
+				for range cap(match.cmds) * 2 {
+					match.Dispatch(NewAnnounceCommand("hello"))
+				}
+			})
+		}
+
+		// 2. Act
+		require.NoError(t, match.Close())
+		wg.Wait()
+
+		// 3. Assert
+		match.Dispatch(NewAnnounceCommand("hello"))
+		require.True(t, match.IsClosed())
+	})
+
+	t.Run("close stops resume timers", func(t *testing.T) {
+		// 1. Arrange
+		loggerMock := new(logger.MockLogger)
+		loggerMock.On("Infof", mock.Anything, mock.Anything).Maybe()
+
+		match := NewMatch(t.Context(), &config.Config{
+			App: config.AppConfig{
+				KeepAlivePeriod: time.Second * 5,
+				RoomCapacityMax: 5,
+			},
+		}, loggerMock)
+
+		player := newTestPlayer(t, "1")
+		player.resumeTimer = time.AfterFunc(time.Hour, func() {})
+		match.players[player.ID()] = player
+
+		// 2. Act
+		require.NoError(t, match.Close())
+
+		// 3. Assert
+		require.Falsef(t, player.resumeTimer.Stop(), "resume timer must be already stopped")
+	})
+}
+
+func TestTryDispatch(t *testing.T) {
+	t.Run("busy match refuses commands without waiting", func(t *testing.T) {
+		// 1. Arrange
+		match := newPlacingMatch()
+		for range cap(match.cmds) {
+			require.NoError(t, match.TryDispatch(NewAnnounceCommand("hello")))
+		}
+
+		// 2. Act
+		err := match.TryDispatch(NewAnnounceCommand("hello"))
+
+		// 3. Assert
+		require.ErrorIs(t, err, ErrMatchIsBusy)
+	})
+
+	t.Run("closed match refuses commands", func(t *testing.T) {
+		// 1. Arrange
+		match := newPlacingMatch()
+		match.isClosed.Store(true)
+
+		// 2. Act
+		err := match.TryDispatch(NewAnnounceCommand("hello"))
+
+		// 3. Assert
+		require.ErrorIs(t, err, ErrRoomIsClosed)
+		require.Empty(t, match.cmds)
+	})
 }
 
 func TestFireAtCell(t *testing.T) {
@@ -170,10 +307,8 @@ func TestFireAtCell(t *testing.T) {
 			expectedType: domain.Miss,
 		},
 		{
-			name: "fire at non-initialized cell, also expecting miss",
-			board: domain.Board{
-				{},
-			},
+			name:         "fire at non-initialized cell, also expecting miss",
+			board:        domain.NewBoard(domain.DefaultBoardSize),
 			cellX:        0,
 			cellY:        0,
 			expectedType: domain.Miss,
@@ -181,20 +316,28 @@ func TestFireAtCell(t *testing.T) {
 		{
 			name: "fire at ship cell, expecting dead",
 			board: domain.Board{
-				{domain.Ship},
+				{domain.Ship, domain.Ship},
 			},
 			cellX:        0,
 			cellY:        0,
 			expectedType: domain.Dead,
 		},
+		{
+			name: "fire at the last alive cell of a ship, expecting sunk",
+			board: domain.Board{
+				{domain.Dead, domain.Ship},
+			},
+			cellX:        1,
+			cellY:        0,
+			expectedType: domain.Sunk,
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			// 1. Arrange
 			targetPlayer := domain.NewPlayerModel(tt.board, domain.ClientMetadata{})
 
 			// 2. Act
-			var match Match
-			err := match.fireAtCell(targetPlayer, tt.cellX, tt.cellY)
+			_, err := targetPlayer.FireAt(tt.cellX, tt.cellY)
 
 			// 3. Assert
 			require.NoError(t, err)
@@ -210,8 +353,7 @@ func TestFireAtInvalidTarget(t *testing.T) {
 		targetPlayer := domain.NewPlayerModel(board, domain.ClientMetadata{})
 
 		// 2. Act
-		var match Match
-		err := match.fireAtCell(targetPlayer, 0, 0)
+		_, err := targetPlayer.FireAt(0, 0)
 
 		// 3. Assert
 		require.ErrorIsf(t, err, ErrInvalidTarget, "expected error invalid target")
@@ -227,9 +369,10 @@ func TestPlayerIsDeadAfterAllShipCellsWereHit(t *testing.T) {
 		targetPlayer := domain.NewPlayerModel(board, domain.ClientMetadata{})
 
 		// 2. Act
-		var match Match
-		require.NoError(t, match.fireAtCell(targetPlayer, 1, 0))
-		require.NoError(t, match.fireAtCell(targetPlayer, 2, 0))
+		_, err := targetPlayer.FireAt(1, 0)
+		require.NoError(t, err)
+		_, err = targetPlayer.FireAt(2, 0)
+		require.NoError(t, err)
 
 		// 3. Assert
 		require.Truef(t, targetPlayer.IsDead(), "target player must be dead")
@@ -237,20 +380,20 @@ func TestPlayerIsDeadAfterAllShipCellsWereHit(t *testing.T) {
 
 	t.Run("hit all ship cells 2", func(t *testing.T) {
 		// 1. Arrange
-		var board domain.Board
+		board := domain.NewBoard(domain.DefaultBoardSize)
 		for i := 0; i < board.Size(); i++ {
 			for j := 0; j < board.Size(); j++ {
 				board.SetCell(byte(j), byte(i), domain.Ship)
 			}
 		}
 
-		var match Match
 		targetPlayer := domain.NewPlayerModel(board, domain.ClientMetadata{})
 
 		// 2. Act
 		for i := 0; i < board.Size(); i++ {
 			for j := 0; j < board.Size(); j++ {
-				require.NoError(t, match.fireAtCell(targetPlayer, byte(j), byte(i)))
+				_, err := targetPlayer.FireAt(byte(j), byte(i))
+				require.NoError(t, err)
 			}
 		}
 
@@ -266,10 +409,1148 @@ func TestPlayerIsDeadAfterAllShipCellsWereHit(t *testing.T) {
 		targetPlayer := domain.NewPlayerModel(board, domain.ClientMetadata{})
 
 		// 2. Act
-		var match Match
-		require.NoError(t, match.fireAtCell(targetPlayer, 1, 0))
+		_, err := targetPlayer.FireAt(1, 0)
+		require.NoError(t, err)
 
 		// 3. Assert
 		require.Falsef(t, targetPlayer.IsDead(), "target player must not be dead")
 	})
 }
+
+func TestPlaceFleet(t *testing.T) {
+	validFleet := []domain.ShipPlacement{
+		{X: 0, Y: 0, Length: 4, Horizontal: true},
+		{X: 0, Y: 2, Length: 3, Horizontal: true},
+		{X: 4, Y: 2, Length: 3, Horizontal: true},
+		{X: 0, Y: 4, Length: 2, Horizontal: true},
+		{X: 3, Y: 4, Length: 2, Horizontal: true},
+		{X: 6, Y: 4, Length: 2, Horizontal: true},
+		{X: 0, Y: 6, Length: 1},
+		{X: 2, Y: 6, Length: 1},
+		{X: 4, Y: 6, Length: 1},
+		{X: 6, Y: 6, Length: 1},
+	}
+
+	t.Run("player becomes ready after placing a valid fleet", func(t *testing.T) {
+		// 1. Arrange
+		player1 := newTestPlayer(t, "1")
+		player2 := newTestPlayer(t, "2")
+		match := newPlacingMatch(player1, player2)
+
+		// 2. Act
+		err := match.PlaceFleet(events.PlaceFleetCommandArgs{PlayerID: "1", Ships: validFleet})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Truef(t, player1.IsReady(), "player must be ready")
+		require.Falsef(t, player2.IsReady(), "player must not be ready")
+		require.Equal(t, domain.Ship, player1.Model.Board.GetCellType(3, 0))
+		require.Emptyf(t, match.cmds, "match shouldn't start until all players are ready")
+	})
+
+	t.Run("match starts when all players are ready", func(t *testing.T) {
+		// 1. Arrange
+		player1 := newTestPlayer(t, "1")
+		player2 := newTestPlayer(t, "2")
+		match := newPlacingMatch(player1, player2)
+
+		// 2. Act
+		require.NoError(t, match.PlaceFleet(events.PlaceFleetCommandArgs{PlayerID: "1", Ships: validFleet}))
+		require.NoError(t, match.PlaceFleet(events.PlaceFleetCommandArgs{PlayerID: "2", Ships: validFleet}))
+
+		// 3. Assert
+		require.Len(t, match.cmds, 1)
+		require.IsType(t, &GameStartCommand{}, <-match.cmds)
+	})
+
+	t.Run("invalid fleet is rejected", func(t *testing.T) {
+		// 1. Arrange
+		player := newTestPlayer(t, "1")
+		board := player.Model.Board
+		match := newPlacingMatch(player)
+
+		// 2. Act
+		err := match.PlaceFleet(events.PlaceFleetCommandArgs{PlayerID: "1", Ships: validFleet[1:]})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Falsef(t, player.IsReady(), "player must not be ready")
+		require.Equalf(t, board, player.Model.Board, "board must not be changed")
+		require.Empty(t, match.cmds)
+	})
+
+	t.Run("fleet is rejected when placement is over", func(t *testing.T) {
+		// 1. Arrange
+		player := newTestPlayer(t, "1")
+		match := newPlacingMatch(player)
+		match.isPlacing.Store(false)
+
+		// 2. Act
+		err := match.PlaceFleet(events.PlaceFleetCommandArgs{PlayerID: "1", Ships: validFleet})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Falsef(t, player.IsReady(), "player must not be ready")
+	})
+}
+
+func TestRerollFleet(t *testing.T) {
+	t.Run("reroll changes the board of a player", func(t *testing.T) {
+		// 1. Arrange
+		player := newTestPlayer(t, "1")
+		player.SetBoard(domain.Board{})
+		match := newPlacingMatch(player)
+
+		// 2. Act
+		err := match.RerollFleet("1")
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.NotZerof(t, player.Model.ShipCells, "player must get a new fleet")
+	})
+
+	t.Run("ready player cannot reroll the fleet", func(t *testing.T) {
+		// 1. Arrange
+		player := newTestPlayer(t, "1")
+		player.SetBoard(domain.Board{})
+		player.SetReady(true)
+		match := newPlacingMatch(player)
+
+		// 2. Act
+		err := match.RerollFleet("1")
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Zerof(t, player.Model.ShipCells, "board must not be changed")
+	})
+}
+
+func TestSeed(t *testing.T) {
+	t.Run("same seed reproduces boards and the turn order", func(t *testing.T) {
+		// 1. Arrange
+		// Client IDs are new in each session.
+		play := func() (boards []domain.Board, firstSeat int) {
+			players := []*Player{newTestPlayer(t, uuid.New().String()), newTestPlayer(t, uuid.New().String()), newTestPlayer(t, uuid.New().String())}
+			match := newPlacingMatch(players...)
+			match.rng = domain.NewRNG(42)
+
+			for _, player := range players {
+				require.NoError(t, match.RerollFleet(player.ID()))
+				boards = append(boards, player.Model.Board)
+			}
+			return boards, match.getRandomPlayer().seat
+		}
+
+		// 2. Act
+		lhsBoards, lhsFirstSeat := play()
+		rhsBoards, rhsFirstSeat := play()
+
+		// 3. Assert
+		require.Equal(t, lhsBoards, rhsBoards)
+		require.Equal(t, lhsFirstSeat, rhsFirstSeat)
+	})
+
+	t.Run("seed is exposed with the start of the game", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		match.seed = 123456789
+		match.isStarted.Store(false)
+		match.isPlacing.Store(true)
+
+		var gameStartEvent *events.GameStartEvent
+		clientMock := websocket.NewMockClient(t)
+		clientMock.On("ID").Return(players[0].ID()).Maybe()
+		clientMock.On("SendMessage", mock.Anything).Run(func(args mock.Arguments) {
+			if e := args.Get(0).(events.Event); e.Type == events.GameStartEventType {
+				event, err := events.CastTo[events.GameStartEvent](e)
+				require.NoError(t, err)
+				gameStartEvent = &event
+			}
+		}).Return(nil)
+		players[0].Client = clientMock
+
+		// 2. Act
+		err := match.StartMatch()
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.NotNil(t, gameStartEvent)
+		require.Equal(t, int64(123456789), gameStartEvent.Seed)
+	})
+}
+
+func newTestPlayer(t *testing.T, id string) *Player {
+	clientMock := websocket.NewMockClient(t)
+	clientMock.On("ID").Return(id).Maybe()
+	clientMock.On("SendMessage", mock.Anything).Return(nil).Maybe()
+
+	return NewPlayer(clientMock, domain.ClientMetadata{ClientID: id, Nickname: "player " + id})
+}
+
+// newTestBoard fills the top rows of a default-size board with the given cells.
+func newTestBoard(rows ...[]domain.Cell) domain.Board {
+	board := domain.NewBoard(domain.DefaultBoardSize)
+	for y, row := range rows {
+		copy(board[y], row)
+	}
+	return board
+}
+
+func newPlacingMatch(players ...*Player) *Match {
+	match := &Match{
+		doneCh:  make(chan struct{}),
+		room:    &Room{clients: make(map[string]websocket.Client, len(players))},
+		cfg:     &config.Config{},
+		rules:   domain.DefaultRules(),
+		rng:     domain.NewRNG(1),
+		players: make(map[string]*Player, len(players)),
+		cmds:    make(chan Command, 10),
+	}
+
+	for _, player := range players {
+		match.takeSeat(player)
+		match.players[player.ID()] = player
+		match.room.clients[player.ID()] = player
+	}
+	match.isPlacing.Store(true)
+
+	return match
+}
+
+func TestFireSinksShip(t *testing.T) {
+	t.Run("surroundings of a sunk ship are revealed to the shooter", func(t *testing.T) {
+		// 1. Arrange
+		firingPlayer := newTestPlayer(t, "1")
+		targetPlayer := newTestPlayer(t, "2")
+		targetPlayer.SetBoard(newTestBoard(
+			[]domain.Cell{domain.Ship, domain.Ship},
+		))
+
+		match := newPlacingMatch(firingPlayer, targetPlayer)
+		match.isPlacing.Store(false)
+		match.isStarted.Store(true)
+		match.turningPlayer = firingPlayer
+
+		// 2. Act
+		require.NoError(t, match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2", CellX: 0, CellY: 0}))
+		require.NoError(t, match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2", CellX: 1, CellY: 0}))
+
+		// 3. Assert
+		maskedBoard := targetPlayer.maskBoardForPlayer(firingPlayer)
+		require.Equal(t, domain.Sunk, maskedBoard.GetCellType(0, 0))
+		require.Equal(t, domain.Sunk, maskedBoard.GetCellType(1, 0))
+		require.Equal(t, domain.Miss, maskedBoard.GetCellType(2, 0))
+		require.Equal(t, domain.Miss, maskedBoard.GetCellType(0, 1))
+		require.Equal(t, domain.Miss, maskedBoard.GetCellType(2, 1))
+		require.Truef(t, maskedBoard.IsCellEmpty(3, 0), "cell far from the ship must stay hidden")
+
+		gameState := match.buildGameStateForPlayer(firingPlayer)
+		require.Lenf(t, gameState.Players["2"].Ships, 1, "only sunk ships of the opponent are visible")
+	})
+}
+
+func TestHitGrantsShot(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		hitGrantsShot bool
+		cellX         byte
+		expectedCmd   Command
+	}{
+		{
+			name:          "hit keeps the turn when the rule is on",
+			hitGrantsShot: true,
+			cellX:         0,
+			expectedCmd:   &GameContinueTurnCommand{},
+		},
+		{
+			name:          "miss passes the turn when the rule is on",
+			hitGrantsShot: true,
+			cellX:         5,
+			expectedCmd:   &GameTurnCommand{},
+		},
+		{
+			name:          "hit passes the turn when the rule is off",
+			hitGrantsShot: false,
+			cellX:         0,
+			expectedCmd:   &GameTurnCommand{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			firingPlayer := newTestPlayer(t, "1")
+			targetPlayer := newTestPlayer(t, "2")
+			targetPlayer.SetBoard(newTestBoard(
+				[]domain.Cell{domain.Ship, domain.Ship, domain.Empty, domain.Ship},
+			))
+
+			match := newPlacingMatch(firingPlayer, targetPlayer)
+			match.rules.HitGrantsShot = tt.hitGrantsShot
+			match.isPlacing.Store(false)
+			match.isStarted.Store(true)
+			match.turningPlayer = firingPlayer
+
+			// 2. Act
+			err := match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2", CellX: tt.cellX, CellY: 0})
+
+			// 3. Assert
+			require.NoError(t, err)
+			require.Len(t, match.cmds, 1)
+			require.IsType(t, tt.expectedCmd, <-match.cmds)
+		})
+	}
+}
+
+func TestFireSalvo(t *testing.T) {
+	newSalvoMatch := func(t *testing.T) (*Match, *Player) {
+		firingPlayer := newTestPlayer(t, "1")
+		firingPlayer.SetBoard(newTestBoard(
+			[]domain.Cell{domain.Ship, domain.Empty, domain.Ship},
+		))
+		targetPlayer := newTestPlayer(t, "2")
+		targetPlayer.SetBoard(newTestBoard(
+			[]domain.Cell{domain.Ship, domain.Ship, domain.Empty, domain.Ship},
+		))
+
+		match := newPlacingMatch(firingPlayer, targetPlayer)
+		match.rules.Mode = domain.SalvoMode
+		match.isPlacing.Store(false)
+		match.isStarted.Store(true)
+		match.turningPlayer = firingPlayer
+		return match, targetPlayer
+	}
+
+	t.Run("all shots of a salvo are resolved together", func(t *testing.T) {
+		// 1. Arrange
+		match, targetPlayer := newSalvoMatch(t)
+
+		// 2. Act
+		err := match.Fire(events.FireCommandArgs{
+			FiringPlayerID: "1",
+			TargetPlayerID: "2",
+			Shots:          []domain.Coordinate{{X: 3, Y: 0}, {X: 5, Y: 5}},
+		})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Equal(t, domain.Sunk, targetPlayer.Model.Board.GetCellType(3, 0))
+		require.Equal(t, domain.Miss, targetPlayer.Model.Board.GetCellType(5, 5))
+		require.IsType(t, &GameTurnCommand{}, <-match.cmds)
+	})
+
+	for _, tt := range []struct {
+		name        string
+		shots       []domain.Coordinate
+		expectedErr error
+	}{
+		{
+			name:        "more shots than ships afloat",
+			shots:       []domain.Coordinate{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 3, Y: 0}},
+			expectedErr: ErrInvalidSalvo,
+		},
+		{
+			name:        "the same cell twice",
+			shots:       []domain.Coordinate{{X: 0, Y: 0}, {X: 0, Y: 0}},
+			expectedErr: ErrInvalidSalvo,
+		},
+		{
+			name:        "one of the cells is out of bounds",
+			shots:       []domain.Coordinate{{X: 0, Y: 0}, {X: 255, Y: 0}},
+			expectedErr: ErrInvalidTarget,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			match, targetPlayer := newSalvoMatch(t)
+
+			// 2. Act
+			err := match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2", Shots: tt.shots})
+
+			// 3. Assert
+			require.NoErrorf(t, err, "rejected salvo must not close the match")
+			require.Equalf(t, domain.Ship, targetPlayer.Model.Board.GetCellType(0, 0), "rejected salvo must not be resolved")
+			require.Empty(t, match.cmds)
+			requireNotified(t, match.players["1"], tt.expectedErr.Error())
+		})
+	}
+}
+
+// requireNotified checks that the player alone was told the message.
+func requireNotified(t *testing.T, player *Player, msg string) {
+	t.Helper()
+
+	player.Client.(*websocket.MockClient).AssertCalled(t, "SendMessage", mock.MatchedBy(func(e events.Event) bool {
+		notification, err := events.CastTo[events.SendMessageEvent](e)
+		return err == nil && notification.Message == msg
+	}))
+}
+
+func TestRefusedShot(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		arrange     func(match *Match)
+		expectedErr error
+	}{
+		{
+			name: "shot before the match starts",
+			arrange: func(match *Match) {
+				match.isStarted.Store(false)
+				match.isPlacing.Store(true)
+				match.turningPlayer = nil
+			},
+			expectedErr: ErrNotStarted,
+		},
+		{
+			name: "shot after the turn ran out",
+			arrange: func(match *Match) {
+				match.turningPlayer = match.players["2"]
+			},
+			expectedErr: ErrNotYourTurn,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			match, players := newStartedMatch(t)
+			board := players[1].Model.Board.Clone()
+			tt.arrange(match)
+
+			// 2. Act
+			err := match.Fire(events.FireCommandArgs{FiringPlayerID: players[0].ID(), TargetPlayerID: players[1].ID()})
+
+			// 3. Assert
+			require.NoErrorf(t, err, "refused shot must not close the match")
+			require.Empty(t, match.cmds)
+			require.Equalf(t, board, players[1].Model.Board, "refused shot must not be resolved")
+			requireNotified(t, players[0], tt.expectedErr.Error())
+		})
+	}
+}
+
+func TestFreeForAll(t *testing.T) {
+	newFreeForAllMatch := func(t *testing.T) (*Match, []*Player) {
+		players := []*Player{newTestPlayer(t, "1"), newTestPlayer(t, "2"), newTestPlayer(t, "3")}
+		for _, player := range players {
+			player.SetBoard(newTestBoard(
+				[]domain.Cell{domain.Ship},
+			))
+		}
+
+		match := newPlacingMatch(players...)
+		match.isPlacing.Store(false)
+		match.isStarted.Store(true)
+		match.turningPlayer = players[0]
+		return match, players
+	}
+
+	t.Run("match goes on while at least two fleets are afloat", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newFreeForAllMatch(t)
+
+		// 2. Act
+		err := match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2"})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.True(t, players[1].IsEliminated())
+		require.IsType(t, &GameTurnCommand{}, <-match.cmds)
+		require.Equalf(t, players[2], match.getNextTarget(), "eliminated player must be skipped")
+	})
+
+	t.Run("last fleet standing wins", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newFreeForAllMatch(t)
+		players[1].Model.HitAt(0, 0)
+
+		// 2. Act
+		err := match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "3"})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.IsType(t, &GameEndCommand{}, <-match.cmds)
+	})
+
+	t.Run("eliminated player cannot be a target", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newFreeForAllMatch(t)
+		players[1].Model.HitAt(0, 0)
+
+		// 2. Act
+		err := match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2", CellX: 1})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Empty(t, match.cmds)
+		requireNotified(t, players[0], ErrInvalidTarget.Error())
+	})
+
+	t.Run("revealed cells are visible on the fired board only", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newFreeForAllMatch(t)
+
+		// 2. Act
+		err := match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2", CellX: 5, CellY: 5})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Equal(t, domain.Miss, players[1].maskBoardForPlayer(players[0]).GetCellType(5, 5))
+		require.True(t, players[2].maskBoardForPlayer(players[0]).IsCellEmpty(5, 5))
+	})
+
+	t.Run("last remaining player wins when others leave", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newFreeForAllMatch(t)
+
+		// 2. Act
+		require.NoError(t, match.RemovePlayer(players[1]))
+		require.Empty(t, match.cmds)
+		require.NoError(t, match.RemovePlayer(players[2]))
+
+		// 3. Assert
+		require.IsType(t, &GameEndCommand{}, <-match.cmds)
+	})
+}
+
+func TestTeamMatch(t *testing.T) {
+	newTeamMatch := func(t *testing.T) (*Match, []*Player) {
+		players := []*Player{newTestPlayer(t, "1"), newTestPlayer(t, "2"), newTestPlayer(t, "3"), newTestPlayer(t, "4")}
+		for i, player := range players {
+			player.SetBoard(newTestBoard(
+				[]domain.Cell{domain.Ship},
+			))
+			player.Model.Team = i/2 + 1
+		}
+
+		match := newPlacingMatch(players...)
+		match.rules.IsTeamMatch = true
+		match.isPlacing.Store(false)
+		match.isStarted.Store(true)
+		match.turningPlayer = players[0]
+		return match, players
+	}
+
+	t.Run("ally cannot be a target", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newTeamMatch(t)
+
+		// 2. Act
+		err := match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2"})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Equalf(t, domain.Ship, players[1].Model.Board.GetCellType(0, 0), "ally must not be hit")
+		requireNotified(t, players[0], ErrInvalidTarget.Error())
+	})
+
+	t.Run("team is eliminated when both fleets are sunk", func(t *testing.T) {
+		// 1. Arrange
+		match, _ := newTeamMatch(t)
+
+		// 2. Act
+		require.NoError(t, match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "3"}))
+		require.IsType(t, &GameTurnCommand{}, <-match.cmds)
+		require.NoError(t, match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "4"}))
+
+		// 3. Assert
+		require.IsType(t, &GameEndCommand{}, <-match.cmds)
+	})
+
+	t.Run("turns alternate between teams", func(t *testing.T) {
+		// 1. Arrange
+		match, _ := newTeamMatch(t)
+
+		for i := 0; i < 8; i++ {
+			// 2. Act
+			nextPlayer := match.getNextTarget()
+
+			// 3. Assert
+			require.NotEqual(t, match.turningPlayer.Model.Team, nextPlayer.Model.Team)
+			match.turningPlayer = nextPlayer
+		}
+	})
+
+	t.Run("ally board is fully visible", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newTeamMatch(t)
+
+		// 2. Act
+		gameState := match.buildGameStateForPlayer(players[0])
+
+		// 3. Assert
+		require.Equal(t, domain.Ship, gameState.Players["2"].Board.GetCellType(0, 0))
+		require.Truef(t, gameState.Players["3"].Board.IsCellEmpty(0, 0), "opponent board must be masked")
+	})
+
+	t.Run("cells revealed by an ally are shared", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newTeamMatch(t)
+		players[1].RevealCell("3", 5, 5)
+
+		// 2. Act
+		gameState := match.buildGameStateForPlayer(players[0])
+
+		// 3. Assert
+		require.Equal(t, domain.Miss, gameState.Players["3"].Board.GetCellType(5, 5))
+	})
+}
+
+func TestTimeoutTurn(t *testing.T) {
+	newTimeoutMatch := func(t *testing.T, policy string) (*Match, []*Player) {
+		players := []*Player{newTestPlayer(t, "1"), newTestPlayer(t, "2")}
+		for _, player := range players {
+			player.SetBoard(newTestBoard(
+				[]domain.Cell{domain.Ship, domain.Empty, domain.Ship},
+			))
+		}
+
+		match := newPlacingMatch(players...)
+		match.cfg.Game.TimeoutPolicy = policy
+		match.cfg.Game.TimeoutsToForfeit = 2
+		match.isPlacing.Store(false)
+		match.isStarted.Store(true)
+		match.turningPlayer = players[0]
+		return match, players
+	}
+
+	t.Run("turn is skipped", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newTimeoutMatch(t, config.SkipTurnPolicy)
+
+		// 2. Act
+		err := match.TimeoutTurn()
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Equal(t, 1, players[0].timeouts)
+		require.IsType(t, &GameTurnCommand{}, <-match.cmds)
+	})
+
+	t.Run("random cell is fired at", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newTimeoutMatch(t, config.AutoFirePolicy)
+
+		// 2. Act
+		err := match.TimeoutTurn()
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Len(t, players[0].visibility["2"], 1)
+		require.Len(t, match.cmds, 1)
+	})
+
+	t.Run("timeouts counter is reset when the player fires", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newTimeoutMatch(t, config.SkipTurnPolicy)
+		require.NoError(t, match.TimeoutTurn())
+		<-match.cmds
+
+		// 2. Act
+		err := match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2", CellX: 5, CellY: 5})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Zero(t, players[0].timeouts)
+	})
+
+	t.Run("player forfeits after too many timeouts in a row", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newTimeoutMatch(t, config.SkipTurnPolicy)
+		require.NoError(t, match.TimeoutTurn())
+		<-match.cmds
+
+		// 2. Act
+		err := match.TimeoutTurn()
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.True(t, players[0].IsEliminated())
+
+		cmd := <-match.cmds
+		require.IsType(t, &GameEndCommand{}, cmd)
+		require.Equal(t, players[1], cmd.(*GameEndCommand).winningPlayer)
+	})
+}
+
+// newStartedMatch starts the match of two players, the first one turns.
+func newStartedMatch(t *testing.T) (*Match, []*Player) {
+	players := []*Player{newTestPlayer(t, "1"), newTestPlayer(t, "2")}
+	for _, player := range players {
+		player.resumeToken = "token " + player.ID()
+	}
+
+	loggerMock := new(logger.MockLogger)
+	loggerMock.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	loggerMock.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	match := newPlacingMatch(players...)
+	match.logger = loggerMock
+	match.room.cfg = &config.AppConfig{RoomCapacityMax: 2}
+	match.cfg.Game.GameTurnTime = time.Minute
+	match.cfg.Game.ReconnectTime = time.Minute
+	match.gameTurnTimer = time.NewTimer(time.Minute)
+	match.turnDeadline = time.Now().Add(time.Minute)
+	match.isPlacing.Store(false)
+	match.isStarted.Store(true)
+	match.turningPlayer = players[0]
+
+	t.Cleanup(func() {
+		match.gameTurnTimer.Stop()
+		for _, player := range players {
+			if player.resumeTimer != nil {
+				player.resumeTimer.Stop()
+			}
+		}
+	})
+	return match, players
+}
+
+func TestSessionResume(t *testing.T) {
+	disconnect := func(t *testing.T, match *Match, player *Player) {
+		delete(match.room.clients, player.ID())
+		require.NoError(t, match.DisconnectPlayer(player))
+	}
+
+	newReconnectedPlayer := func(t *testing.T, id, resumeToken string) (*Player, *websocket.MockClient) {
+		clientMock := websocket.NewMockClient(t)
+		clientMock.On("ID").Return(id).Maybe()
+		clientMock.On("ReadMessages", mock.Anything, mock.Anything).Return().Maybe()
+		clientMock.On("WriteMessages", mock.Anything).Return().Maybe()
+
+		return NewPlayer(clientMock, domain.ClientMetadata{ClientID: id, ResumeToken: resumeToken}), clientMock
+	}
+
+	t.Run("disconnected player is kept and the turn timer is paused", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+
+		// 2. Act
+		disconnect(t, match, players[0])
+
+		// 3. Assert
+		require.Contains(t, match.players, players[0].ID())
+		require.True(t, players[0].IsDisconnected())
+		require.True(t, match.isTurnPaused)
+		require.True(t, match.IsAwaitingPlayer(players[0].resumeToken))
+		require.Empty(t, match.cmds)
+	})
+
+	t.Run("player resumes the session with a valid token", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		disconnect(t, match, players[0])
+
+		reconnectedPlayer, clientMock := newReconnectedPlayer(t, "1", "token 1")
+		clientMock.On("SendMessage", mock.MatchedBy(func(e events.Event) bool {
+			return e.Type == events.SessionResumedEventType
+		})).Return(nil).Once()
+		clientMock.On("SendMessage", mock.Anything).Return(nil).Maybe()
+
+		// 2. Act
+		err := match.ResumePlayer(reconnectedPlayer)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.False(t, players[0].IsDisconnected())
+		require.False(t, match.isTurnPaused)
+		require.False(t, match.IsAwaitingPlayer(players[0].resumeToken))
+		require.Equal(t, clientMock, players[0].Client)
+		require.Contains(t, match.room.clients, players[0].ID())
+	})
+
+	t.Run("player with an invalid token is rejected", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		disconnect(t, match, players[0])
+
+		reconnectedPlayer, clientMock := newReconnectedPlayer(t, "1", "token 2")
+		clientMock.On("Close").Return().Once()
+
+		// 2. Act
+		err := match.ResumePlayer(reconnectedPlayer)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.True(t, players[0].IsDisconnected())
+		require.True(t, match.isTurnPaused)
+		require.NotContains(t, match.room.clients, players[0].ID())
+	})
+
+	t.Run("player who didn't reconnect in time leaves the match", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		disconnect(t, match, players[0])
+		players[0].resumeDeadline = time.Now()
+
+		// 2. Act
+		err := match.ExpireSession(players[0])
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.NotContains(t, match.players, players[0].ID())
+		require.False(t, match.IsAwaitingPlayer(players[0].resumeToken))
+
+		cmd := <-match.cmds
+		require.IsType(t, &GameEndCommand{}, cmd)
+		require.Equal(t, players[1], cmd.(*GameEndCommand).winningPlayer)
+	})
+
+	t.Run("session doesn't expire in the ended match", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		disconnect(t, match, players[0])
+
+		// 2. Act
+		err := match.EndMatch(players[1])
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Falsef(t, players[0].resumeTimer.Stop(), "resume timer must be already stopped")
+	})
+
+	t.Run("player, who has left already, isn't disconnected twice", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		delete(match.players, players[0].ID())
+
+		// 2. Act
+		err := match.DisconnectPlayer(players[0])
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.False(t, players[0].IsDisconnected())
+		require.Empty(t, match.cmds)
+	})
+
+	t.Run("player leaves right away before the match has started", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		match.isStarted.Store(false)
+
+		// 2. Act
+		disconnect(t, match, players[0])
+
+		// 3. Assert
+		require.NotContains(t, match.players, players[0].ID())
+		require.False(t, match.isTurnPaused)
+	})
+}
+
+func TestKickPlayer(t *testing.T) {
+	t.Run("kicked player leaves without waiting for the reconnect", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+
+		// 2. Act
+		require.NoError(t, match.KickPlayer(players[0].ID()))
+		delete(match.room.clients, players[0].ID())
+		require.NoError(t, match.DisconnectPlayer(players[0]))
+
+		// 3. Assert
+		require.NotContains(t, match.players, players[0].ID())
+		require.False(t, match.IsAwaitingPlayer(players[0].resumeToken))
+		require.False(t, match.isTurnPaused)
+	})
+
+	t.Run("disconnected player can't resume the session after the kick", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		delete(match.room.clients, players[0].ID())
+		require.NoError(t, match.DisconnectPlayer(players[0]))
+
+		// 2. Act
+		err := match.KickPlayer(players[0].ID())
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.NotContains(t, match.players, players[0].ID())
+		require.False(t, match.IsAwaitingPlayer(players[0].resumeToken))
+
+		cmd := <-match.cmds
+		require.IsType(t, &GameEndCommand{}, cmd)
+		require.Equal(t, players[1], cmd.(*GameEndCommand).winningPlayer)
+	})
+
+	t.Run("unknown player is ignored", func(t *testing.T) {
+		// 1. Arrange
+		match, _ := newStartedMatch(t)
+
+		// 2. Act
+		err := match.KickPlayer("unknown")
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Len(t, match.players, 2)
+	})
+}
+
+func TestSpoofedEvents(t *testing.T) {
+	newEvent := func(t *testing.T, senderID string, event events.Event, err error) events.Event {
+		require.NoError(t, err)
+		event.SenderID = senderID
+		return event
+	}
+
+	t.Run("player can't fire on behalf of another player", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		event, err := events.NewPlayerFireEvent(events.FireCommandArgs{FiringPlayerID: players[0].ID(), TargetPlayerID: players[1].ID()})
+
+		// 2. Act
+		err = match.onPlayerFiredHandler(newEvent(t, players[1].ID(), event, err))
+
+		// 3. Assert
+		require.ErrorIs(t, err, ErrSpoofedIdentity)
+		require.Empty(t, match.cmds)
+	})
+
+	t.Run("player fires on its own behalf", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		event, err := events.NewPlayerFireEvent(events.FireCommandArgs{FiringPlayerID: players[0].ID(), TargetPlayerID: players[1].ID()})
+
+		// 2. Act
+		err = match.onPlayerFiredHandler(newEvent(t, players[0].ID(), event, err))
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.IsType(t, &FireCommand{}, <-match.cmds)
+	})
+
+	t.Run("player can't place or reroll the fleet of another player", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		placeEvent, placeErr := events.NewPlaceFleetEvent(events.PlaceFleetCommandArgs{PlayerID: players[0].ID()})
+		rerollEvent, rerollErr := events.NewRerollFleetEvent(players[0].ID())
+
+		// 2. Act
+		placeErr = match.onPlayerPlacedFleetHandler(newEvent(t, players[1].ID(), placeEvent, placeErr))
+		rerollErr = match.onPlayerRerolledFleetHandler(newEvent(t, players[1].ID(), rerollEvent, rerollErr))
+
+		// 3. Assert
+		require.ErrorIs(t, placeErr, ErrSpoofedIdentity)
+		require.ErrorIs(t, rerollErr, ErrSpoofedIdentity)
+		require.Empty(t, match.cmds)
+	})
+
+	t.Run("chat message is signed by the sender", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		event, err := events.NewEvent(events.SendMessageType, events.SendMessageEvent{
+			Sender:  players[1].Nickname(),
+			Message: "I surrender",
+			Type:    events.RoomNotificationType,
+		})
+
+		// 2. Act
+		err = match.onPlayerSentMessageHandler(newEvent(t, players[0].ID(), event, err))
+
+		// 3. Assert
+		require.NoError(t, err)
+		history := match.getChatHistory()
+		require.Len(t, history, 1)
+
+		msg, err := events.CastTo[events.SendMessageEvent](history[0])
+		require.NoError(t, err)
+		require.Equal(t, players[0].Nickname(), msg.Sender)
+		require.Equal(t, events.MessageType, msg.Type)
+		require.Equal(t, "I surrender", msg.Message)
+	})
+
+	t.Run("chat message from a client, which isn't a player, is refused", func(t *testing.T) {
+		// 1. Arrange
+		match, _ := newStartedMatch(t)
+		event, err := events.NewSendMessageEvent("player 1", "hello")
+
+		// 2. Act
+		err = match.onPlayerSentMessageHandler(newEvent(t, "stranger", event, err))
+
+		// 3. Assert
+		require.ErrorIs(t, err, ErrUnknownSender)
+		require.Empty(t, match.getChatHistory())
+	})
+}
+
+func TestOpponentBoardIsNotLeaked(t *testing.T) {
+	// findBoards collects boards of the player from any event, wherever the player is nested.
+	var findBoards func(value any, playerID string, states *[]events.PlayerState)
+	findBoards = func(value any, playerID string, states *[]events.PlayerState) {
+		switch value := value.(type) {
+		case map[string]any:
+			if _, hasBoard := value["board"]; hasBoard && value["id"] == playerID {
+				raw, err := json.Marshal(value)
+				require.NoError(t, err)
+
+				var state events.PlayerState
+				require.NoError(t, json.Unmarshal(raw, &state))
+				*states = append(*states, state)
+			}
+			for _, nested := range value {
+				findBoards(nested, playerID, states)
+			}
+		case []any:
+			for _, nested := range value {
+				findBoards(nested, playerID, states)
+			}
+		}
+	}
+
+	t.Run("player never gets unrevealed ships of the opponent", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newStartedMatch(t)
+		player, opponent := players[0], players[1]
+		opponent.SetBoard(newTestBoard(
+			[]domain.Cell{domain.Ship, domain.Ship, domain.Empty, domain.Ship},
+		))
+
+		var sentEvents []events.Event
+		clientMock := websocket.NewMockClient(t)
+		clientMock.On("ID").Return(player.ID()).Maybe()
+		clientMock.On("SendMessage", mock.Anything).Run(func(args mock.Arguments) {
+			sentEvents = append(sentEvents, args.Get(0).(events.Event))
+		}).Return(nil)
+		player.Client = clientMock
+		match.room.logger = match.logger
+
+		// 2. Act
+		match.onPlayerJoinedHandler(opponent)
+		require.NoError(t, match.allPlayersUpdate())
+		for _, cell := range []domain.Coordinate{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 5, Y: 5}} {
+			match.turningPlayer = player
+			require.NoError(t, match.Fire(events.FireCommandArgs{FiringPlayerID: player.ID(), TargetPlayerID: opponent.ID(), CellX: cell.X, CellY: cell.Y}))
+		}
+		require.NoError(t, match.sendResumedSession(player))
+		match.announcePlayerLeft(opponent)
+		require.NoError(t, match.EndMatch(player))
+
+		// 3. Assert
+		require.True(t, slices.ContainsFunc(sentEvents, func(e events.Event) bool {
+			return e.Type == events.PlayerJoinedEventType
+		}), "join of the opponent isn't broadcast")
+
+		var states []events.PlayerState
+		for _, event := range sentEvents {
+			var data any
+			require.NoError(t, json.Unmarshal(event.Data, &data))
+			findBoards(data, opponent.ID(), &states)
+		}
+		require.NotEmpty(t, states)
+
+		lastState := states[len(states)-1]
+		require.Equal(t, domain.Dead, lastState.Board.GetCellType(0, 0))
+		require.Equal(t, domain.Sunk, lastState.Board.GetCellType(3, 0))
+		require.Len(t, lastState.Ships, 1)
+
+		for _, state := range states {
+			require.Zero(t, state.ShipCells, "count of ship cells of the opponent is leaked")
+			for y := range state.Board {
+				for x := range state.Board[y] {
+					require.NotEqualf(t, domain.Ship, state.Board.GetCellType(byte(x), byte(y)), "cell (%d, %d) of the opponent is leaked", x, y)
+				}
+			}
+			for _, ship := range state.Ships {
+				require.True(t, ship.IsSunk(), "ship of the opponent, which isn't sunk, is leaked")
+			}
+		}
+	})
+}
+
+func TestSpectator(t *testing.T) {
+	newSpectatedMatch := func(t *testing.T) (*Match, []*Player) {
+		players := []*Player{newTestPlayer(t, "1"), newTestPlayer(t, "2")}
+		for _, player := range players {
+			player.SetBoard(newTestBoard(
+				[]domain.Cell{domain.Ship, domain.Empty, domain.Ship},
+			))
+		}
+
+		loggerMock := new(logger.MockLogger)
+		loggerMock.On("Infof", mock.Anything, mock.Anything).Maybe()
+
+		match := newPlacingMatch(players...)
+		match.logger = loggerMock
+		match.audience = &Room{
+			clients: make(map[string]websocket.Client),
+			cfg:     &config.AppConfig{RoomCapacityMax: 1},
+		}
+		match.isPlacing.Store(false)
+		match.isStarted.Store(true)
+		match.turningPlayer = players[0]
+		return match, players
+	}
+
+	newTestSpectator := func(t *testing.T) (*Spectator, *websocket.MockClient) {
+		clientMock := websocket.NewMockClient(t)
+		clientMock.On("ID").Return("spectator").Maybe()
+		clientMock.On("ReadMessages", mock.Anything, mock.Anything).Return().Maybe()
+		clientMock.On("WriteMessages", mock.Anything).Return().Maybe()
+
+		return NewSpectator(clientMock, domain.ClientMetadata{ClientID: "spectator", Role: domain.SpectatorRole}), clientMock
+	}
+
+	t.Run("spectator is kept apart from players", func(t *testing.T) {
+		// 1. Arrange
+		match, _ := newSpectatedMatch(t)
+		spectator, clientMock := newTestSpectator(t)
+		clientMock.On("SendMessage", mock.Anything).Return(nil)
+
+		// 2. Act
+		err := match.JoinSpectator(spectator)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Len(t, match.players, 2)
+		require.Equal(t, 1, match.audience.Capacity())
+		require.Contains(t, match.room.clients, "1")
+		require.NotContains(t, match.room.clients, "spectator")
+	})
+
+	t.Run("spectator sees only cells revealed by shots until the match is over", func(t *testing.T) {
+		// 1. Arrange
+		match, _ := newSpectatedMatch(t)
+		require.NoError(t, match.Fire(events.FireCommandArgs{FiringPlayerID: "1", TargetPlayerID: "2", CellX: 0, CellY: 0}))
+
+		// 2. Act
+		gameState := match.buildGameStateForPlayer(nil)
+		match.isEnded = true
+		revealedGameState := match.buildGameStateForPlayer(nil)
+
+		// 3. Assert
+		require.Equal(t, domain.Sunk, gameState.Players["2"].Board.GetCellType(0, 0))
+		require.True(t, gameState.Players["2"].Board.IsCellEmpty(2, 0))
+		require.True(t, gameState.Players["1"].Board.IsCellEmpty(0, 0))
+		require.Equal(t, domain.Ship, revealedGameState.Players["1"].Board.GetCellType(0, 0))
+	})
+
+	t.Run("ended match is closed after the spectator delay", func(t *testing.T) {
+		// 1. Arrange
+		match, players := newSpectatedMatch(t)
+		match.cfg.Game.SpectatorDelay = time.Minute
+		match.gameTurnTimer = time.NewTimer(time.Minute)
+		match.closeTimer = time.NewTimer(time.Minute)
+		match.closeTimer.Stop()
+
+		// 2. Act
+		err := match.EndMatch(players[0])
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Emptyf(t, match.cmds, "match must not be closed before spectators see its end")
+		require.Truef(t, match.closeTimer.Stop(), "close timer must be armed")
+	})
+
+	t.Run("events are delayed for spectators", func(t *testing.T) {
+		// 1. Arrange
+		match, _ := newSpectatedMatch(t)
+		match.cfg.Game.SpectatorDelay = time.Minute
+		spectator, clientMock := newTestSpectator(t)
+		require.NoError(t, match.audience.RegisterClient(spectator))
+
+		event, err := events.NewGameStartEvent(1)
+		require.NoError(t, err)
+
+		// 2. Act
+		require.NoError(t, match.broadcast(event))
+
+		// 3. Assert
+		clientMock.AssertNotCalled(t, "SendMessage", mock.Anything)
+		require.Len(t, match.spectatorQueue, 1)
+	})
+}
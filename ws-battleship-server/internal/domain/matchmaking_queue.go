@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+	"ws-battleship-server/internal/config"
+	"ws-battleship-shared/events"
+	"ws-battleship-shared/pkg/logger"
+)
+
+// waitSmoothing weights the latest wait in the moving average, which estimates waits in the queue.
+const waitSmoothing = 0.2
+
+type queueEntry struct {
+	player   *Player
+	rating   float64
+	joinedAt time.Time
+	// stopWriting hands the connection over to the match, which writes to it on its own.
+	stopWriting context.CancelFunc
+	writerDone  chan struct{}
+}
+
+// MatchmakingQueue pairs players of a close rating. The allowed rating gap grows, while players
+// wait, so nobody waits forever.
+type MatchmakingQueue struct {
+	mu       sync.Mutex
+	cfg      *config.AppConfig
+	profiles ProfileStore
+	logger   logger.Logger
+
+	entries []*queueEntry
+	// avgWait is the moving average of waits of matched players.
+	avgWait time.Duration
+}
+
+func NewMatchmakingQueue(cfg *config.AppConfig, profiles ProfileStore, logger logger.Logger) *MatchmakingQueue {
+	return &MatchmakingQueue{
+		cfg:      cfg,
+		profiles: profiles,
+		logger:   logger,
+	}
+}
+
+// Enqueue puts the player into the queue. Meanwhile the queue writes its status to the player.
+func (q *MatchmakingQueue) Enqueue(ctx context.Context, player *Player) {
+	writerCtx, cancel := context.WithCancel(ctx)
+	entry := &queueEntry{
+		player:      player,
+		rating:      q.profiles.Rating(player.ProfileID()),
+		joinedAt:    time.Now(),
+		stopWriting: cancel,
+		writerDone:  make(chan struct{}),
+	}
+
+	go func() {
+		defer close(entry.writerDone)
+		player.WriteMessages(writerCtx)
+	}()
+
+	q.mu.Lock()
+	q.entries = append(q.entries, entry)
+	position := len(q.entries)
+	q.mu.Unlock()
+
+	q.sendStatus(entry, position, entry.joinedAt)
+	q.logger.Infof("player %s with rating %.0f joined the matchmaking queue [queued: %d]", player, entry.rating, position)
+}
+
+func (q *MatchmakingQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+// Run matches players each interval and passes the groups to onMatched. Players, who are still
+// waiting, are closed, when the context is done.
+func (q *MatchmakingQueue) Run(ctx context.Context, onMatched func(players []*Player)) {
+	ticker := time.NewTicker(q.cfg.MatchmakingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			q.close()
+			return
+		case now := <-ticker.C:
+			q.dropDisconnected()
+			for _, players := range q.Match(now) {
+				onMatched(players)
+			}
+		}
+	}
+}
+
+// Match takes groups of players of a close rating out of the queue, the longest waiting players
+// are matched first. The rest get their new position in the queue.
+func (q *MatchmakingQueue) Match(now time.Time) [][]*Player {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	groupSize := int(q.cfg.RoomCapacityMax)
+	isTaken := make(map[*queueEntry]bool, len(q.entries))
+
+	var groups [][]*Player
+	for _, first := range q.entries {
+		if isTaken[first] {
+			continue
+		}
+
+		var candidates []*queueEntry
+		for _, entry := range q.entries {
+			gap := max(q.allowedGap(first, now), q.allowedGap(entry, now))
+			if entry != first && !isTaken[entry] && math.Abs(entry.rating-first.rating) <= gap {
+				candidates = append(candidates, entry)
+			}
+		}
+
+		if len(candidates) < groupSize-1 {
+			continue
+		}
+
+		slices.SortStableFunc(candidates, func(a, b *queueEntry) int {
+			return cmp.Compare(math.Abs(a.rating-first.rating), math.Abs(b.rating-first.rating))
+		})
+
+		group := append([]*queueEntry{first}, candidates[:groupSize-1]...)
+		for _, entry := range group {
+			isTaken[entry] = true
+		}
+		groups = append(groups, q.takeGroup(group, now))
+	}
+
+	q.entries = slices.DeleteFunc(q.entries, func(entry *queueEntry) bool {
+		return isTaken[entry]
+	})
+	for i, entry := range q.entries {
+		q.sendStatus(entry, i+1, now)
+	}
+	return groups
+}
+
+func (q *MatchmakingQueue) allowedGap(entry *queueEntry, now time.Time) float64 {
+	return q.cfg.RatingGap + q.cfg.RatingGapGrowth*now.Sub(entry.joinedAt).Seconds()
+}
+
+// takeGroup notifies players, that they are matched, and stops writing to them.
+func (q *MatchmakingQueue) takeGroup(group []*queueEntry, now time.Time) []*Player {
+	players := make([]*Player, 0, len(group))
+	for _, entry := range group {
+		wait := now.Sub(entry.joinedAt)
+		if q.avgWait == 0 {
+			q.avgWait = wait
+		} else {
+			q.avgWait += time.Duration(waitSmoothing * float64(wait-q.avgWait))
+		}
+
+		event, err := events.NewQueueStatusEvent(0, 0, true)
+		if err == nil {
+			err = entry.player.SendMessage(event)
+		}
+		if err != nil {
+			q.logger.Errorf("failed to notify player %s of the found match: %s", entry.player, err)
+		}
+
+		entry.stopWriting()
+		<-entry.writerDone
+		players = append(players, entry.player)
+	}
+
+	q.logger.Infof("matched %d players from the queue [queued: %d]", len(players), len(q.entries)-len(players))
+	return players
+}
+
+func (q *MatchmakingQueue) sendStatus(entry *queueEntry, position int, now time.Time) {
+	// Nothing is estimated, until anybody has been matched.
+	var estimatedWait time.Duration
+	if q.avgWait > 0 {
+		estimatedWait = max(q.avgWait-now.Sub(entry.joinedAt), 0)
+	}
+
+	event, err := events.NewQueueStatusEvent(position, estimatedWait, false)
+	if err == nil {
+		err = entry.player.SendMessage(event)
+	}
+	if err != nil {
+		q.logger.Errorf("failed to send the queue status to player %s: %s", entry.player, err)
+	}
+}
+
+// dropDisconnected removes players, whose connection has dropped. Nobody reads from them in
+// the queue, so they are pinged instead.
+func (q *MatchmakingQueue) dropDisconnected() {
+	q.mu.Lock()
+	entries := slices.Clone(q.entries)
+	q.mu.Unlock()
+
+	var dropped []*queueEntry
+	for _, entry := range entries {
+		if err := entry.player.Ping(); err != nil {
+			q.logger.Infof("player %s left the matchmaking queue: %s", entry.player, err)
+			dropped = append(dropped, entry)
+		}
+	}
+
+	if len(dropped) == 0 {
+		return
+	}
+
+	q.mu.Lock()
+	q.entries = slices.DeleteFunc(q.entries, func(entry *queueEntry) bool {
+		return slices.Contains(dropped, entry)
+	})
+	q.mu.Unlock()
+
+	for _, entry := range dropped {
+		entry.stopWriting()
+		entry.player.Close()
+	}
+}
+
+func (q *MatchmakingQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, entry := range q.entries {
+		entry.stopWriting()
+		entry.player.Close()
+	}
+	q.entries = nil
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+	"ws-battleship-server/internal/config"
+	"ws-battleship-server/internal/delivery/websocket"
+	"ws-battleship-shared/domain"
+	"ws-battleship-shared/events"
+	"ws-battleship-shared/pkg/logger"
+
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newQueuedPlayer(t *testing.T, id string, statuses chan<- events.QueueStatusEvent) *Player {
+	clientMock := websocket.NewMockClient(t)
+	clientMock.On("ID").Return(id).Maybe()
+	clientMock.On("WriteMessages", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return().Maybe()
+	clientMock.On("SendMessage", mock.Anything).Run(func(args mock.Arguments) {
+		status, err := events.CastTo[events.QueueStatusEvent](args.Get(0).(events.Event))
+		require.NoError(t, err)
+		if statuses != nil {
+			statuses <- status
+		}
+	}).Return(nil).Maybe()
+
+	return NewPlayer(clientMock, domain.ClientMetadata{ClientID: id, Nickname: "player " + id, Ranked: true, ProfileID: id})
+}
+
+func newTestQueue(t *testing.T, ratings map[string]float64) *MatchmakingQueue {
+	loggerMock := new(logger.MockLogger)
+	loggerMock.On("Infof", mock.Anything, mock.Anything).Maybe()
+
+	store, err := NewFileProfileStore("")
+	require.NoError(t, err)
+	for id, rating := range ratings {
+		require.NoError(t, store.SaveProfile(domain.Profile{ID: id, Rating: rating}))
+	}
+
+	return NewMatchmakingQueue(&config.AppConfig{
+		RoomCapacityMax: 2,
+		RatingGap:       100,
+		RatingGapGrowth: 10,
+	}, store, loggerMock)
+}
+
+func TestMatchmakingQueue(t *testing.T) {
+	t.Run("players of a close rating are matched", func(t *testing.T) {
+		// 1. Arrange
+		queue := newTestQueue(t, map[string]float64{"1": 1500, "2": 2000, "3": 1550})
+		for _, id := range []string{"1", "2", "3"} {
+			queue.Enqueue(t.Context(), newQueuedPlayer(t, id, nil))
+		}
+
+		// 2. Act
+		groups := queue.Match(time.Now())
+
+		// 3. Assert
+		require.Len(t, groups, 1)
+		require.ElementsMatch(t, []string{"1", "3"}, []string{groups[0][0].ID(), groups[0][1].ID()})
+		require.Equal(t, 1, queue.Len())
+	})
+
+	t.Run("rating gap grows with the wait", func(t *testing.T) {
+		// 1. Arrange
+		queue := newTestQueue(t, map[string]float64{"1": 1500, "2": 1800})
+		queue.Enqueue(t.Context(), newQueuedPlayer(t, "1", nil))
+		queue.Enqueue(t.Context(), newQueuedPlayer(t, "2", nil))
+
+		// 2. Act
+		notMatched := queue.Match(time.Now())
+		matched := queue.Match(time.Now().Add(30 * time.Second))
+
+		// 3. Assert
+		require.Empty(t, notMatched)
+		require.Len(t, matched, 1)
+		require.Zero(t, queue.Len())
+	})
+
+	t.Run("waiting players get their position and matched ones are notified", func(t *testing.T) {
+		// 1. Arrange
+		queue := newTestQueue(t, map[string]float64{"1": 1500, "2": 2500})
+		statuses := make(chan events.QueueStatusEvent, 10)
+		queue.Enqueue(t.Context(), newQueuedPlayer(t, "1", nil))
+		queue.Enqueue(t.Context(), newQueuedPlayer(t, "2", statuses))
+		require.Equal(t, 2, (<-statuses).Position)
+
+		// 2. Act
+		queue.Match(time.Now())
+		waiting := <-statuses
+		queue.Match(time.Now().Add(time.Minute * 2))
+		matched := <-statuses
+
+		// 3. Assert
+		require.Equal(t, 2, waiting.Position)
+		require.False(t, waiting.IsMatched)
+		require.True(t, matched.IsMatched)
+	})
+}
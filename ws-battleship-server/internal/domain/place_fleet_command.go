@@ -0,0 +1,15 @@
+package domain
+
+import "ws-battleship-shared/events"
+
+type PlaceFleetCommand struct {
+	events.PlaceFleetCommandArgs
+}
+
+func NewPlaceFleetCommand(args events.PlaceFleetCommandArgs) *PlaceFleetCommand {
+	return &PlaceFleetCommand{PlaceFleetCommandArgs: args}
+}
+
+func (c *PlaceFleetCommand) Execute(executor CommandExecutor) error {
+	return executor.PlaceFleet(c.PlaceFleetCommandArgs)
+}
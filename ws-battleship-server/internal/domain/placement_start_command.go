@@ -0,0 +1,16 @@
+package domain
+
+import "ws-battleship-shared/pkg/logger"
+
+type PlacementStartCommand struct {
+	logger logger.Logger
+}
+
+func NewPlacementStartCommand(logger logger.Logger) *PlacementStartCommand {
+	return &PlacementStartCommand{logger: logger}
+}
+
+func (c *PlacementStartCommand) Execute(executor CommandExecutor) error {
+	c.logger.Infof("fleet placement is starting in room id=%s", executor.ID())
+	return executor.StartPlacement()
+}
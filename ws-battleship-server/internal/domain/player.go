@@ -2,25 +2,62 @@ package domain
 
 import (
 	"fmt"
+	"time"
 	"ws-battleship-server/internal/delivery/websocket"
 	"ws-battleship-shared/domain"
 )
 
-type VisibleCell struct {
-	X, Y byte
-}
-
 type Player struct {
 	websocket.Client
-	Model      *domain.PlayerModel
-	visibility []VisibleCell
+	Model *domain.PlayerModel
+	// visibility holds cells revealed by this player on boards of other players, by their IDs.
+	visibility map[string][]domain.Coordinate
+	isReady    bool
+	// timeouts counts turns in a row, in which the player didn't fire in time.
+	timeouts    int
+	isForfeited bool
+	// shotsFired and hits make the accuracy of the player in the match.
+	shotsFired int
+	hits       int
+
+	resumeToken string
+	// seat is the join order of the player in the match. Unlike client IDs, it's the same, when
+	// the match is replayed, so random draws index players by seats.
+	seat int
+	// botDifficulty is set, if the player asked to play against bots.
+	botDifficulty domain.BotDifficulty
+	// matchID is set, if the player picked the match in the lobby.
+	matchID string
+	// createRoom, roomCode and roomPassword are set, if the player plays in a private room.
+	createRoom   bool
+	roomCode     string
+	roomPassword string
+	// ranked is set, if the player waits for opponents in the matchmaking queue.
+	ranked bool
+	// profileID is empty for players without a profile, their results aren't recorded.
+	profileID      string
+	isDisconnected bool
+	// isKicked is set, if the admin kicked the player, who can't resume the session then.
+	isKicked bool
+	// resumeDeadline is the moment, after which the disconnected player can't resume the session.
+	resumeDeadline time.Time
+	resumeTimer    *time.Timer
 }
 
 func NewPlayer(client websocket.Client, metadata domain.ClientMetadata) *Player {
-	model := domain.NewPlayerModel(domain.RandomizeBoard(), metadata)
+	model := domain.NewPlayerModel(domain.RandomizeBoard(domain.DefaultRules()), metadata)
 	return &Player{
-		Model:  model,
-		Client: client,
+		Model:         model,
+		Client:        client,
+		visibility:    make(map[string][]domain.Coordinate),
+		resumeToken:   metadata.ResumeToken,
+		botDifficulty: metadata.BotDifficulty,
+		matchID:       metadata.MatchID,
+		createRoom:    metadata.CreateRoom,
+		roomCode:      NormalizeRoomCode(metadata.RoomCode),
+		roomPassword:  metadata.RoomPassword,
+		ranked:        metadata.Ranked,
+		profileID:     metadata.ProfileID,
 	}
 }
 
@@ -46,21 +83,95 @@ func (p *Player) Nickname() string {
 	return p.Model.Nickname
 }
 
-func (p *Player) RevealCell(cellX, cellY byte) {
-	p.visibility = append(p.visibility, VisibleCell{X: cellX, Y: cellY})
+func (p *Player) IsReady() bool {
+	return p.isReady
+}
+
+func (p *Player) SetReady(isReady bool) {
+	p.isReady = isReady
+}
+
+func (p *Player) SetBoard(board domain.Board) {
+	p.Model.SetBoard(board)
+}
+
+// ResumeToken returns the token, by which the player resumes the session after a dropped connection.
+func (p *Player) ResumeToken() string {
+	return p.resumeToken
+}
+
+func (p *Player) BotDifficulty() domain.BotDifficulty {
+	return p.botDifficulty
+}
+
+func (p *Player) MatchID() string {
+	return p.matchID
+}
+
+// CreatesRoom reports whether the player asked for a new private room.
+func (p *Player) CreatesRoom() bool {
+	return p.createRoom
+}
+
+func (p *Player) RoomCode() string {
+	return p.roomCode
+}
+
+func (p *Player) RoomPassword() string {
+	return p.roomPassword
+}
+
+func (p *Player) ProfileID() string {
+	return p.profileID
+}
+
+// NewProfile starts the profile of the player, who connected for the first time.
+func (p *Player) NewProfile() domain.Profile {
+	return domain.NewProfile(p.ProfileID(), p.Nickname())
+}
+
+func (p *Player) IsRanked() bool {
+	return p.ranked
+}
+
+func (p *Player) IsBot() bool {
+	_, isBot := p.Client.(*Bot)
+	return isBot
+}
+
+func (p *Player) IsDisconnected() bool {
+	return p.isDisconnected
+}
+
+func (p *Player) IsEliminated() bool {
+	return p.isForfeited || p.Model.IsDead()
+}
+
+func (p *Player) Forfeit() {
+	p.isForfeited = true
+}
+
+// RevealCell makes the cell of the target player's board visible to this player.
+func (p *Player) RevealCell(targetPlayerID string, cellX, cellY byte) {
+	if p.visibility == nil {
+		p.visibility = make(map[string][]domain.Coordinate)
+	}
+	p.visibility[targetPlayerID] = append(p.visibility[targetPlayerID], domain.Coordinate{X: cellX, Y: cellY})
 }
 
-func (p *Player) maskBoardForPlayer(targetPlayer *Player) domain.Board {
-	if targetPlayer == nil {
-		return p.Model.Board
+// maskBoardForPlayer hides all cells of the board except the ones revealed by the target
+// player and its allies. A nil target player stands for a spectator, who sees the cells revealed
+// by the allies only. It's the only way to send the board to anybody but its owner.
+func (p *Player) maskBoardForPlayer(targetPlayer *Player, allies ...*Player) domain.Board {
+	viewers := allies
+	if targetPlayer != nil {
+		viewers = append([]*Player{targetPlayer}, allies...)
 	}
 
-	var copiedBoard domain.Board
-	for i := 0; i < len(targetPlayer.visibility); i++ {
-		visibleX := targetPlayer.visibility[i].X
-		visibleY := targetPlayer.visibility[i].Y
-		copiedBoard.SetCell(visibleX, visibleY, p.Model.Board.GetCellType(visibleX, visibleY))
+	var revealed []domain.Coordinate
+	for _, viewer := range viewers {
+		revealed = append(revealed, viewer.visibility[p.ID()]...)
 	}
 
-	return copiedBoard
+	return p.Model.Board.Mask(revealed)
 }
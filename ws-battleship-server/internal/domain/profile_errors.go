@@ -0,0 +1,8 @@
+package domain
+
+import "errors"
+
+var (
+	ErrProfileNotExist      = errors.New("profile doesn't exist")
+	ErrInvalidProfileSecret = errors.New("profile doesn't exist or its secret is wrong")
+)
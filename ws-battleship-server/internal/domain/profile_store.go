@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"cmp"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"ws-battleship-shared/domain"
+
+	"github.com/google/uuid"
+)
+
+// profileSecretLength is the number of random bytes in the secret of a profile.
+const profileSecretLength = 32
+
+// ProfileStore keeps profiles of players between restarts of the server.
+type ProfileStore interface {
+	Profile(id string) (domain.Profile, error)
+	SaveProfile(profile domain.Profile) error
+	// CreateProfile makes a profile with a new ID and returns the secret, which logs into it.
+	CreateProfile(nickname string) (domain.Profile, string, error)
+	// Authenticate returns the profile, if the secret is right.
+	Authenticate(id, secret string) (domain.Profile, error)
+	Profiles() []domain.Profile
+	// Rating returns the default rating for players without a profile.
+	Rating(id string) float64
+}
+
+// storedProfile keeps the hash of the secret next to the profile. The hash never leaves the server.
+type storedProfile struct {
+	domain.Profile
+	SecretHash string `json:"secret_hash,omitempty"`
+}
+
+// FileProfileStore holds all profiles in memory and rewrites the JSON file on each change.
+type FileProfileStore struct {
+	mu sync.RWMutex
+	// path is empty, if profiles aren't saved between restarts.
+	path     string
+	profiles map[string]storedProfile
+}
+
+func NewFileProfileStore(path string) (*FileProfileStore, error) {
+	store := &FileProfileStore{
+		path:     path,
+		profiles: make(map[string]storedProfile),
+	}
+
+	if path == "" {
+		return store, nil
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return store, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	var profiles []storedProfile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles: %w", err)
+	}
+
+	for _, profile := range profiles {
+		// Profiles, which were saved before handles, are published by new ones.
+		if profile.Handle == "" {
+			profile.Handle = uuid.New().String()
+		}
+		store.profiles[profile.ID] = profile
+	}
+	return store, nil
+}
+
+func (s *FileProfileStore) Profile(id string) (domain.Profile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, found := s.profiles[id]
+	if !found {
+		return domain.Profile{}, ErrProfileNotExist
+	}
+	return stored.Profile, nil
+}
+
+// SaveProfile keeps the secret of the profile, if it's already stored. A new profile is given
+// a handle.
+func (s *FileProfileStore) SaveProfile(profile domain.Profile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.profiles[profile.ID]
+	if profile.Handle == "" {
+		profile.Handle = cmp.Or(stored.Handle, uuid.New().String())
+	}
+	stored.Profile = profile
+	s.profiles[profile.ID] = stored
+	return s.save()
+}
+
+func (s *FileProfileStore) CreateProfile(nickname string) (domain.Profile, string, error) {
+	secret := make([]byte, profileSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return domain.Profile{}, "", fmt.Errorf("failed to generate a profile secret: %w", err)
+	}
+	encodedSecret := base64.RawURLEncoding.EncodeToString(secret)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile := domain.NewProfile(uuid.New().String(), nickname)
+	profile.Handle = uuid.New().String()
+	s.profiles[profile.ID] = storedProfile{
+		Profile:    profile,
+		SecretHash: hashProfileSecret(encodedSecret),
+	}
+	return profile, encodedSecret, s.save()
+}
+
+// Authenticate refuses profiles, which were saved without a secret, since anybody could claim them.
+func (s *FileProfileStore) Authenticate(id, secret string) (domain.Profile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored, found := s.profiles[id]
+	if !found || stored.SecretHash == "" || secret == "" {
+		return domain.Profile{}, ErrInvalidProfileSecret
+	}
+
+	if subtle.ConstantTimeCompare([]byte(stored.SecretHash), []byte(hashProfileSecret(secret))) != 1 {
+		return domain.Profile{}, ErrInvalidProfileSecret
+	}
+	return stored.Profile, nil
+}
+
+// Profiles are sorted by their IDs.
+func (s *FileProfileStore) Profiles() []domain.Profile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	profiles := make([]domain.Profile, 0, len(s.profiles))
+	for _, stored := range s.profiles {
+		profiles = append(profiles, stored.Profile)
+	}
+
+	slices.SortFunc(profiles, func(lhs, rhs domain.Profile) int {
+		return strings.Compare(lhs.ID, rhs.ID)
+	})
+	return profiles
+}
+
+func (s *FileProfileStore) Rating(id string) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if stored, found := s.profiles[id]; found {
+		return stored.Rating
+	}
+	return domain.DefaultRating
+}
+
+// save writes profiles into a temporary file first, so a crash never leaves the file half-written.
+func (s *FileProfileStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	profiles := make([]storedProfile, 0, len(s.profiles))
+	for _, stored := range s.profiles {
+		profiles = append(profiles, stored)
+	}
+
+	data, err := json.MarshalIndent(profiles, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write profiles: %w", err)
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// hashProfileSecret is enough for secrets, since they are random and long, unlike passwords.
+func hashProfileSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"ws-battleship-shared/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileProfileStore(t *testing.T) {
+	t.Run("profiles survive a restart", func(t *testing.T) {
+		// 1. Arrange
+		path := filepath.Join(t.TempDir(), "data", "profiles.json")
+		store, err := NewFileProfileStore(path)
+		require.NoError(t, err)
+
+		profile := domain.NewProfile("1", "player")
+		profile.Wins = 2
+
+		// 2. Act
+		require.NoError(t, store.SaveProfile(profile))
+		restarted, err := NewFileProfileStore(path)
+
+		// 3. Assert
+		require.NoError(t, err)
+		got, err := restarted.Profile("1")
+		require.NoError(t, err)
+		require.Equal(t, "player", got.Nickname)
+		require.Equal(t, 2, got.Wins)
+		require.True(t, profile.CreatedAt.Equal(got.CreatedAt))
+	})
+
+	t.Run("profile is given a handle, which is kept", func(t *testing.T) {
+		// 1. Arrange
+		store, err := NewFileProfileStore("")
+		require.NoError(t, err)
+
+		// 2. Act
+		require.NoError(t, store.SaveProfile(domain.NewProfile("1", "player")))
+		saved, err := store.Profile("1")
+		require.NoError(t, err)
+		require.NoError(t, store.SaveProfile(domain.NewProfile("1", "renamed")))
+		resaved, err := store.Profile("1")
+		require.NoError(t, err)
+		created, _, err := store.CreateProfile("player")
+		require.NoError(t, err)
+
+		// 3. Assert
+		require.NotEmpty(t, saved.Handle)
+		require.Equal(t, saved.Handle, resaved.Handle)
+		require.NotEmpty(t, created.Handle)
+		require.NotEqual(t, created.ID, created.Handle)
+	})
+
+	t.Run("created profile is logged into by its secret only", func(t *testing.T) {
+		// 1. Arrange
+		path := filepath.Join(t.TempDir(), "profiles.json")
+		store, err := NewFileProfileStore(path)
+		require.NoError(t, err)
+
+		profile, secret, err := store.CreateProfile("player")
+		require.NoError(t, err)
+		profile.Wins = 1
+		require.NoError(t, store.SaveProfile(profile))
+
+		// 2. Act
+		restarted, err := NewFileProfileStore(path)
+		require.NoError(t, err)
+		got, err := restarted.Authenticate(profile.ID, secret)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Equal(t, 1, got.Wins)
+		for _, wrongSecret := range []string{"", "forged", secret + "a"} {
+			_, err := restarted.Authenticate(profile.ID, wrongSecret)
+			require.ErrorIsf(t, err, ErrInvalidProfileSecret, "secret %q", wrongSecret)
+		}
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NotContainsf(t, string(data), secret, "secret must not be saved")
+	})
+
+	t.Run("profile without a secret can't be logged into", func(t *testing.T) {
+		// 1. Arrange
+		store, err := NewFileProfileStore("")
+		require.NoError(t, err)
+		require.NoError(t, store.SaveProfile(domain.NewProfile("1", "player")))
+
+		// 2. Act
+		_, err = store.Authenticate("1", "")
+
+		// 3. Assert
+		require.ErrorIs(t, err, ErrInvalidProfileSecret)
+	})
+
+	t.Run("unknown profile has the default rating", func(t *testing.T) {
+		// 1. Arrange
+		store, err := NewFileProfileStore(filepath.Join(t.TempDir(), "profiles.json"))
+		require.NoError(t, err)
+
+		// 2. Act
+		_, err = store.Profile("1")
+
+		// 3. Assert
+		require.ErrorIs(t, err, ErrProfileNotExist)
+		require.Equal(t, domain.DefaultRating, store.Rating("1"))
+	})
+
+	t.Run("corrupted file is reported", func(t *testing.T) {
+		// 1. Arrange
+		path := filepath.Join(t.TempDir(), "profiles.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+
+		// 2. Act
+		_, err := NewFileProfileStore(path)
+
+		// 3. Assert
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "math"
+
+// eloFactor limits how much the rating changes after a single match.
+const eloFactor = 32.0
+
+// ExpectedScore is the chance of the player to win against the opponent by the Elo rating system.
+func ExpectedScore(rating, opponentRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponentRating-rating)/400))
+}
+
+// NewEloRating returns the rating of the player after the match. The score is 1 for the win and
+// 0 for the loss, the player is scored against each opponent.
+func NewEloRating(rating, score float64, opponentRatings ...float64) float64 {
+	if len(opponentRatings) == 0 {
+		return rating
+	}
+
+	var delta float64
+	for _, opponentRating := range opponentRatings {
+		delta += score - ExpectedScore(rating, opponentRating)
+	}
+	return rating + eloFactor*delta/float64(len(opponentRatings))
+}
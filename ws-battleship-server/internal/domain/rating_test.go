@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"testing"
+	"ws-battleship-shared/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEloRating(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		rating          float64
+		score           float64
+		opponentRatings []float64
+		want            float64
+	}{
+		{
+			name:            "win against an equal opponent",
+			rating:          1500,
+			score:           1,
+			opponentRatings: []float64{1500},
+			want:            1516,
+		},
+		{
+			name:            "loss against an equal opponent",
+			rating:          1500,
+			score:           0,
+			opponentRatings: []float64{1500},
+			want:            1484,
+		},
+		{
+			name:            "win against a much stronger opponent",
+			rating:          1500,
+			score:           1,
+			opponentRatings: []float64{1900},
+			want:            1529.09,
+		},
+		{
+			name:   "rating stays without opponents",
+			rating: 1500,
+			score:  1,
+			want:   1500,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Act
+			got := NewEloRating(tt.rating, tt.score, tt.opponentRatings...)
+
+			// 2. Assert
+			require.InDelta(t, tt.want, got, 0.01)
+		})
+	}
+}
+
+func TestUpdateProfiles(t *testing.T) {
+	newFinishedMatch := func(t *testing.T) (*Match, []*Player, *FileProfileStore) {
+		players := []*Player{newTestPlayer(t, "1"), newTestPlayer(t, "2"), newTestPlayer(t, "3")}
+		for _, player := range players {
+			player.profileID = "profile " + player.ID()
+		}
+		players[0].shotsFired, players[0].hits = 4, 3
+
+		store, err := NewFileProfileStore("")
+		require.NoError(t, err)
+
+		match := newPlacingMatch(players...)
+		match.SetProfileStore(store)
+		match.startedPlayers = players
+		return match, players, store
+	}
+
+	t.Run("winner gains the rating lost by others", func(t *testing.T) {
+		// 1. Arrange
+		match, players, store := newFinishedMatch(t)
+		match.SetRated()
+
+		// 2. Act
+		match.updateProfiles(players[0])
+
+		// 3. Assert
+		require.InDelta(t, 1516, store.Rating("profile 1"), 0.01)
+		require.InDelta(t, 1484, store.Rating("profile 2"), 0.01)
+		require.InDelta(t, 1484, store.Rating("profile 3"), 0.01)
+	})
+
+	t.Run("player, who left the match, is recorded too", func(t *testing.T) {
+		// 1. Arrange
+		match, players, store := newFinishedMatch(t)
+		require.NoError(t, match.RemovePlayer(players[2]))
+
+		// 2. Act
+		match.updateProfiles(players[0])
+
+		// 3. Assert
+		profile, err := store.Profile("profile 3")
+		require.NoError(t, err)
+		require.Equal(t, 1, profile.Losses)
+	})
+
+	t.Run("unrated match records statistics and keeps ratings", func(t *testing.T) {
+		// 1. Arrange
+		match, players, store := newFinishedMatch(t)
+
+		// 2. Act
+		match.updateProfiles(players[0])
+
+		// 3. Assert
+		profile, err := store.Profile("profile 1")
+		require.NoError(t, err)
+		require.Equal(t, "player 1", profile.Nickname)
+		require.Equal(t, 1, profile.Wins)
+		require.Zero(t, profile.Losses)
+		require.Equal(t, 4, profile.ShotsFired)
+		require.InDelta(t, 0.75, profile.Accuracy(), 0.01)
+		require.Equal(t, domain.DefaultRating, profile.Rating)
+	})
+
+	t.Run("players without a profile aren't recorded", func(t *testing.T) {
+		// 1. Arrange
+		match, players, store := newFinishedMatch(t)
+		players[1].profileID = ""
+
+		// 2. Act
+		match.updateProfiles(players[0])
+
+		// 3. Assert
+		require.Len(t, store.Profiles(), 2)
+	})
+}
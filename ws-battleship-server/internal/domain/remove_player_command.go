@@ -0,0 +1,13 @@
+package domain
+
+type RemovePlayerCommand struct {
+	player *Player
+}
+
+func NewRemovePlayerCommand(player *Player) *RemovePlayerCommand {
+	return &RemovePlayerCommand{player: player}
+}
+
+func (c *RemovePlayerCommand) Execute(executor CommandExecutor) error {
+	return executor.RemovePlayer(c.player)
+}
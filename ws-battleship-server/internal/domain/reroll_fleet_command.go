@@ -0,0 +1,13 @@
+package domain
+
+type RerollFleetCommand struct {
+	playerID string
+}
+
+func NewRerollFleetCommand(playerID string) *RerollFleetCommand {
+	return &RerollFleetCommand{playerID: playerID}
+}
+
+func (c *RerollFleetCommand) Execute(executor CommandExecutor) error {
+	return executor.RerollFleet(c.playerID)
+}
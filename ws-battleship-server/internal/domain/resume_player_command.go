@@ -0,0 +1,17 @@
+package domain
+
+import "ws-battleship-shared/pkg/logger"
+
+type ResumePlayerCommand struct {
+	logger logger.Logger
+	player *Player
+}
+
+func NewResumePlayerCommand(logger logger.Logger, player *Player) *ResumePlayerCommand {
+	return &ResumePlayerCommand{logger: logger, player: player}
+}
+
+func (c *ResumePlayerCommand) Execute(executor CommandExecutor) error {
+	c.logger.Infof("player %s is resuming the session...", c.player)
+	return executor.ResumePlayer(c.player)
+}
@@ -26,9 +26,10 @@ type Room struct {
 	messagesCh chan events.Event
 	closeCh    chan struct{}
 
-	id     string
-	cfg    *config.AppConfig
-	logger logger.Logger
+	id      string
+	cfg     *config.AppConfig
+	logger  logger.Logger
+	journal Journal
 
 	clientJoinedHandler func(websocket.Client)
 	clientLeftHandler   func(websocket.Client)
@@ -113,6 +114,33 @@ func (r *Room) JoinNewClient(joinedClient websocket.Client) error {
 	return nil
 }
 
+// RegisterClient registers the client right away. Unlike [Room.JoinNewClient], the joined
+// handler isn't called.
+func (r *Room) RegisterClient(client websocket.Client) error {
+	select {
+	case <-r.closeCh:
+		return ErrRoomIsClosed
+	default:
+	}
+	return r.registerNewClient(client)
+}
+
+// ResumeClient registers the client, which comes back to the room. The client is restored by
+// resume under the lock of the room, so goroutines of the room never see it half-restored.
+func (r *Room) ResumeClient(client websocket.Client, resume func()) error {
+	select {
+	case <-r.closeCh:
+		return ErrRoomIsClosed
+	default:
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resume()
+	return r.addClient(client)
+}
+
 func (r *Room) LeaveClient(client websocket.Client) {
 	select {
 	case <-r.closeCh:
@@ -136,10 +164,12 @@ func (r *Room) SendMessageToClient(clientID string, msg events.Event) error {
 		return ErrPlayerNotExist
 	}
 
+	r.record(clientID, msg)
 	return r.clients[clientID].SendMessage(msg)
 }
 
 func (r *Room) Broadcast(e events.Event) error {
+	r.record(events.JournalBroadcast, e)
 	for _, client := range r.GetClients() {
 		if err := client.SendMessage(e); err != nil {
 			return fmt.Errorf("failed to send a broadcast message to client id=%s", client.ID())
@@ -170,6 +200,11 @@ func (r *Room) SetClientLeftHandler(fn func(websocket.Client)) {
 	r.clientLeftHandler = fn
 }
 
+// SetJournal records all messages sent to clients of the room into the journal.
+func (r *Room) SetJournal(journal Journal) {
+	r.journal = journal
+}
+
 func (r *Room) handleConnections(ctx context.Context) {
 	for {
 		if err := ctx.Err(); err != nil {
@@ -226,23 +261,45 @@ func (r *Room) pingClients(ctx context.Context) {
 	}
 }
 
-func (r *Room) registerNewClient(newClient websocket.Client) error {
-	if r.IsFull() {
-		return ErrRoomIsFull
+func (r *Room) record(recipientID string, e events.Event) {
+	if r.journal == nil {
+		return
+	}
+
+	if err := r.journal.Record(recipientID, e); err != nil {
+		r.logger.Errorf("failed to record an event to the journal of room id=%s: %s", r.ID(), err)
 	}
+}
 
+func (r *Room) registerNewClient(newClient websocket.Client) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	return r.addClient(newClient)
+}
+
+// addClient must be called under the lock of the room.
+func (r *Room) addClient(newClient websocket.Client) error {
+	if len(r.clients) >= int(r.cfg.RoomCapacityMax) {
+		return ErrRoomIsFull
+	}
+
 	if _, found := r.clients[newClient.ID()]; found {
 		return ErrPlayerAlreadyInRoom
 	}
 
 	r.clients[newClient.ID()] = newClient
 
-	r.wg.Add(2)
+	r.wg.Add(3)
+	incomingCh := make(chan events.Event)
 	go func(wg *sync.WaitGroup, client websocket.Client) {
 		defer wg.Done()
-		client.ReadMessages(r.ctx, r.messagesCh)
+		defer close(incomingCh)
+		client.ReadMessages(r.ctx, incomingCh)
+	}(&r.wg, newClient)
+
+	go func(wg *sync.WaitGroup, client websocket.Client) {
+		defer wg.Done()
+		r.tagMessages(client.ID(), incomingCh)
 	}(&r.wg, newClient)
 
 	go func(wg *sync.WaitGroup, client websocket.Client) {
@@ -253,6 +310,18 @@ func (r *Room) registerNewClient(newClient websocket.Client) error {
 	return nil
 }
 
+// tagMessages passes events of the client to the room, each of them is tagged with the ID of the
+// client. Events are dropped, once the room is closing.
+func (r *Room) tagMessages(clientID string, incomingCh <-chan events.Event) {
+	for e := range incomingCh {
+		e.SenderID = clientID
+		select {
+		case <-r.closeCh:
+		case r.messagesCh <- e:
+		}
+	}
+}
+
 func (r *Room) unregisterClient(client websocket.Client) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
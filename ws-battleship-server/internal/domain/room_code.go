@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// roomCodeAlphabet skips letters and digits, which are easily confused, like O and 0.
+const (
+	roomCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	roomCodeLength   = 6
+)
+
+// NewRoomCode returns a short code, by which friends join the private room.
+func NewRoomCode() string {
+	buf := make([]byte, roomCodeLength)
+	_, _ = rand.Read(buf)
+
+	code := make([]byte, roomCodeLength)
+	for i, b := range buf {
+		code[i] = roomCodeAlphabet[int(b)%len(roomCodeAlphabet)]
+	}
+	return string(code)
+}
+
+// NormalizeRoomCode lets players type the code in any case.
+func NormalizeRoomCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -8,4 +8,6 @@ var (
 	ErrPlayerAlreadyInRoom = errors.New("player is already in room")
 	ErrPlayerNotExist      = errors.New("player doesn't exist")
 	ErrAlreadyStarted      = errors.New("already started")
+	ErrMatchNotExist       = errors.New("match doesn't exist")
+	ErrWrongRoomPassword   = errors.New("wrong password of the private room")
 )
@@ -1,10 +1,13 @@
 package domain
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 	"ws-battleship-server/internal/config"
 	"ws-battleship-server/internal/delivery/websocket"
+	"ws-battleship-shared/events"
 	"ws-battleship-shared/pkg/logger"
 
 	"github.com/stretchr/testify/mock"
@@ -298,6 +301,40 @@ func TestRegisterNewPlayer(t *testing.T) {
 	})
 }
 
+func TestRoomTagsMessages(t *testing.T) {
+	t.Run("event is tagged with the connection it arrived on", func(t *testing.T) {
+		// 1. Arrange
+		spoofedEvent, err := events.NewPlayerFireEvent(events.FireCommandArgs{FiringPlayerID: "456"})
+		require.NoError(t, err)
+		spoofedEvent.SenderID = "456"
+
+		mockClient := new(websocket.MockClient)
+		mockClient.On("Close").Return(nil)
+		mockClient.On("ID").Return("123")
+		mockClient.On("WriteMessages", mock.Anything).Return()
+		mockClient.On("ReadMessages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(chan<- events.Event) <- spoofedEvent
+		}).Return()
+
+		room := NewRoom(t.Context(), &config.AppConfig{
+			RoomCapacityMax: 5,
+			KeepAlivePeriod: time.Second * 5,
+		}, nil)
+
+		// 2. Act
+		require.NoError(t, room.registerNewClient(mockClient))
+
+		// 3. Assert
+		select {
+		case e := <-room.Events():
+			require.Equal(t, "123", e.SenderID)
+			require.Equal(t, events.PlayerFireEventType, e.Type)
+		case <-time.After(time.Second):
+			require.Fail(t, "event wasn't passed to the room")
+		}
+	})
+}
+
 func TestUnregisterPlayer(t *testing.T) {
 	t.Run("unregister 1 player", func(t *testing.T) {
 		// 1. Arrange
@@ -342,3 +379,42 @@ func TestUnregisterPlayer(t *testing.T) {
 		require.Zerof(t, room.Capacity(), "there should be 0 players")
 	})
 }
+
+func TestRoomJournal(t *testing.T) {
+	t.Run("sent messages are recorded with their recipients", func(t *testing.T) {
+		// 1. Arrange
+		mockClient := new(websocket.MockClient)
+		mockClient.On("ID").Return("123").Maybe()
+		mockClient.On("SendMessage", mock.Anything).Return(nil)
+
+		dir := t.TempDir()
+		journal, err := NewFileJournal(dir, "match")
+		require.NoError(t, err)
+
+		room := Room{clients: map[string]websocket.Client{"123": mockClient}}
+		room.SetJournal(journal)
+
+		gameStartEvent, err := events.NewGameStartEvent(1)
+		require.NoError(t, err)
+		sessionEvent, err := events.NewSessionEvent("secret")
+		require.NoError(t, err)
+
+		// 2. Act
+		require.NoError(t, room.Broadcast(gameStartEvent))
+		require.NoError(t, room.SendMessageToClient("123", sessionEvent))
+		require.NoError(t, room.SendMessageToClient("123", gameStartEvent))
+		require.NoError(t, journal.Close())
+
+		// 3. Assert
+		file, err := os.Open(filepath.Join(dir, "match.jsonl"))
+		require.NoError(t, err)
+		defer file.Close()
+
+		entries, err := events.ReadJournal(file)
+		require.NoError(t, err)
+		require.Len(t, entries, 2, "resume tokens must not be recorded")
+		require.Equal(t, events.JournalBroadcast, entries[0].RecipientID)
+		require.Equal(t, "123", entries[1].RecipientID)
+		require.Equal(t, events.GameStartEventType, entries[1].Event.Type)
+	})
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidSessionToken = errors.New("session token is missing or invalid")
+	ErrSessionExpired      = errors.New("session token is expired")
+	ErrSessionInUse        = errors.New("client already has a live session")
+)
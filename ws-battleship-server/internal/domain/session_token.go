@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+	"ws-battleship-shared/domain"
+
+	"github.com/google/uuid"
+)
+
+// sessionSecretLength is used for the random secret, if none is configured.
+const sessionSecretLength = 32
+
+// SessionClaims are signed into the token. The server trusts them instead of headers of the client.
+type SessionClaims struct {
+	ClientID  domain.ClientID `json:"sub"`
+	Nickname  string          `json:"nickname"`
+	ProfileID string          `json:"profile_id,omitempty"`
+	ExpiresAt time.Time       `json:"exp"`
+}
+
+// SessionTokens issues and verifies session tokens. The token is the claims in JSON and their
+// HMAC-SHA256 signature, both encoded in URL-safe base64 and separated by a dot.
+type SessionTokens struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewSessionTokens signs tokens with the secret. A random secret is generated, if it's empty,
+// so tokens are valid until the restart of the server only.
+func NewSessionTokens(secret string, ttl time.Duration) *SessionTokens {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, sessionSecretLength)
+		_, _ = rand.Read(key)
+	}
+
+	return &SessionTokens{
+		secret: key,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Issue starts a new guest session with a new client ID.
+func (s *SessionTokens) Issue(nickname, profileID string) (domain.SessionToken, error) {
+	return s.sign(SessionClaims{
+		ClientID:  uuid.New().String(),
+		Nickname:  nickname,
+		ProfileID: profileID,
+	})
+}
+
+// Refresh extends the session, which isn't expired yet. The client keeps its ID, so a dropped
+// session can still be resumed.
+func (s *SessionTokens) Refresh(token string) (domain.SessionToken, error) {
+	claims, err := s.Verify(token)
+	if err != nil {
+		return domain.SessionToken{}, err
+	}
+	return s.sign(claims)
+}
+
+// Verify checks the signature and the expiry of the token.
+func (s *SessionTokens) Verify(token string) (SessionClaims, error) {
+	payload, signature, found := strings.Cut(token, ".")
+	if !found {
+		return SessionClaims{}, ErrInvalidSessionToken
+	}
+
+	wantSignature, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(wantSignature, s.signature(payload)) {
+		return SessionClaims{}, ErrInvalidSessionToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return SessionClaims{}, ErrInvalidSessionToken
+	}
+
+	var claims SessionClaims
+	if err := json.Unmarshal(data, &claims); err != nil || claims.ClientID == "" {
+		return SessionClaims{}, ErrInvalidSessionToken
+	}
+
+	if !s.now().Before(claims.ExpiresAt) {
+		return SessionClaims{}, ErrSessionExpired
+	}
+	return claims, nil
+}
+
+func (s *SessionTokens) sign(claims SessionClaims) (domain.SessionToken, error) {
+	claims.ExpiresAt = s.now().Add(s.ttl).UTC()
+
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return domain.SessionToken{}, err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return domain.SessionToken{
+		Token:     payload + "." + base64.RawURLEncoding.EncodeToString(s.signature(payload)),
+		ClientID:  claims.ClientID,
+		Nickname:  claims.Nickname,
+		ExpiresAt: claims.ExpiresAt,
+	}, nil
+}
+
+func (s *SessionTokens) signature(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
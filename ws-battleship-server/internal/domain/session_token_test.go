@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionTokens(t *testing.T) {
+	t.Run("issued token is verified", func(t *testing.T) {
+		// 1. Arrange
+		tokens := NewSessionTokens("secret", time.Minute)
+
+		// 2. Act
+		token, err := tokens.Issue("player", "profile")
+		require.NoError(t, err)
+		claims, err := tokens.Verify(token.Token)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.NotEmpty(t, claims.ClientID)
+		require.Equal(t, token.ClientID, claims.ClientID)
+		require.Equal(t, "player", claims.Nickname)
+		require.Equal(t, "profile", claims.ProfileID)
+	})
+
+	t.Run("every guest gets its own client ID", func(t *testing.T) {
+		// 1. Arrange
+		tokens := NewSessionTokens("secret", time.Minute)
+
+		// 2. Act
+		lhs, lhsErr := tokens.Issue("player", "")
+		rhs, rhsErr := tokens.Issue("player", "")
+
+		// 3. Assert
+		require.NoError(t, lhsErr)
+		require.NoError(t, rhsErr)
+		require.NotEqual(t, lhs.ClientID, rhs.ClientID)
+	})
+
+	t.Run("forged tokens are refused", func(t *testing.T) {
+		// 1. Arrange
+		tokens := NewSessionTokens("secret", time.Minute)
+		token, err := tokens.Issue("player", "")
+		require.NoError(t, err)
+
+		payload, signature, _ := strings.Cut(token.Token, ".")
+		otherToken, err := NewSessionTokens("other secret", time.Minute).Issue("player", "")
+		require.NoError(t, err)
+		otherPayload, _, _ := strings.Cut(otherToken.Token, ".")
+
+		for _, forged := range []string{
+			"",
+			payload,
+			payload + ".",
+			otherToken.Token,
+			otherPayload + "." + signature,
+		} {
+			// 2. Act
+			_, err := tokens.Verify(forged)
+
+			// 3. Assert
+			require.ErrorIsf(t, err, ErrInvalidSessionToken, "token %q", forged)
+		}
+	})
+
+	t.Run("expired token is refused and can't be refreshed", func(t *testing.T) {
+		// 1. Arrange
+		tokens := NewSessionTokens("secret", time.Minute)
+		token, err := tokens.Issue("player", "")
+		require.NoError(t, err)
+		tokens.now = func() time.Time { return time.Now().Add(time.Minute) }
+
+		// 2. Act
+		_, verifyErr := tokens.Verify(token.Token)
+		_, refreshErr := tokens.Refresh(token.Token)
+
+		// 3. Assert
+		require.ErrorIs(t, verifyErr, ErrSessionExpired)
+		require.ErrorIs(t, refreshErr, ErrSessionExpired)
+	})
+
+	t.Run("refreshed token keeps the client ID", func(t *testing.T) {
+		// 1. Arrange
+		tokens := NewSessionTokens("secret", time.Minute)
+		token, err := tokens.Issue("player", "")
+		require.NoError(t, err)
+		tokens.now = func() time.Time { return time.Now().Add(30 * time.Second) }
+
+		// 2. Act
+		refreshed, err := tokens.Refresh(token.Token)
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Equal(t, token.ClientID, refreshed.ClientID)
+		require.True(t, refreshed.ExpiresAt.After(token.ExpiresAt))
+	})
+}
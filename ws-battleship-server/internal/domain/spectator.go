@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"fmt"
+	"ws-battleship-server/internal/delivery/websocket"
+	"ws-battleship-shared/domain"
+)
+
+// Spectator watches the match without taking part in it.
+type Spectator struct {
+	websocket.Client
+	nickname string
+	matchID  string
+}
+
+func NewSpectator(client websocket.Client, metadata domain.ClientMetadata) *Spectator {
+	return &Spectator{
+		Client:   client,
+		nickname: metadata.Nickname,
+		matchID:  metadata.MatchID,
+	}
+}
+
+func (s *Spectator) String() string {
+	return fmt.Sprintf(`'%s' [%s]`, s.nickname, s.ID())
+}
+
+func (s *Spectator) Nickname() string {
+	return s.nickname
+}
+
+// MatchID returns the ID of the match the spectator wants to watch.
+func (s *Spectator) MatchID() string {
+	return s.matchID
+}
@@ -0,0 +1,11 @@
+package domain
+
+type TurnTimeoutCommand struct{}
+
+func NewTurnTimeoutCommand() *TurnTimeoutCommand {
+	return &TurnTimeoutCommand{}
+}
+
+func (c *TurnTimeoutCommand) Execute(executor CommandExecutor) error {
+	return executor.TimeoutTurn()
+}
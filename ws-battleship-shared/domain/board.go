@@ -3,10 +3,11 @@ package domain
 import (
 	"bytes"
 	"fmt"
+	"slices"
 )
 
 const (
-	boardAlphabet = "abcdefghij"
+	boardAlphabet = "abcdefghijklmnopqrstuvwxyz"
 )
 
 type CellType = rune
@@ -17,13 +18,35 @@ const (
 	Dead  CellType = '□'
 	Ship  CellType = '■'
 	Miss  CellType = '∙'
+	Sunk  CellType = '▣'
 )
 
 type Cell = rune
 
-type Board [len(boardAlphabet)][len(boardAlphabet)]Cell
+// Board is a square grid of cells. Its size is chosen per match, see GameRules.
+type Board [][]Cell
 
-func (b *Board) IsCellDead(cellX, cellY byte) bool {
+func NewBoard(size int) Board {
+	board := make(Board, size)
+	for i := range board {
+		board[i] = make([]Cell, size)
+	}
+	return board
+}
+
+func (b Board) Clone() Board {
+	if b == nil {
+		return nil
+	}
+
+	board := make(Board, len(b))
+	for i := range b {
+		board[i] = slices.Clone(b[i])
+	}
+	return board
+}
+
+func (b Board) IsCellDead(cellX, cellY byte) bool {
 	cellType := b.GetCellType(cellX, cellY)
 	if cellType == Null {
 		return false
@@ -31,7 +54,7 @@ func (b *Board) IsCellDead(cellX, cellY byte) bool {
 	return cellType == Dead
 }
 
-func (b *Board) IsCellEmpty(cellX, cellY byte) bool {
+func (b Board) IsCellEmpty(cellX, cellY byte) bool {
 	cellType := b.GetCellType(cellX, cellY)
 	if cellType == Null {
 		return true
@@ -39,35 +62,35 @@ func (b *Board) IsCellEmpty(cellX, cellY byte) bool {
 	return cellType == Empty
 }
 
-func (b *Board) GetCellType(cellX, cellY byte) CellType {
+func (b Board) GetCellType(cellX, cellY byte) CellType {
 	if b.checkBounds(cellX, cellY) {
 		return b[cellY][cellX]
 	}
 	return Null
 }
 
-func (b *Board) SetCell(cellX, cellY byte, cellType CellType) {
+func (b Board) SetCell(cellX, cellY byte, cellType CellType) {
 	if b.checkBounds(cellX, cellY) {
 		b[cellY][cellX] = cellType
 	}
 }
 
-func (b *Board) CellString(cellX, cellY byte) string {
+func (b Board) CellString(cellX, cellY byte) string {
 	if b.checkBounds(cellX, cellY) {
 		return fmt.Sprintf("%c%d", boardAlphabet[cellX], cellY+1)
 	}
 	return ""
 }
 
-func (b *Board) Size() int {
+func (b Board) Size() int {
 	return len(b)
 }
 
-func (b *Board) Alphabet() []rune {
-	return []rune(boardAlphabet)
+func (b Board) Alphabet() []rune {
+	return []rune(boardAlphabet[:min(b.Size(), len(boardAlphabet))])
 }
 
-func (b *Board) Lines() []string {
+func (b Board) Lines() []string {
 	result := make([]string, b.Size())
 
 	for i := 0; i < b.Size(); i++ {
@@ -76,13 +99,18 @@ func (b *Board) Lines() []string {
 	return result
 }
 
+// MarshalBinary writes the board size as the first rune followed by all cells row by row.
 func (b *Board) MarshalBinary() ([]byte, error) {
 	buf := new(bytes.Buffer)
-	buf.Grow(b.Size() * 2)
+	buf.Grow((b.Size()*b.Size() + 1) * 2)
+
+	if _, err := buf.WriteRune(rune(b.Size())); err != nil {
+		return nil, err
+	}
 
 	for i := 0; i < b.Size(); i++ {
 		for j := 0; j < b.Size(); j++ {
-			if _, err := buf.WriteRune(b[i][j]); err != nil {
+			if _, err := buf.WriteRune(b.GetCellType(byte(j), byte(i))); err != nil {
 				return nil, err
 			}
 		}
@@ -93,7 +121,15 @@ func (b *Board) MarshalBinary() ([]byte, error) {
 func (b *Board) UnmarshalBinary(buf []byte) error {
 	buffer := bytes.NewBuffer(buf)
 
-	var board Board
+	size, _, err := buffer.ReadRune()
+	if err != nil {
+		return err
+	}
+	if int(size) > len(boardAlphabet) {
+		return fmt.Errorf("board size %d exceeds the maximum of %d", size, len(boardAlphabet))
+	}
+
+	board := NewBoard(int(size))
 	for i := 0; i < board.Size(); i++ {
 		for j := 0; j < board.Size(); j++ {
 			r, _, err := buffer.ReadRune()
@@ -103,22 +139,24 @@ func (b *Board) UnmarshalBinary(buf []byte) error {
 			board[i][j] = r
 		}
 	}
+
+	*b = board
 	return nil
 }
 
-func (b *Board) checkBounds(cellX, cellY byte) bool {
-	return cellX < byte(b.Size()) && cellY < byte(b.Size())
+func (b Board) checkBounds(cellX, cellY byte) bool {
+	return int(cellY) < len(b) && int(cellX) < len(b[cellY])
 }
 
-func (b *Board) renderRow(rowIdx int) string {
+func (b Board) renderRow(rowIdx int) string {
 	if rowIdx >= b.Size() {
 		return ""
 	}
 
-	row := make([]rune, 0, b.Size())
+	row := make([]rune, 0, b.Size()*2)
 	for colIdx := 0; colIdx < b.Size(); colIdx++ {
-		cell := b[rowIdx][colIdx]
-		if cell == 0 {
+		cell := b.GetCellType(byte(colIdx), byte(rowIdx))
+		if cell == Null {
 			cell = Empty
 		}
 		row = append(row, rune(cell))
@@ -77,16 +77,17 @@ func TestBoardSize(t *testing.T) {
 }
 
 func TestAlphabet(t *testing.T) {
-	t.Run("check board's alphabet equals const variable", func(t *testing.T) {
-		var b Board
-		require.Equal(t, boardAlphabet, string(b.Alphabet()))
+	t.Run("check board's alphabet scales with its size", func(t *testing.T) {
+		require.Equal(t, "abcdefghij", string(NewBoard(DefaultBoardSize).Alphabet()))
+		require.Equal(t, "abcdefghijklmno", string(NewBoard(15).Alphabet()))
 	})
 }
 
 func TestBoardLines(t *testing.T) {
 	t.Run("board's lines", func(t *testing.T) {
 		// 1. Arrange
-		var b = Board{
+		b := NewBoard(DefaultBoardSize)
+		copy(b, Board{
 			{Miss, Miss, Miss, Miss, Miss, Miss, Miss, Miss, Miss, Miss},           // 1
 			{Dead, Empty, Dead, Empty, Dead, Empty, Dead, Empty, Dead, Empty},      // 2
 			{Ship, Dead, Ship, Dead, Ship, Dead, Ship, Dead, Ship, Dead},           // 3
@@ -97,7 +98,7 @@ func TestBoardLines(t *testing.T) {
 			// 8 - Empty
 			// 9 - Empty
 			// 10 - Empty
-		}
+		})
 
 		// 2. Act
 		got := b.Lines()
@@ -121,11 +122,12 @@ func TestBoardLines(t *testing.T) {
 
 func TestRenderBoardRow(t *testing.T) {
 	// 1. Arrange
-	var b = Board{
+	b := NewBoard(DefaultBoardSize)
+	copy(b, Board{
 		{Miss, Miss, Empty, Empty, Ship, Ship, Dead, Empty, Miss, Empty},
 		{Empty, Empty, Empty, Empty, Empty, Empty, Empty, Empty, Empty, Empty},
 		{Dead, Dead, Dead, Dead, Dead, Dead, Dead, Dead, Dead, Dead},
-	}
+	})
 
 	tests := []struct {
 		name     string
@@ -173,7 +175,7 @@ func TestRenderBoardRow(t *testing.T) {
 func TestBoardMarshalBinary(t *testing.T) {
 	t.Run("no error when marshal an empty board", func(t *testing.T) {
 		// 1. Arrange
-		var b Board
+		b := NewBoard(DefaultBoardSize)
 
 		// 2. Act
 		got, err := b.MarshalBinary()
@@ -184,3 +186,25 @@ func TestBoardMarshalBinary(t *testing.T) {
 		require.NotZero(t, len(got))
 	})
 }
+
+func TestBoardUnmarshalBinary(t *testing.T) {
+	t.Run("board of any size survives a round trip", func(t *testing.T) {
+		for _, size := range []int{MinBoardSize, DefaultBoardSize, 15, MaxBoardSize} {
+			// 1. Arrange
+			b := NewBoard(size)
+			b.SetCell(0, 0, Ship)
+			b.SetCell(byte(size-1), byte(size-1), Miss)
+
+			data, err := b.MarshalBinary()
+			require.NoError(t, err)
+
+			// 2. Act
+			var got Board
+			err = got.UnmarshalBinary(data)
+
+			// 3. Assert
+			require.NoError(t, err)
+			require.Equal(t, b, got)
+		}
+	})
+}
@@ -1,21 +1,39 @@
 package domain
 
-import "math/rand"
+const (
+	placementAttempts  = 1000
+	validationAttempts = 100
+	// validationSeed makes the validation of the same rules give the same answer every time.
+	validationSeed = 0
+)
 
-func RandomizeBoard() Board {
-	ships := [...]int{4, 3, 3, 2, 2, 2, 1, 1, 1, 1}
+// RandomizeBoard places the fleet at random. Rules must be validated beforehand, otherwise
+// it may never return.
+func RandomizeBoard(rules GameRules) Board {
+	return RandomizeBoardWithRNG(globalRNG{}, rules)
+}
 
+// RandomizeBoardWithRNG places the fleet like [RandomizeBoard], but draws from the given source.
+func RandomizeBoardWithRNG(rng RNG, rules GameRules) Board {
 	for {
-		var board Board
+		if board, ok := randomizeBoard(rng, rules, 1); ok {
+			return board
+		}
+	}
+}
+
+func randomizeBoard(rng RNG, rules GameRules, attempts int) (Board, bool) {
+	for ; attempts > 0; attempts-- {
+		board := NewBoard(rules.BoardSize)
 		ok := true
 
-		for _, size := range ships {
+		for _, size := range rules.Fleet {
 			placed := false
 
-			for attempts := 0; attempts < 1000; attempts++ {
-				x := rand.Intn(board.Size())
-				y := rand.Intn(board.Size())
-				horizontal := rand.Intn(2) == 0
+			for i := 0; i < placementAttempts; i++ {
+				x := rng.Intn(board.Size())
+				y := rng.Intn(board.Size())
+				horizontal := rng.Intn(2) == 0
 
 				if canPlace(&board, x, y, size, horizontal) {
 					placeShip(&board, x, y, size, horizontal)
@@ -31,9 +49,10 @@ func RandomizeBoard() Board {
 		}
 
 		if ok {
-			return board
+			return board, true
 		}
 	}
+	return nil, false
 }
 
 func canPlace(
@@ -60,7 +79,7 @@ func canPlace(
 				cy := ny + dy
 
 				if cx >= 0 && cx < board.Size() && cy >= 0 && cy < board.Size() {
-					if board[cx][cy] == Ship {
+					if board.GetCellType(byte(cy), byte(cx)) == Ship {
 						return false
 					}
 				}
@@ -78,9 +97,9 @@ func placeShip(
 ) {
 	for i := 0; i < length; i++ {
 		if horizontal {
-			board[x][y+i] = Ship
+			board.SetCell(byte(y+i), byte(x), Ship)
 		} else {
-			board[x+i][y] = Ship
+			board.SetCell(byte(y), byte(x+i), Ship)
 		}
 	}
 }
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrUnknownBotDifficulty = errors.New("unknown bot difficulty")
+)
+
+// BotStrategy picks cells to fire at. The board of the target is masked, so unknown cells
+// are empty, hit cells are dead and cells of sunk ships are sunk.
+type BotStrategy interface {
+	NextShots(rng RNG, board Board, afloat []int, shots int) []Coordinate
+}
+
+func NewBotStrategy(difficulty BotDifficulty) (BotStrategy, error) {
+	switch difficulty {
+	case EasyBot:
+		return RandomStrategy{}, nil
+	case MediumBot:
+		return HuntTargetStrategy{}, nil
+	case HardBot:
+		return ProbabilityStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBotDifficulty, difficulty)
+	}
+}
+
+// RandomStrategy fires at unknown cells at random.
+type RandomStrategy struct{}
+
+func (RandomStrategy) NextShots(rng RNG, board Board, afloat []int, shots int) []Coordinate {
+	cells := unknownCells(board)
+	rng.Shuffle(len(cells), func(i, j int) {
+		cells[i], cells[j] = cells[j], cells[i]
+	})
+	return cells[:min(shots, len(cells))]
+}
+
+// HuntTargetStrategy hunts on a checkerboard, since every ship longer than one cell covers one
+// of its cells. After a hit it probes neighbors of the wounded ship until it's sunk.
+type HuntTargetStrategy struct{}
+
+func (HuntTargetStrategy) NextShots(rng RNG, board Board, afloat []int, shots int) []Coordinate {
+	targets := TargetCells(board)
+	rng.Shuffle(len(targets), func(i, j int) {
+		targets[i], targets[j] = targets[j], targets[i]
+	})
+
+	var hunt, rest []Coordinate
+	for _, cell := range unknownCells(board) {
+		if slices.Contains(targets, cell) {
+			continue
+		}
+
+		if (cell.X+cell.Y)%2 == 0 {
+			hunt = append(hunt, cell)
+		} else {
+			rest = append(rest, cell)
+		}
+	}
+	rng.Shuffle(len(hunt), func(i, j int) {
+		hunt[i], hunt[j] = hunt[j], hunt[i]
+	})
+	rng.Shuffle(len(rest), func(i, j int) {
+		rest[i], rest[j] = rest[j], rest[i]
+	})
+
+	cells := slices.Concat(targets, hunt, rest)
+	return cells[:min(shots, len(cells))]
+}
+
+// ProbabilityStrategy counts, how many placements of the ships afloat cover each unknown cell,
+// and fires at the most likely ones. Placements through hit cells weigh much more, so wounded
+// ships are finished first.
+type ProbabilityStrategy struct{}
+
+func (ProbabilityStrategy) NextShots(rng RNG, board Board, afloat []int, shots int) []Coordinate {
+	const hitWeight = 20
+
+	size := board.Size()
+	density := make(map[Coordinate]int)
+	for _, length := range afloat {
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				for _, horizontal := range []bool{true, false} {
+					placement := ShipPlacement{X: byte(x), Y: byte(y), Length: length, Horizontal: horizontal}
+					if length == 1 && !horizontal {
+						continue
+					}
+
+					cells := placement.Cells()
+					hits, ok := 0, true
+					for _, cell := range cells {
+						if int(cell.X) >= size || int(cell.Y) >= size {
+							ok = false
+							break
+						}
+
+						switch board.GetCellType(cell.X, cell.Y) {
+						case Null, Empty:
+						case Dead:
+							hits++
+						default:
+							ok = false
+						}
+					}
+					if !ok {
+						continue
+					}
+
+					for _, cell := range cells {
+						if board.IsCellEmpty(cell.X, cell.Y) {
+							density[cell] += 1 + hits*hitWeight
+						}
+					}
+				}
+			}
+		}
+	}
+
+	cells := unknownCells(board)
+	rng.Shuffle(len(cells), func(i, j int) {
+		cells[i], cells[j] = cells[j], cells[i]
+	})
+	slices.SortStableFunc(cells, func(lhs, rhs Coordinate) int {
+		return density[rhs] - density[lhs]
+	})
+	return cells[:min(shots, len(cells))]
+}
+
+func unknownCells(board Board) []Coordinate {
+	var cells []Coordinate
+	for y := 0; y < board.Size(); y++ {
+		for x := 0; x < board.Size(); x++ {
+			if board.IsCellEmpty(byte(x), byte(y)) {
+				cells = append(cells, Coordinate{X: byte(x), Y: byte(y)})
+			}
+		}
+	}
+	return cells
+}
+
+// TargetCells returns unknown cells next to wounded ships. If a ship is hit more than once,
+// it's known to lie along the line of hits.
+func TargetCells(board Board) []Coordinate {
+	var targets []Coordinate
+	for _, cell := range unknownCells(board) {
+		x, y := cell.X, cell.Y
+		horizontal := board.IsCellDead(x-1, y) || board.IsCellDead(x+1, y)
+		vertical := board.IsCellDead(x, y-1) || board.IsCellDead(x, y+1)
+		if !horizontal && !vertical {
+			continue
+		}
+
+		// A neighbor across the line of hits can't belong to the same ship.
+		if horizontal && !vertical && isLineOfHits(board, x, y, false) {
+			continue
+		}
+		if vertical && !horizontal && isLineOfHits(board, x, y, true) {
+			continue
+		}
+		targets = append(targets, cell)
+	}
+	return targets
+}
+
+// isLineOfHits reports whether the wounded neighbor of the cell is a part of a line of hits,
+// which runs across the given direction.
+func isLineOfHits(board Board, x, y byte, horizontal bool) bool {
+	for _, neighbor := range []Coordinate{{X: x - 1, Y: y}, {X: x + 1, Y: y}, {X: x, Y: y - 1}, {X: x, Y: y + 1}} {
+		if !board.IsCellDead(neighbor.X, neighbor.Y) {
+			continue
+		}
+
+		if horizontal {
+			if board.IsCellDead(neighbor.X-1, neighbor.Y) || board.IsCellDead(neighbor.X+1, neighbor.Y) {
+				return true
+			}
+		} else if board.IsCellDead(neighbor.X, neighbor.Y-1) || board.IsCellDead(neighbor.X, neighbor.Y+1) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBotStrategies(t *testing.T) {
+	// A wounded ship lies horizontally through b3 and c3.
+	woundedBoard := NewBoard(DefaultBoardSize)
+	woundedBoard.SetCell(0, 0, Miss)
+	woundedBoard.SetCell(1, 2, Dead)
+	woundedBoard.SetCell(2, 2, Dead)
+
+	for _, tt := range []struct {
+		name     string
+		strategy BotStrategy
+		board    Board
+		shots    int
+		isValid  func(cell Coordinate) bool
+	}{
+		{
+			name:     "random strategy fires at unknown cells",
+			strategy: RandomStrategy{},
+			board:    woundedBoard,
+			shots:    3,
+			isValid: func(cell Coordinate) bool {
+				return woundedBoard.IsCellEmpty(cell.X, cell.Y)
+			},
+		},
+		{
+			name:     "hunt/target strategy finishes the wounded ship along its line",
+			strategy: HuntTargetStrategy{},
+			board:    woundedBoard,
+			shots:    2,
+			isValid: func(cell Coordinate) bool {
+				return cell == Coordinate{X: 0, Y: 2} || cell == Coordinate{X: 3, Y: 2}
+			},
+		},
+		{
+			name:     "probability strategy finishes the wounded ship along its line",
+			strategy: ProbabilityStrategy{},
+			board:    woundedBoard,
+			shots:    1,
+			isValid: func(cell Coordinate) bool {
+				return cell == Coordinate{X: 0, Y: 2} || cell == Coordinate{X: 3, Y: 2}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Act
+			got := tt.strategy.NextShots(NewRNG(1), tt.board, DefaultFleet, tt.shots)
+
+			// 2. Assert
+			require.Len(t, got, tt.shots)
+			for _, cell := range got {
+				require.Truef(t, tt.isValid(cell), "unexpected shot at %+v", cell)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+)
+
+var (
+	ErrInvalidFleet     = errors.New("fleet doesn't match the required composition")
+	ErrInvalidPlacement = errors.New("ship is out of bounds or touches another ship")
+)
+
+// DefaultFleet is a classic fleet: one battleship, two cruisers, three destroyers and four submarines.
+var DefaultFleet = []int{4, 3, 3, 2, 2, 2, 1, 1, 1, 1}
+
+type Coordinate struct {
+	X byte `json:"x"`
+	Y byte `json:"y"`
+}
+
+type ShipPlacement struct {
+	X          byte `json:"x"`
+	Y          byte `json:"y"`
+	Length     int  `json:"length"`
+	Horizontal bool `json:"horizontal"`
+}
+
+func (p ShipPlacement) Cells() []Coordinate {
+	cells := make([]Coordinate, 0, p.Length)
+	for i := 0; i < p.Length; i++ {
+		if p.Horizontal {
+			cells = append(cells, Coordinate{X: p.X + byte(i), Y: p.Y})
+		} else {
+			cells = append(cells, Coordinate{X: p.X, Y: p.Y + byte(i)})
+		}
+	}
+	return cells
+}
+
+func CanPlaceShip(board *Board, placement ShipPlacement) bool {
+	if placement.Length <= 0 {
+		return false
+	}
+	return canPlace(board, int(placement.Y), int(placement.X), placement.Length, placement.Horizontal)
+}
+
+func PlaceShip(board *Board, placement ShipPlacement) bool {
+	if !CanPlaceShip(board, placement) {
+		return false
+	}
+	placeShip(board, int(placement.Y), int(placement.X), placement.Length, placement.Horizontal)
+	return true
+}
+
+// BuildBoard places all ships on a new board and checks that they form exactly the fleet
+// of the rules and don't break the no-touch rule.
+func BuildBoard(placements []ShipPlacement, rules GameRules) (Board, error) {
+	board := NewBoard(rules.BoardSize)
+
+	lengths := make([]int, 0, len(placements))
+	for _, placement := range placements {
+		lengths = append(lengths, placement.Length)
+	}
+
+	expected := slices.Clone(rules.Fleet)
+	slices.Sort(expected)
+	slices.Sort(lengths)
+	if !slices.Equal(expected, lengths) {
+		return board, ErrInvalidFleet
+	}
+
+	for _, placement := range placements {
+		if !PlaceShip(&board, placement) {
+			return board, ErrInvalidPlacement
+		}
+	}
+	return board, nil
+}
+
+// ExtractShips finds all ships on the board. Alive, dead and sunk ship cells are considered.
+func ExtractShips(board Board) []ShipPlacement {
+	isShipCell := func(x, y int) bool {
+		if x < 0 || y < 0 {
+			return false
+		}
+		cellType := board.GetCellType(byte(x), byte(y))
+		return cellType == Ship || cellType == Dead || cellType == Sunk
+	}
+
+	var ships []ShipPlacement
+	for y := range board {
+		for x := range board[y] {
+			// Skip cells that are not the beginning of a ship.
+			if !isShipCell(x, y) || isShipCell(x-1, y) || isShipCell(x, y-1) {
+				continue
+			}
+
+			placement := ShipPlacement{X: byte(x), Y: byte(y), Length: 1, Horizontal: true}
+			switch {
+			case isShipCell(x+1, y):
+				for isShipCell(x+placement.Length, y) {
+					placement.Length++
+				}
+			case isShipCell(x, y+1):
+				placement.Horizontal = false
+				for isShipCell(x, y+placement.Length) {
+					placement.Length++
+				}
+			}
+			ships = append(ships, placement)
+		}
+	}
+	return ships
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildBoard(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		placements  []ShipPlacement
+		fleet       []int
+		expectedErr error
+	}{
+		{
+			name: "valid fleet",
+			placements: []ShipPlacement{
+				{X: 0, Y: 0, Length: 3, Horizontal: true},
+				{X: 0, Y: 2, Length: 2, Horizontal: false},
+				{X: 9, Y: 9, Length: 1},
+			},
+			fleet: []int{1, 2, 3},
+		},
+		{
+			name: "missing ship",
+			placements: []ShipPlacement{
+				{X: 0, Y: 0, Length: 3, Horizontal: true},
+				{X: 9, Y: 9, Length: 1},
+			},
+			fleet:       []int{1, 2, 3},
+			expectedErr: ErrInvalidFleet,
+		},
+		{
+			name: "extra ship",
+			placements: []ShipPlacement{
+				{X: 0, Y: 0, Length: 1},
+				{X: 9, Y: 9, Length: 1},
+			},
+			fleet:       []int{1},
+			expectedErr: ErrInvalidFleet,
+		},
+		{
+			name: "ships touch each other diagonally",
+			placements: []ShipPlacement{
+				{X: 0, Y: 0, Length: 2, Horizontal: true},
+				{X: 2, Y: 1, Length: 1},
+			},
+			fleet:       []int{1, 2},
+			expectedErr: ErrInvalidPlacement,
+		},
+		{
+			name: "ships overlap",
+			placements: []ShipPlacement{
+				{X: 0, Y: 0, Length: 2, Horizontal: true},
+				{X: 1, Y: 0, Length: 2, Horizontal: false},
+			},
+			fleet:       []int{2, 2},
+			expectedErr: ErrInvalidPlacement,
+		},
+		{
+			name: "ship is out of bounds",
+			placements: []ShipPlacement{
+				{X: 8, Y: 0, Length: 3, Horizontal: true},
+			},
+			fleet:       []int{3},
+			expectedErr: ErrInvalidPlacement,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Act
+			board, err := BuildBoard(tt.placements, GameRules{BoardSize: DefaultBoardSize, Fleet: tt.fleet})
+
+			// 2. Assert
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			for _, placement := range tt.placements {
+				for _, cell := range placement.Cells() {
+					require.Equal(t, Ship, board.GetCellType(cell.X, cell.Y))
+				}
+			}
+		})
+	}
+}
+
+func TestExtractShips(t *testing.T) {
+	t.Run("extracted ships rebuild the same board", func(t *testing.T) {
+		// 1. Arrange
+		board := RandomizeBoard(DefaultRules())
+
+		// 2. Act
+		ships := ExtractShips(board)
+
+		// 3. Assert
+		require.Len(t, ships, len(DefaultFleet))
+
+		rebuilt, err := BuildBoard(ships, DefaultRules())
+		require.NoError(t, err)
+		require.Equal(t, board, rebuilt)
+	})
+
+	t.Run("dead cells are still part of a ship", func(t *testing.T) {
+		// 1. Arrange
+		board := Board{
+			{Ship, Dead, Ship, Empty, Miss},
+			{Empty, Empty, Empty, Empty, Dead},
+		}
+
+		// 2. Act
+		ships := ExtractShips(board)
+
+		// 3. Assert
+		require.Equal(t, []ShipPlacement{
+			{X: 0, Y: 0, Length: 3, Horizontal: true},
+			{X: 4, Y: 1, Length: 1, Horizontal: true},
+		}, ships)
+	})
+}
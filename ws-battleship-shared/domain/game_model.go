@@ -1,6 +1,20 @@
 package domain
 
+// NoTeam is the team of every player in a free-for-all match.
+const NoTeam = 0
+
 type GameModel struct {
 	TurnCount int
 	Players   map[string]*PlayerModel
 }
+
+// TeamMembers returns all players of the team.
+func (m *GameModel) TeamMembers(team int) []*PlayerModel {
+	var members []*PlayerModel
+	for _, player := range m.Players {
+		if team != NoTeam && player.Team == team {
+			members = append(members, player)
+		}
+	}
+	return members
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	DefaultBoardSize = 10
+	MinBoardSize     = 5
+	MaxBoardSize     = len(boardAlphabet)
+)
+
+var (
+	ErrInvalidBoardSize = fmt.Errorf("board size must be between %d and %d", MinBoardSize, MaxBoardSize)
+	ErrEmptyFleet       = errors.New("fleet must contain at least one ship")
+	ErrInvalidShip      = errors.New("ship length must be between 1 and the board size")
+	ErrFleetDoesNotFit  = errors.New("fleet doesn't fit on the board")
+	ErrUnknownPreset    = errors.New("unknown game preset")
+	ErrUnknownMode      = errors.New("unknown game mode")
+)
+
+type GameMode string
+
+const (
+	// ClassicMode gives a single shot per turn.
+	ClassicMode GameMode = "classic"
+	// SalvoMode gives as many shots per turn as the player has ships afloat.
+	SalvoMode GameMode = "salvo"
+)
+
+// GameRules describe the board and the fleet every player of a match gets.
+type GameRules struct {
+	BoardSize int      `json:"board_size"`
+	Fleet     []int    `json:"fleet"`
+	Mode      GameMode `json:"mode,omitempty"`
+	// IsTeamMatch splits players into two equal teams, which share the victory.
+	IsTeamMatch bool `json:"is_team_match,omitempty"`
+	// HitGrantsShot lets the player, who has hit a ship, fire once more.
+	HitGrantsShot bool `json:"hit_grants_shot,omitempty"`
+}
+
+const (
+	ClassicPreset  = "classic"
+	HasbroPreset   = "hasbro"
+	BigOceanPreset = "big_ocean"
+)
+
+var Presets = map[string]GameRules{
+	// Classic Russian rules: 10x10 board, ships from 4 to 1 decks.
+	ClassicPreset: {BoardSize: DefaultBoardSize, Fleet: DefaultFleet},
+	// Hasbro rules: carrier, battleship, cruiser, submarine and destroyer.
+	HasbroPreset:   {BoardSize: DefaultBoardSize, Fleet: []int{5, 4, 3, 3, 2}},
+	BigOceanPreset: {BoardSize: 15, Fleet: []int{5, 4, 4, 3, 3, 3, 2, 2, 2, 2, 1, 1, 1, 1, 1}},
+}
+
+func DefaultRules() GameRules {
+	return Presets[ClassicPreset]
+}
+
+func GetPreset(name string) (GameRules, error) {
+	rules, found := Presets[name]
+	if !found {
+		return GameRules{}, fmt.Errorf("%w: %q", ErrUnknownPreset, name)
+	}
+	return rules, nil
+}
+
+func (r GameRules) IsSalvo() bool {
+	return r.Mode == SalvoMode
+}
+
+// ShotsPerTurn returns how many shots the player fires in one turn.
+func (r GameRules) ShotsPerTurn(player *PlayerModel) int {
+	if !r.IsSalvo() || player == nil {
+		return 1
+	}
+	return max(len(player.Ships)-len(player.SunkShips()), 1)
+}
+
+// AfloatShips returns lengths of ships of the target, which aren't sunk yet.
+func (r GameRules) AfloatShips(target *PlayerModel) []int {
+	afloat := slices.Clone(r.Fleet)
+	for _, ship := range target.SunkShips() {
+		if idx := slices.Index(afloat, ship.Length); idx >= 0 {
+			afloat = slices.Delete(afloat, idx, idx+1)
+		}
+	}
+	return afloat
+}
+
+func (r GameRules) Validate() error {
+	switch r.Mode {
+	case "", ClassicMode, SalvoMode:
+	default:
+		return ErrUnknownMode
+	}
+
+	if r.BoardSize < MinBoardSize || r.BoardSize > MaxBoardSize {
+		return ErrInvalidBoardSize
+	}
+
+	if len(r.Fleet) == 0 {
+		return ErrEmptyFleet
+	}
+
+	for _, length := range r.Fleet {
+		if length < 1 || length > r.BoardSize {
+			return ErrInvalidShip
+		}
+	}
+
+	if _, ok := randomizeBoard(NewRNG(validationSeed), r, validationAttempts); !ok {
+		return ErrFleetDoesNotFit
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameRulesValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		rules       GameRules
+		expectedErr error
+	}{
+		{
+			name:  "classic preset",
+			rules: Presets[ClassicPreset],
+		},
+		{
+			name:  "hasbro preset",
+			rules: Presets[HasbroPreset],
+		},
+		{
+			name:  "big ocean preset",
+			rules: Presets[BigOceanPreset],
+		},
+		{
+			name:  "salvo mode",
+			rules: GameRules{BoardSize: DefaultBoardSize, Fleet: DefaultFleet, Mode: SalvoMode},
+		},
+		{
+			name:        "unknown mode",
+			rules:       GameRules{BoardSize: DefaultBoardSize, Fleet: DefaultFleet, Mode: "blitz"},
+			expectedErr: ErrUnknownMode,
+		},
+		{
+			name:        "board is too small",
+			rules:       GameRules{BoardSize: MinBoardSize - 1, Fleet: []int{1}},
+			expectedErr: ErrInvalidBoardSize,
+		},
+		{
+			name:        "board is too big",
+			rules:       GameRules{BoardSize: MaxBoardSize + 1, Fleet: []int{1}},
+			expectedErr: ErrInvalidBoardSize,
+		},
+		{
+			name:        "empty fleet",
+			rules:       GameRules{BoardSize: DefaultBoardSize},
+			expectedErr: ErrEmptyFleet,
+		},
+		{
+			name:        "ship is longer than the board",
+			rules:       GameRules{BoardSize: MinBoardSize, Fleet: []int{MinBoardSize + 1}},
+			expectedErr: ErrInvalidShip,
+		},
+		{
+			name:        "fleet doesn't fit",
+			rules:       GameRules{BoardSize: MinBoardSize, Fleet: []int{5, 5, 5, 5}},
+			expectedErr: ErrFleetDoesNotFit,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Act
+			err := tt.rules.Validate()
+
+			// 2. Assert
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestValidateIsDeterministic(t *testing.T) {
+	// 1. Arrange
+	rules := GameRules{BoardSize: MinBoardSize, Fleet: []int{4, 3, 3, 2, 2, 1, 1}}
+	want := rules.Validate()
+
+	for range 20 {
+		// 2. Act
+		err := rules.Validate()
+
+		// 3. Assert
+		require.Equal(t, want, err, "tight fleet must be validated the same way every time")
+	}
+}
+
+func TestRandomizeBoard(t *testing.T) {
+	t.Run("random board matches the rules", func(t *testing.T) {
+		for name, rules := range Presets {
+			// 1. Act
+			board := RandomizeBoard(rules)
+
+			// 2. Assert
+			require.Equalf(t, rules.BoardSize, board.Size(), "preset %s", name)
+
+			_, err := BuildBoard(ExtractShips(board), rules)
+			require.NoErrorf(t, err, "preset %s", name)
+		}
+	})
+
+	t.Run("same seed gives the same board", func(t *testing.T) {
+		// 1. Arrange
+		const seed = 42
+
+		// 2. Act
+		lhs := RandomizeBoardWithRNG(NewRNG(seed), DefaultRules())
+		rhs := RandomizeBoardWithRNG(NewRNG(seed), DefaultRules())
+
+		// 3. Assert
+		require.Equal(t, lhs, rhs)
+	})
+}
+
+func TestShotsPerTurn(t *testing.T) {
+	// 1. Arrange
+	player := NewPlayerModel(Board{
+		{Ship, Ship, Empty, Ship, Empty, Ship},
+	}, ClientMetadata{})
+	player.HitAt(3, 0)
+
+	for _, tt := range []struct {
+		name     string
+		mode     GameMode
+		expected int
+	}{
+		{
+			name:     "classic mode gives a single shot",
+			mode:     ClassicMode,
+			expected: 1,
+		},
+		{
+			name:     "salvo mode gives a shot per ship afloat",
+			mode:     SalvoMode,
+			expected: 2,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 2. Act
+			got := GameRules{Mode: tt.mode}.ShotsPerTurn(player)
+
+			// 3. Assert
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import "time"
+
+type LeaderboardSort = string
+
+const (
+	SortByWins    LeaderboardSort = "wins"
+	SortByWinRate LeaderboardSort = "win_rate"
+	SortByRating  LeaderboardSort = "rating"
+)
+
+var LeaderboardSorts = []LeaderboardSort{SortByWins, SortByWinRate, SortByRating}
+
+type LeaderboardEntry struct {
+	Rank     int           `json:"rank"`
+	Profile  PublicProfile `json:"profile"`
+	WinRate  float64       `json:"win_rate"`
+	Accuracy float64       `json:"accuracy"`
+}
+
+// Page is a part of the long list, which is fetched from the server.
+type Page[T any] struct {
+	Items    []T `json:"items"`
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+	Total    int `json:"total"`
+}
+
+// MatchRecord is the result of the finished match.
+type MatchRecord struct {
+	ID        string    `json:"id"`
+	Rules     GameRules `json:"rules"`
+	IsRated   bool      `json:"is_rated"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	// Duration is counted from the first turn, the placement isn't included.
+	Duration time.Duration `json:"duration"`
+	Turns    int           `json:"turns"`
+	// Winner is the nickname of the winning player, or the winning team.
+	Winner       string             `json:"winner"`
+	Participants []MatchParticipant `json:"participants"`
+}
+
+// MatchParticipant is the player, who started the match. Players, who left early, lost it.
+type MatchParticipant struct {
+	// Handle is empty for bots and players without a profile.
+	Handle     string  `json:"handle,omitempty"`
+	Nickname   string  `json:"nickname"`
+	Team       int     `json:"team,omitempty"`
+	IsBot      bool    `json:"is_bot"`
+	IsWinner   bool    `json:"is_winner"`
+	ShotsFired int     `json:"shots_fired"`
+	Hits       int     `json:"hits"`
+	Accuracy   float64 `json:"accuracy"`
+}
+
+// HasPlayer reports whether the player with the profile took part in the match.
+func (r MatchRecord) HasPlayer(handle string) bool {
+	for _, participant := range r.Participants {
+		if handle != "" && participant.Handle == handle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package domain
+
+type MatchStatus = string
+
+const (
+	WaitingMatch MatchStatus = "waiting"
+	PlacingMatch MatchStatus = "placing"
+	RunningMatch MatchStatus = "running"
+)
+
+// MatchSummary describes an open match in the lobby.
+type MatchSummary struct {
+	ID       string      `json:"id"`
+	Players  []string    `json:"players"`
+	Capacity int         `json:"capacity"`
+	Rules    GameRules   `json:"rules"`
+	Status   MatchStatus `json:"status"`
+}
+
+// IsJoinable reports whether there is a free seat in the match, which hasn't begun yet.
+func (s MatchSummary) IsJoinable() bool {
+	return s.Status == WaitingMatch && len(s.Players) < s.Capacity
+}
@@ -11,24 +11,47 @@ type PlayerModel struct {
 	Board     Board
 	ID        string
 	Nickname  string
-	ShipCells byte
+	ShipCells int
+	Ships     []*ShipModel
+	// IsEliminated is set by the server, since boards of opponents are masked and their ship
+	// cells can't be counted on the client.
+	IsEliminated bool
+	// Team is zero unless the player takes part in a team match.
+	Team int
 }
 
 func NewPlayerModel(board Board, metadata ClientMetadata) *PlayerModel {
-	var shipCells byte
-	for i := 0; i < board.Size(); i++ {
-		for j := 0; j < board.Size(); j++ {
+	model := &PlayerModel{
+		ID:       metadata.ClientID,
+		Nickname: metadata.Nickname,
+	}
+	model.SetBoard(board)
+	return model
+}
+
+func (m *PlayerModel) SetBoard(board Board) {
+	var shipCells int
+	for i := range board {
+		for j := range board[i] {
 			if board.GetCellType(byte(j), byte(i)) == Ship {
 				shipCells++
 			}
 		}
 	}
 
-	return &PlayerModel{
-		Board:     board,
-		ID:        metadata.ClientID,
-		Nickname:  metadata.Nickname,
-		ShipCells: shipCells,
+	m.Board = board
+	m.ShipCells = shipCells
+	placements := ExtractShips(board)
+	m.Ships = make([]*ShipModel, 0, len(placements))
+
+	for i, placement := range placements {
+		ship := NewShipModel(i, placement)
+		for _, cell := range ship.Cells {
+			if board.GetCellType(cell.X, cell.Y) != Ship {
+				ship.Hit()
+			}
+		}
+		m.Ships = append(m.Ships, ship)
 	}
 }
 
@@ -46,6 +69,14 @@ func (m *PlayerModel) Compare(rhs *PlayerModel) int {
 	return strings.Compare(m.ID, rhs.ID)
 }
 
+// IsAllyOf reports whether both players are in the same team. Players without a team have no allies.
+func (m *PlayerModel) IsAllyOf(rhs *PlayerModel) bool {
+	if rhs == nil || m.Team == NoTeam {
+		return false
+	}
+	return m.Team == rhs.Team
+}
+
 func (m *PlayerModel) IsDead() bool {
 	return m.ShipCells == 0
 }
@@ -57,17 +88,103 @@ func (m *PlayerModel) Hit() {
 	m.ShipCells--
 }
 
+// HitAt marks the ship cell as dead. If the whole ship goes down, all of its cells are marked
+// as sunk and the ship is returned.
+func (m *PlayerModel) HitAt(cellX, cellY byte) (sunkShip *ShipModel) {
+	if m.Board.GetCellType(cellX, cellY) != Ship {
+		return nil
+	}
+
+	m.Board.SetCell(cellX, cellY, Dead)
+	m.Hit()
+
+	ship := m.ShipAt(cellX, cellY)
+	if ship == nil {
+		return nil
+	}
+
+	ship.Hit()
+	if !ship.IsSunk() {
+		return nil
+	}
+
+	for _, cell := range ship.Cells {
+		m.Board.SetCell(cell.X, cell.Y, Sunk)
+	}
+	return ship
+}
+
+func (m *PlayerModel) ShipAt(cellX, cellY byte) *ShipModel {
+	for _, ship := range m.Ships {
+		if ship.Contains(cellX, cellY) {
+			return ship
+		}
+	}
+	return nil
+}
+
+func (m *PlayerModel) SunkShips() []*ShipModel {
+	ships := make([]*ShipModel, 0, len(m.Ships))
+	for _, ship := range m.Ships {
+		if ship.IsSunk() {
+			ships = append(ships, ship)
+		}
+	}
+	return ships
+}
+
 type ClientID = string
 
+type ClientRole = string
+
+const (
+	PlayerRole    ClientRole = "player"
+	SpectatorRole ClientRole = "spectator"
+)
+
+// BotDifficulty picks the strategy of server bots.
+type BotDifficulty = string
+
+const (
+	EasyBot   BotDifficulty = "easy"
+	MediumBot BotDifficulty = "medium"
+	HardBot   BotDifficulty = "hard"
+)
+
+var BotDifficulties = []BotDifficulty{EasyBot, MediumBot, HardBot}
+
 type ClientMetadata struct {
 	ClientID ClientID
 	Nickname string
+	Role     ClientRole
+	// MatchID is the match to spectate or to join. Players without it are matched automatically.
+	MatchID string
+	// ResumeToken is issued by the server on join. It's sent back to resume the session after
+	// the connection was dropped.
+	ResumeToken string
+	// BotDifficulty is set, if the player wants to play against bots right away.
+	BotDifficulty BotDifficulty
+	// CreateRoom asks the server to create a private room. Its code is sent back after the join.
+	CreateRoom bool
+	// RoomCode is the private room to join.
+	RoomCode string
+	// RoomPassword protects the created private room, or unlocks the joined one.
+	RoomPassword string
+	// Ranked puts the player into the matchmaking queue, which pairs players of a close rating.
+	Ranked bool
+	// ProfileID is the stable identity of the player, which the statistics are kept by. It isn't
+	// sent in headers, the server takes it from the session token only.
+	ProfileID string
+	// SessionToken is issued by the server on login. The server overrides the ID, the nickname
+	// and the profile of the client by ones signed in the token.
+	SessionToken string
 }
 
 func NewClientMetadata(nickname string) ClientMetadata {
 	return ClientMetadata{
 		ClientID: uuid.New().String(),
 		Nickname: nickname,
+		Role:     PlayerRole,
 	}
 }
 
@@ -75,12 +192,58 @@ func ParseClientMetadataToHeaders(metadata ClientMetadata) http.Header {
 	headers := make(http.Header)
 	headers.Set("X-Client-ID", metadata.ClientID)
 	headers.Set("X-Nickname", metadata.Nickname)
+	if metadata.Role == SpectatorRole {
+		headers.Set("X-Role", metadata.Role)
+	}
+	if metadata.MatchID != "" {
+		headers.Set("X-Match-ID", metadata.MatchID)
+	}
+	if metadata.ResumeToken != "" {
+		headers.Set("X-Resume-Token", metadata.ResumeToken)
+	}
+	if metadata.BotDifficulty != "" {
+		headers.Set("X-Bot-Difficulty", metadata.BotDifficulty)
+	}
+	if metadata.CreateRoom {
+		headers.Set("X-Create-Room", "true")
+	}
+	if metadata.RoomCode != "" {
+		headers.Set("X-Room-Code", metadata.RoomCode)
+	}
+	if metadata.RoomPassword != "" {
+		headers.Set("X-Room-Password", metadata.RoomPassword)
+	}
+	if metadata.Ranked {
+		headers.Set("X-Ranked", "true")
+	}
+	if metadata.SessionToken != "" {
+		headers.Set("Authorization", "Bearer "+metadata.SessionToken)
+	}
 	return headers
 }
 
 func ParseClientMetadataFromHeaders(r *http.Request) ClientMetadata {
-	return ClientMetadata{
-		ClientID: r.Header.Get("X-Client-ID"),
-		Nickname: r.Header.Get("X-Nickname"),
+	metadata := ClientMetadata{
+		ClientID:      r.Header.Get("X-Client-ID"),
+		MatchID:       r.Header.Get("X-Match-ID"),
+		Nickname:      r.Header.Get("X-Nickname"),
+		ResumeToken:   r.Header.Get("X-Resume-Token"),
+		BotDifficulty: r.Header.Get("X-Bot-Difficulty"),
+		CreateRoom:    r.Header.Get("X-Create-Room") == "true",
+		RoomCode:      r.Header.Get("X-Room-Code"),
+		RoomPassword:  r.Header.Get("X-Room-Password"),
+		Ranked:        r.Header.Get("X-Ranked") == "true",
+	}
+	metadata.SessionToken, _ = strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	if r.Header.Get("X-Role") == SpectatorRole {
+		metadata.Role = SpectatorRole
+	} else {
+		metadata.Role = PlayerRole
 	}
+	return metadata
+}
+
+func (m ClientMetadata) IsSpectator() bool {
+	return m.Role == SpectatorRole
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -25,7 +26,7 @@ func TestPlayerIsDeadAfterAllShipCellsWereHit(t *testing.T) {
 
 	t.Run("hit all ship cells", func(t *testing.T) {
 		// 1. Arrange
-		var board Board
+		board := NewBoard(DefaultBoardSize)
 		for i := 0; i < board.Size(); i++ {
 			for j := 0; j < board.Size(); j++ {
 				board.SetCell(byte(j), byte(i), Ship)
@@ -58,7 +59,141 @@ func TestPlayerIsDeadAfterAllShipCellsWereHit(t *testing.T) {
 		player.Hit()
 
 		// 3. Assert
-		require.Equalf(t, byte(1), player.ShipCells, "1 ship cell should remain")
+		require.Equalf(t, 1, player.ShipCells, "1 ship cell should remain")
 		require.Falsef(t, player.IsDead(), "player must not be dead")
 	})
 }
+
+func TestPlayerHitAt(t *testing.T) {
+	t.Run("hit a ship cell without sinking the ship", func(t *testing.T) {
+		// 1. Arrange
+		board := Board{
+			{Ship, Ship},
+		}
+		player := NewPlayerModel(board, ClientMetadata{})
+
+		// 2. Act
+		sunkShip := player.HitAt(0, 0)
+
+		// 3. Assert
+		require.Nil(t, sunkShip)
+		require.Equal(t, Dead, player.Board.GetCellType(0, 0))
+		require.Equal(t, Ship, player.Board.GetCellType(1, 0))
+		require.Equal(t, 1, player.ShipCells)
+	})
+
+	t.Run("sink a ship", func(t *testing.T) {
+		// 1. Arrange
+		board := Board{
+			{Ship, Ship, Empty, Ship},
+		}
+		player := NewPlayerModel(board, ClientMetadata{})
+
+		// 2. Act
+		require.Nil(t, player.HitAt(0, 0))
+		sunkShip := player.HitAt(1, 0)
+
+		// 3. Assert
+		require.NotNil(t, sunkShip)
+		require.Equal(t, 2, sunkShip.Length)
+		require.Equal(t, Sunk, player.Board.GetCellType(0, 0))
+		require.Equal(t, Sunk, player.Board.GetCellType(1, 0))
+		require.Len(t, player.SunkShips(), 1)
+		require.Falsef(t, player.IsDead(), "player must not be dead")
+	})
+
+	t.Run("hit a non-ship cell", func(t *testing.T) {
+		// 1. Arrange
+		board := Board{
+			{Dead, Ship},
+		}
+		player := NewPlayerModel(board, ClientMetadata{})
+
+		// 2. Act
+		sunkShip := player.HitAt(0, 0)
+
+		// 3. Assert
+		require.Nil(t, sunkShip)
+		require.Equal(t, 1, player.ShipCells)
+	})
+
+	t.Run("partially damaged ships are restored from the board", func(t *testing.T) {
+		// 1. Arrange
+		board := Board{
+			{Dead, Dead, Ship},
+		}
+
+		// 2. Act
+		player := NewPlayerModel(board, ClientMetadata{})
+
+		// 3. Assert
+		require.Len(t, player.Ships, 1)
+		require.Equal(t, 2, player.Ships[0].Hits)
+		require.NotNil(t, player.HitAt(2, 0))
+	})
+}
+
+func TestClientMetadataHeaders(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		metadata ClientMetadata
+		want     ClientMetadata
+	}{
+		{
+			name:     "player",
+			metadata: ClientMetadata{ClientID: "1", Nickname: "player"},
+			want:     ClientMetadata{ClientID: "1", Nickname: "player", Role: PlayerRole},
+		},
+		{
+			name:     "spectator",
+			metadata: ClientMetadata{ClientID: "1", Nickname: "spectator", Role: SpectatorRole, MatchID: "match"},
+			want:     ClientMetadata{ClientID: "1", Nickname: "spectator", Role: SpectatorRole, MatchID: "match"},
+		},
+		{
+			name:     "player against bots",
+			metadata: ClientMetadata{ClientID: "1", Nickname: "player", BotDifficulty: HardBot},
+			want:     ClientMetadata{ClientID: "1", Nickname: "player", Role: PlayerRole, BotDifficulty: HardBot},
+		},
+		{
+			name:     "player joins the picked match",
+			metadata: ClientMetadata{ClientID: "1", Nickname: "player", MatchID: "match"},
+			want:     ClientMetadata{ClientID: "1", Nickname: "player", Role: PlayerRole, MatchID: "match"},
+		},
+		{
+			name:     "player creates a private room",
+			metadata: ClientMetadata{ClientID: "1", Nickname: "player", CreateRoom: true, RoomPassword: "secret"},
+			want:     ClientMetadata{ClientID: "1", Nickname: "player", Role: PlayerRole, CreateRoom: true, RoomPassword: "secret"},
+		},
+		{
+			name:     "player joins a private room",
+			metadata: ClientMetadata{ClientID: "1", Nickname: "player", RoomCode: "ABC234"},
+			want:     ClientMetadata{ClientID: "1", Nickname: "player", Role: PlayerRole, RoomCode: "ABC234"},
+		},
+		{
+			name:     "player joins the matchmaking queue",
+			metadata: ClientMetadata{ClientID: "1", Nickname: "player", Ranked: true},
+			want:     ClientMetadata{ClientID: "1", Nickname: "player", Role: PlayerRole, Ranked: true},
+		},
+		{
+			name:     "profile isn't sent in headers",
+			metadata: ClientMetadata{ClientID: "1", Nickname: "player", ProfileID: "profile"},
+			want:     ClientMetadata{ClientID: "1", Nickname: "player", Role: PlayerRole},
+		},
+		{
+			name:     "player with a session token",
+			metadata: ClientMetadata{ClientID: "1", Nickname: "player", SessionToken: "token"},
+			want:     ClientMetadata{ClientID: "1", Nickname: "player", Role: PlayerRole, SessionToken: "token"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			req := &http.Request{Header: ParseClientMetadataToHeaders(tt.metadata)}
+
+			// 2. Act
+			got := ParseClientMetadataFromHeaders(req)
+
+			// 3. Assert
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
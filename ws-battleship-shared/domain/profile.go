@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+// DefaultRating is given to players, who haven't played rated matches yet.
+const DefaultRating = 1500.0
+
+// Profile is the persistent identity of the player with the statistics of all played matches.
+type Profile struct {
+	// ID logs into the profile with its secret, so it's never published. Others know the profile
+	// by its handle.
+	ID         string    `json:"id"`
+	Handle     string    `json:"handle"`
+	Nickname   string    `json:"nickname"`
+	CreatedAt  time.Time `json:"created_at"`
+	Wins       int       `json:"wins"`
+	Losses     int       `json:"losses"`
+	ShotsFired int       `json:"shots_fired"`
+	Hits       int       `json:"hits"`
+	Rating     float64   `json:"rating"`
+}
+
+func NewProfile(id, nickname string) Profile {
+	return Profile{
+		ID:        id,
+		Nickname:  nickname,
+		CreatedAt: time.Now().UTC(),
+		Rating:    DefaultRating,
+	}
+}
+
+// PublicProfile is the profile, as other players see it.
+type PublicProfile struct {
+	Handle   string  `json:"handle"`
+	Nickname string  `json:"nickname"`
+	Wins     int     `json:"wins"`
+	Losses   int     `json:"losses"`
+	Rating   float64 `json:"rating"`
+}
+
+func (p Profile) Public() PublicProfile {
+	return PublicProfile{
+		Handle:   p.Handle,
+		Nickname: p.Nickname,
+		Wins:     p.Wins,
+		Losses:   p.Losses,
+		Rating:   p.Rating,
+	}
+}
+
+// Accuracy is the share of shots, which hit a ship.
+func (p Profile) Accuracy() float64 {
+	return Accuracy(p.ShotsFired, p.Hits)
+}
+
+func (p Profile) Matches() int {
+	return p.Wins + p.Losses
+}
+
+func (p Profile) WinRate() float64 {
+	if p.Matches() == 0 {
+		return 0
+	}
+	return float64(p.Wins) / float64(p.Matches())
+}
+
+func Accuracy(shotsFired, hits int) float64 {
+	if shotsFired == 0 {
+		return 0
+	}
+	return float64(hits) / float64(shotsFired)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "math/rand"
+
+// RNG is a source of randomness. It's injected, so a match can be reproduced from its seed.
+type RNG interface {
+	Intn(n int) int
+	Shuffle(n int, swap func(i, j int))
+}
+
+// NewRNG returns a source, which yields the same sequence for the same seed. It isn't safe
+// for concurrent use.
+func NewRNG(seed int64) RNG {
+	return rand.New(rand.NewSource(seed))
+}
+
+// NewSeed returns a random seed for a new RNG.
+func NewSeed() int64 {
+	return rand.Int63()
+}
+
+// globalRNG uses the global source, which is safe for concurrent use.
+type globalRNG struct{}
+
+func (globalRNG) Intn(n int) int {
+	return rand.Intn(n)
+}
+
+func (globalRNG) Shuffle(n int, swap func(i, j int)) {
+	rand.Shuffle(n, swap)
+}
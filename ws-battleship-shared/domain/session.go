@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// SessionToken is issued by the server on login. The client proves its identity with it on every
+// connection, the server takes the ID and the nickname from the token, not from headers.
+type SessionToken struct {
+	Token     string    `json:"token"`
+	ClientID  ClientID  `json:"client_id"`
+	Nickname  string    `json:"nickname"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// Profile is set by the login, which created a new profile, only.
+	Profile *ProfileCredential `json:"profile,omitempty"`
+}
+
+// IsExpired reports, whether the token must be refreshed before the given moment.
+func (t SessionToken) IsExpired(at time.Time) bool {
+	return t.Token == "" || !at.Before(t.ExpiresAt)
+}
+
+// LoginRequest asks the server for a guest session. The profile is proven by its secret, a new
+// profile is created, if none is given.
+type LoginRequest struct {
+	Nickname      string `json:"nickname"`
+	ProfileID     string `json:"profile_id,omitempty"`
+	ProfileSecret string `json:"profile_secret,omitempty"`
+}
+
+// ProfileCredential lets the player log into the profile. The server gives the secret out once,
+// when the profile is created, so the client must keep it. The handle finds the player on the
+// leaderboard and in the history.
+type ProfileCredential struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+	Handle string `json:"handle"`
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "slices"
+
+type ShipModel struct {
+	ID     int          `json:"id"`
+	Length int          `json:"length"`
+	Cells  []Coordinate `json:"cells"`
+	Hits   int          `json:"hits"`
+}
+
+func NewShipModel(id int, placement ShipPlacement) *ShipModel {
+	return &ShipModel{
+		ID:     id,
+		Length: placement.Length,
+		Cells:  placement.Cells(),
+	}
+}
+
+func (s *ShipModel) IsSunk() bool {
+	return s.Hits >= s.Length
+}
+
+func (s *ShipModel) Hit() {
+	if s.IsSunk() {
+		return
+	}
+	s.Hits++
+}
+
+func (s *ShipModel) Contains(cellX, cellY byte) bool {
+	return slices.Contains(s.Cells, Coordinate{X: cellX, Y: cellY})
+}
+
+// Surroundings returns all cells around the ship. According to the no-touch rule there can't
+// be any other ship, so these cells are revealed as soon as the ship is sunk.
+func (s *ShipModel) Surroundings(board *Board) []Coordinate {
+	var result []Coordinate
+
+	for _, cell := range s.Cells {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				x, y := int(cell.X)+dx, int(cell.Y)+dy
+				if x < 0 || y < 0 || !board.checkBounds(byte(x), byte(y)) {
+					continue
+				}
+
+				neighbor := Coordinate{X: byte(x), Y: byte(y)}
+				if s.Contains(neighbor.X, neighbor.Y) || slices.Contains(result, neighbor) {
+					continue
+				}
+				result = append(result, neighbor)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShipIsSunk(t *testing.T) {
+	t.Run("ship is sunk when all cells were hit", func(t *testing.T) {
+		// 1. Arrange
+		ship := NewShipModel(0, ShipPlacement{Length: 2, Horizontal: true})
+
+		// 2. Act
+		ship.Hit()
+		ship.Hit()
+		ship.Hit()
+
+		// 3. Assert
+		require.True(t, ship.IsSunk())
+		require.Equalf(t, 2, ship.Hits, "hits must not exceed ship's length")
+	})
+
+	t.Run("ship is not sunk when at least 1 cell is alive", func(t *testing.T) {
+		// 1. Arrange
+		ship := NewShipModel(0, ShipPlacement{Length: 2, Horizontal: true})
+
+		// 2. Act
+		ship.Hit()
+
+		// 3. Assert
+		require.False(t, ship.IsSunk())
+	})
+}
+
+func TestShipSurroundings(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		placement ShipPlacement
+		expected  []Coordinate
+	}{
+		{
+			name:      "ship in the corner",
+			placement: ShipPlacement{X: 0, Y: 0, Length: 2, Horizontal: true},
+			expected: []Coordinate{
+				{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 0}, {X: 2, Y: 1},
+			},
+		},
+		{
+			name:      "vertical ship in the middle",
+			placement: ShipPlacement{X: 5, Y: 5, Length: 2, Horizontal: false},
+			expected: []Coordinate{
+				{X: 4, Y: 4}, {X: 4, Y: 5}, {X: 4, Y: 6}, {X: 5, Y: 4}, {X: 6, Y: 4}, {X: 6, Y: 5}, {X: 6, Y: 6},
+				{X: 4, Y: 7}, {X: 5, Y: 7}, {X: 6, Y: 7},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			board := NewBoard(DefaultBoardSize)
+			ship := NewShipModel(0, tt.placement)
+
+			// 2. Act
+			got := ship.Surroundings(&board)
+
+			// 3. Assert
+			require.ElementsMatch(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidTarget = errors.New("invalid target")
+	ErrInvalidSalvo  = errors.New("number of shots doesn't match the salvo")
+)
+
+// Salvo is the outcome of shots fired at a player in one go.
+type Salvo struct {
+	Hits      int
+	Cells     []string
+	SunkShips []*ShipModel
+}
+
+// Describe tells everybody, where the shooter has fired.
+func (s Salvo) Describe(shooter string) string {
+	if len(s.Cells) > 1 {
+		return fmt.Sprintf("Player '%s' fired a salvo at cells (%s): %d hit(s).", shooter, strings.Join(s.Cells, ", "), s.Hits)
+	}
+	return fmt.Sprintf("Player '%s' fired at cell (%s).", shooter, s.Cells[0])
+}
+
+// CheckShots checks all shots of a salvo in advance, so it's resolved either completely or
+// not at all.
+func (r GameRules) CheckShots(firingPlayer, targetPlayer *PlayerModel, targets []Coordinate) error {
+	if len(targets) == 0 || len(targets) > r.ShotsPerTurn(firingPlayer) {
+		return ErrInvalidSalvo
+	}
+
+	for i, target := range targets {
+		if slices.Contains(targets[:i], target) {
+			return ErrInvalidSalvo
+		}
+
+		board := targetPlayer.Board
+		if int(target.X) >= board.Size() || int(target.Y) >= board.Size() {
+			return ErrInvalidTarget
+		}
+		if !board.IsCellEmpty(target.X, target.Y) && board.GetCellType(target.X, target.Y) != Ship {
+			return ErrInvalidTarget
+		}
+	}
+	return nil
+}
+
+// KeepsTurn reports whether the shooter fires again after the salvo.
+func (r GameRules) KeepsTurn(salvo Salvo) bool {
+	return salvo.Hits > 0 && r.HitGrantsShot
+}
+
+// FireAt marks the empty cell as a miss and hits the ship cell. Cells, which were fired at
+// already, can't be fired at again.
+func (m *PlayerModel) FireAt(cellX, cellY byte) (sunkShip *ShipModel, err error) {
+	switch {
+	case m.Board.IsCellEmpty(cellX, cellY):
+		m.Board.SetCell(cellX, cellY, Miss)
+		return nil, nil
+
+	case m.Board.GetCellType(cellX, cellY) == Ship:
+		return m.HitAt(cellX, cellY), nil
+
+	default:
+		return nil, ErrInvalidTarget
+	}
+}
+
+// TakeSalvo fires at all targets. They must be checked by [GameRules.CheckShots] first.
+func (m *PlayerModel) TakeSalvo(targets []Coordinate) (Salvo, error) {
+	salvo := Salvo{Cells: make([]string, 0, len(targets))}
+	for _, target := range targets {
+		if m.Board.GetCellType(target.X, target.Y) == Ship {
+			salvo.Hits++
+		}
+
+		sunkShip, err := m.FireAt(target.X, target.Y)
+		if err != nil {
+			return salvo, err
+		}
+		salvo.Cells = append(salvo.Cells, m.Board.CellString(target.X, target.Y))
+
+		if sunkShip != nil {
+			salvo.SunkShips = append(salvo.SunkShips, sunkShip)
+		}
+	}
+	return salvo, nil
+}
+
+// Mask shows the board as somebody, who has revealed the given cells, sees it. Ships, which
+// weren't hit, stay hidden, and revealed cells without a ship are known to be water.
+func (b Board) Mask(revealed []Coordinate) Board {
+	maskedBoard := NewBoard(b.Size())
+	for _, cell := range revealed {
+		cellType := b.GetCellType(cell.X, cell.Y)
+		if b.IsCellEmpty(cell.X, cell.Y) {
+			cellType = Miss
+		}
+		maskedBoard.SetCell(cell.X, cell.Y, cellType)
+	}
+	return maskedBoard
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeSalvo(t *testing.T) {
+	t.Run("salvo counts hits and sunk ships", func(t *testing.T) {
+		// 1. Arrange
+		board := NewBoard(DefaultBoardSize)
+		board.SetCell(0, 0, Ship)
+		board.SetCell(3, 0, Ship)
+		board.SetCell(4, 0, Ship)
+		targetPlayer := NewPlayerModel(board, ClientMetadata{})
+
+		// 2. Act
+		salvo, err := targetPlayer.TakeSalvo([]Coordinate{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 7, Y: 7}})
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Equal(t, 2, salvo.Hits)
+		require.Len(t, salvo.Cells, 3)
+		require.Len(t, salvo.SunkShips, 1)
+		require.Equal(t, "Player 'player' fired a salvo at cells (a1, d1, h8): 2 hit(s).", salvo.Describe("player"))
+	})
+}
+
+func TestBoardMask(t *testing.T) {
+	t.Run("only revealed cells are shown", func(t *testing.T) {
+		// 1. Arrange
+		board := NewBoard(DefaultBoardSize)
+		board.SetCell(0, 0, Dead)
+		board.SetCell(1, 0, Ship)
+		board.SetCell(5, 5, Ship)
+
+		// 2. Act
+		maskedBoard := board.Mask([]Coordinate{{X: 0, Y: 0}, {X: 0, Y: 1}})
+
+		// 3. Assert
+		require.Equal(t, Dead, maskedBoard.GetCellType(0, 0))
+		require.Equal(t, Miss, maskedBoard.GetCellType(0, 1))
+		require.True(t, maskedBoard.IsCellEmpty(1, 0))
+		require.True(t, maskedBoard.IsCellEmpty(5, 5))
+	})
+}
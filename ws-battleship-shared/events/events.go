@@ -17,20 +17,33 @@ const (
 const TimestampFormat = time.RFC3339
 
 const (
-	PlayerJoinedEventType      EventType = "player_join"
-	PlayerLeftEventType        EventType = "player_leave"
-	PlayerTurnEventType        EventType = "player_turn"
-	PlayerFireEventType        EventType = "player_fire"
-	PlayerUpdateStateEventType EventType = "player_update_state"
-	GameStartEventType         EventType = "game_start"
-	GameEndEventType           EventType = "game_end"
-	SendMessageType            EventType = "send_message"
+	PlayerJoinedEventType       EventType = "player_join"
+	PlayerLeftEventType         EventType = "player_leave"
+	PlayerTurnEventType         EventType = "player_turn"
+	PlayerFireEventType         EventType = "player_fire"
+	PlayerUpdateStateEventType  EventType = "player_update_state"
+	GameStartEventType          EventType = "game_start"
+	GameEndEventType            EventType = "game_end"
+	SendMessageType             EventType = "send_message"
+	PlacementStartEventType     EventType = "placement_start"
+	PlaceFleetEventType         EventType = "place_fleet"
+	RerollFleetEventType        EventType = "reroll_fleet"
+	PlayerReadyEventType        EventType = "player_ready"
+	ShipSunkEventType           EventType = "ship_sunk"
+	SessionEventType            EventType = "session"
+	SessionResumedEventType     EventType = "session_resume"
+	PlayerDisconnectedEventType EventType = "player_disconnect"
+	PlayerReconnectedEventType  EventType = "player_reconnect"
+	QueueStatusEventType        EventType = "queue_status"
 )
 
 type Event struct {
 	Type      EventType       `json:"type"`
 	Timestamp string          `json:"timestamp"`
 	Data      json.RawMessage `json:"data,omitempty"`
+	// SenderID is the connection, which the event arrived on. The server sets it and it's never
+	// sent over the wire, so clients can't claim somebody else's identity.
+	SenderID string `json:"-"`
 }
 
 func CastTo[T any](e Event) (result T, err error) {
@@ -54,22 +67,22 @@ func NewEvent(eventType EventType, data any) (Event, error) {
 }
 
 type PlayerJoinedEvent struct {
-	Player *domain.PlayerModel `json:"joined_player"`
+	Player PlayerInfo `json:"joined_player"`
 }
 
 func NewPlayerJoinedEvent(joinedPlayer *domain.PlayerModel) (Event, error) {
 	return NewEvent(PlayerJoinedEventType, PlayerJoinedEvent{
-		Player: joinedPlayer,
+		Player: NewPlayerInfo(joinedPlayer),
 	})
 }
 
 type PlayerLeftEvent struct {
-	Player *domain.PlayerModel `json:"left_player"`
+	Player PlayerInfo `json:"left_player"`
 }
 
 func NewPlayerLeftEvent(leftPlayer *domain.PlayerModel) (Event, error) {
 	return NewEvent(PlayerLeftEventType, PlayerLeftEvent{
-		Player: leftPlayer,
+		Player: NewPlayerInfo(leftPlayer),
 	})
 }
 
@@ -77,13 +90,19 @@ type PlayerTurnEvent struct {
 	TurnCount       int           `json:"turn_count"`
 	TurningPlayerID string        `json:"turning_player_id"`
 	RemainingTime   time.Duration `json:"remaining_time"`
+	// IsContinuation is set when the player keeps the turn after a hit.
+	IsContinuation bool `json:"is_continuation"`
+	// Shots is the number of shots the player fires this turn. It's greater than one in salvo mode only.
+	Shots int `json:"shots"`
 }
 
-func NewPlayerTurnEvent(turnCount int, turningPlayerID string, remainingTime time.Duration) (Event, error) {
+func NewPlayerTurnEvent(turnCount int, turningPlayerID string, remainingTime time.Duration, isContinuation bool, shots int) (Event, error) {
 	return NewEvent(PlayerTurnEventType, PlayerTurnEvent{
 		TurnCount:       turnCount,
 		TurningPlayerID: turningPlayerID,
 		RemainingTime:   remainingTime,
+		IsContinuation:  isContinuation,
+		Shots:           shots,
 	})
 }
 
@@ -96,24 +115,94 @@ type FireCommandArgs struct {
 	TargetPlayerID domain.ClientID `json:"target_player_id"`
 	CellX          byte            `json:"cell_x"`
 	CellY          byte            `json:"cell_y"`
+	// Shots holds all targets of a salvo. A single shot may be given by CellX and CellY instead.
+	Shots []domain.Coordinate `json:"shots,omitempty"`
+}
+
+// Targets returns all cells the player fires at.
+func (a FireCommandArgs) Targets() []domain.Coordinate {
+	if len(a.Shots) > 0 {
+		return a.Shots
+	}
+	return []domain.Coordinate{{X: a.CellX, Y: a.CellY}}
 }
 
 func NewPlayerFireEvent(args FireCommandArgs) (Event, error) {
 	return NewEvent(PlayerFireEventType, PlayerFireEvent{FireCommandArgs: args})
 }
 
-type GameStartEvent struct{}
+type ShipSunkEvent struct {
+	FiringPlayerID domain.ClientID   `json:"firing_player_id"`
+	TargetPlayerID domain.ClientID   `json:"target_player_id"`
+	Ship           *domain.ShipModel `json:"ship"`
+}
+
+func NewShipSunkEvent(firingPlayerID, targetPlayerID domain.ClientID, ship *domain.ShipModel) (Event, error) {
+	return NewEvent(ShipSunkEventType, ShipSunkEvent{
+		FiringPlayerID: firingPlayerID,
+		TargetPlayerID: targetPlayerID,
+		Ship:           ship,
+	})
+}
+
+type PlacementStartEvent struct {
+	Rules         domain.GameRules `json:"rules"`
+	RemainingTime time.Duration    `json:"remaining_time"`
+}
+
+func NewPlacementStartEvent(rules domain.GameRules, remainingTime time.Duration) (Event, error) {
+	return NewEvent(PlacementStartEventType, PlacementStartEvent{
+		Rules:         rules,
+		RemainingTime: remainingTime,
+	})
+}
+
+type PlaceFleetEvent struct {
+	PlaceFleetCommandArgs
+}
+
+type PlaceFleetCommandArgs struct {
+	PlayerID domain.ClientID        `json:"player_id"`
+	Ships    []domain.ShipPlacement `json:"ships"`
+}
+
+func NewPlaceFleetEvent(args PlaceFleetCommandArgs) (Event, error) {
+	return NewEvent(PlaceFleetEventType, PlaceFleetEvent{PlaceFleetCommandArgs: args})
+}
+
+type RerollFleetEvent struct {
+	PlayerID domain.ClientID `json:"player_id"`
+}
+
+func NewRerollFleetEvent(playerID domain.ClientID) (Event, error) {
+	return NewEvent(RerollFleetEventType, RerollFleetEvent{PlayerID: playerID})
+}
+
+type PlayerReadyEvent struct {
+	PlayerID domain.ClientID `json:"player_id"`
+}
+
+func NewPlayerReadyEvent(playerID domain.ClientID) (Event, error) {
+	return NewEvent(PlayerReadyEventType, PlayerReadyEvent{PlayerID: playerID})
+}
+
+type GameStartEvent struct {
+	// Seed reproduces boards and the turn order of the match.
+	Seed int64 `json:"seed"`
+}
 
-func NewGameStartEvent() (Event, error) {
-	return NewEvent(GameStartEventType, GameStartEvent{})
+func NewGameStartEvent(seed int64) (Event, error) {
+	return NewEvent(GameStartEventType, GameStartEvent{Seed: seed})
 }
 
 type GameEndEvent struct {
-	WinningPlayer *domain.PlayerModel `json:"winning_player"`
+	WinningPlayer PlayerInfo `json:"winning_player"`
+	// WinningTeam is set in team matches only.
+	WinningTeam int `json:"winning_team,omitempty"`
 }
 
 func NewGameEndEvent(winningPlayer *domain.PlayerModel) (Event, error) {
-	return NewEvent(GameEndEventType, GameEndEvent{WinningPlayer: winningPlayer})
+	return NewEvent(GameEndEventType, GameEndEvent{WinningPlayer: NewPlayerInfo(winningPlayer), WinningTeam: winningPlayer.Team})
 }
 
 type ChatMessageType = string
@@ -146,11 +235,70 @@ func NewChatNotificationEvent(msg string, msgType ChatMessageType) (Event, error
 }
 
 type PlayerUpdateStateEvent struct {
-	GameModel *domain.GameModel `json:"game_model"`
+	GameState *GameState `json:"game_model"`
 }
 
-func NewPlayerUpdateStateEvent(gameModel *domain.GameModel) (Event, error) {
+func NewPlayerUpdateStateEvent(gameState *GameState) (Event, error) {
 	return NewEvent(PlayerUpdateStateEventType, PlayerUpdateStateEvent{
-		GameModel: gameModel,
+		GameState: gameState,
+	})
+}
+
+type SessionEvent struct {
+	ResumeToken string `json:"resume_token"`
+}
+
+func NewSessionEvent(resumeToken string) (Event, error) {
+	return NewEvent(SessionEventType, SessionEvent{ResumeToken: resumeToken})
+}
+
+// SessionResumedEvent restores the state of the match for the reconnected player.
+type SessionResumedEvent struct {
+	GameState *GameState `json:"game_model"`
+	Chat      []Event    `json:"chat"`
+	// Turn is set when the match has already started.
+	Turn *PlayerTurnEvent `json:"turn,omitempty"`
+}
+
+func NewSessionResumedEvent(gameState *GameState, chat []Event, turn *PlayerTurnEvent) (Event, error) {
+	return NewEvent(SessionResumedEventType, SessionResumedEvent{
+		GameState: gameState,
+		Chat:      chat,
+		Turn:      turn,
+	})
+}
+
+type PlayerDisconnectedEvent struct {
+	PlayerID    domain.ClientID `json:"player_id"`
+	GracePeriod time.Duration   `json:"grace_period"`
+}
+
+func NewPlayerDisconnectedEvent(playerID domain.ClientID, gracePeriod time.Duration) (Event, error) {
+	return NewEvent(PlayerDisconnectedEventType, PlayerDisconnectedEvent{PlayerID: playerID, GracePeriod: gracePeriod})
+}
+
+type PlayerReconnectedEvent struct {
+	PlayerID      domain.ClientID `json:"player_id"`
+	RemainingTime time.Duration   `json:"remaining_time"`
+}
+
+func NewPlayerReconnectedEvent(playerID domain.ClientID, remainingTime time.Duration) (Event, error) {
+	return NewEvent(PlayerReconnectedEventType, PlayerReconnectedEvent{PlayerID: playerID, RemainingTime: remainingTime})
+}
+
+// QueueStatusEvent is sent to the player, who waits in the matchmaking queue. The last one has
+// IsMatched set, all events of the match follow it.
+type QueueStatusEvent struct {
+	Position int `json:"position"`
+	// EstimatedWait is zero, if the server can't estimate it yet.
+	EstimatedWait time.Duration `json:"estimated_wait"`
+	IsMatched     bool          `json:"is_matched"`
+}
+
+func NewQueueStatusEvent(position int, estimatedWait time.Duration, isMatched bool) (Event, error) {
+	return NewEvent(QueueStatusEventType, QueueStatusEvent{
+		Position:      position,
+		EstimatedWait: estimatedWait,
+		IsMatched:     isMatched,
 	})
 }
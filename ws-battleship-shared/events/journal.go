@@ -0,0 +1,50 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Recipients of journal entries besides the player IDs.
+const (
+	// JournalBroadcast marks events, which were sent to all players of the match.
+	JournalBroadcast = ""
+	// JournalOmniscient marks events, which nobody received, like the unmasked state of the match.
+	JournalOmniscient = "*"
+)
+
+// journalLineBytesMax limits a single entry, since the state of the match holds all boards.
+const journalLineBytesMax = 1 << 20
+
+// JournalEntry is a single line of the match journal, which is written in JSON Lines.
+type JournalEntry struct {
+	Time        time.Time `json:"time"`
+	RecipientID string    `json:"recipient_id,omitempty"`
+	Event       Event     `json:"event"`
+}
+
+func ReadJournal(r io.Reader) ([]JournalEntry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, ReadBufferBytesMax), journalLineBytesMax)
+
+	var entries []JournalEntry
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse journal line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJournal(t *testing.T) {
+	t.Run("entries are read line by line", func(t *testing.T) {
+		// 1. Arrange
+		journal := strings.Join([]string{
+			`{"time":"2024-01-01T10:00:00Z","event":{"type":"game_start","timestamp":"2024-01-01T10:00:00Z"}}`,
+			``,
+			`{"time":"2024-01-01T10:00:01Z","recipient_id":"*","event":{"type":"player_fire","timestamp":"2024-01-01T10:00:01Z"}}`,
+		}, "\n")
+
+		// 2. Act
+		entries, err := ReadJournal(strings.NewReader(journal))
+
+		// 3. Assert
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, JournalBroadcast, entries[0].RecipientID)
+		require.Equal(t, GameStartEventType, entries[0].Event.Type)
+		require.Equal(t, JournalOmniscient, entries[1].RecipientID)
+	})
+
+	t.Run("broken line is reported", func(t *testing.T) {
+		// 1. Arrange
+		journal := `{"time":"2024-01-01T10:00:00Z"` + "\n"
+
+		// 2. Act
+		_, err := ReadJournal(strings.NewReader(journal))
+
+		// 3. Assert
+		require.ErrorContains(t, err, "line 1")
+	})
+}
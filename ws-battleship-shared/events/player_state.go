@@ -0,0 +1,108 @@
+package events
+
+import "ws-battleship-shared/domain"
+
+// PlayerInfo is the public part of the player, which is safe to send to anybody.
+type PlayerInfo struct {
+	ID           string `json:"id"`
+	Nickname     string `json:"nickname"`
+	Team         int    `json:"team"`
+	IsEliminated bool   `json:"is_eliminated"`
+}
+
+func NewPlayerInfo(model *domain.PlayerModel) PlayerInfo {
+	return PlayerInfo{
+		ID:           model.ID,
+		Nickname:     model.Nickname,
+		Team:         model.Team,
+		IsEliminated: model.IsEliminated,
+	}
+}
+
+// PlayerState is the player as the recipient of the event sees it. The board of another player
+// must be masked, so ships, which weren't hit, stay hidden.
+type PlayerState struct {
+	PlayerInfo
+	Board domain.Board `json:"board"`
+	// ShipCells is sent with the whole board only, since it tells how much of the fleet is afloat.
+	ShipCells int                 `json:"ship_cells,omitempty"`
+	Ships     []*domain.ShipModel `json:"ships"`
+}
+
+// NewPlayerStateFor shows the player as the viewer sees it. The whole board is revealed to its
+// owner and allies only, others get the masked board.
+func NewPlayerStateFor(viewer, model *domain.PlayerModel, maskedBoard domain.Board) *PlayerState {
+	if viewer != nil && (viewer.ID == model.ID || model.IsAllyOf(viewer)) {
+		return newPlayerState(model)
+	}
+	return NewMaskedPlayerState(model, maskedBoard)
+}
+
+func newPlayerState(model *domain.PlayerModel) *PlayerState {
+	return &PlayerState{
+		PlayerInfo: NewPlayerInfo(model),
+		Board:      model.Board,
+		ShipCells:  model.ShipCells,
+		Ships:      model.Ships,
+	}
+}
+
+// NewMaskedPlayerState shows the masked board of another player. Only sunk ships are revealed.
+func NewMaskedPlayerState(model *domain.PlayerModel, maskedBoard domain.Board) *PlayerState {
+	return &PlayerState{
+		PlayerInfo: NewPlayerInfo(model),
+		Board:      maskedBoard,
+		Ships:      model.SunkShips(),
+	}
+}
+
+func (s *PlayerState) Model() *domain.PlayerModel {
+	return &domain.PlayerModel{
+		Board:        s.Board,
+		ID:           s.ID,
+		Nickname:     s.Nickname,
+		ShipCells:    s.ShipCells,
+		Ships:        s.Ships,
+		IsEliminated: s.IsEliminated,
+		Team:         s.Team,
+	}
+}
+
+// GameState is the match as the recipient of the event sees it.
+type GameState struct {
+	TurnCount int                     `json:"turn_count"`
+	Players   map[string]*PlayerState `json:"players"`
+}
+
+func NewGameState(turnCount, playersCount int) *GameState {
+	return &GameState{
+		TurnCount: turnCount,
+		Players:   make(map[string]*PlayerState, playersCount),
+	}
+}
+
+// NewRevealedGameState reveals boards of all players. It's recorded into the journal and sent
+// once the match is over only.
+func NewRevealedGameState(turnCount int, models ...*domain.PlayerModel) *GameState {
+	gameState := NewGameState(turnCount, len(models))
+	for _, model := range models {
+		gameState.Players[model.ID] = newPlayerState(model)
+	}
+	return gameState
+}
+
+// Model converts the state into the model, which the client plays on.
+func (s *GameState) Model() *domain.GameModel {
+	if s == nil {
+		return nil
+	}
+
+	gameModel := &domain.GameModel{
+		TurnCount: s.TurnCount,
+		Players:   make(map[string]*domain.PlayerModel, len(s.Players)),
+	}
+	for playerID, player := range s.Players {
+		gameModel.Players[playerID] = player.Model()
+	}
+	return gameModel
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"ws-battleship-shared/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlayerStateFor(t *testing.T) {
+	newModel := func(id string, team int) *domain.PlayerModel {
+		board := domain.NewBoard(domain.DefaultBoardSize)
+		board.SetCell(0, 0, domain.Ship)
+
+		model := domain.NewPlayerModel(board, domain.ClientMetadata{ClientID: id, Nickname: id})
+		model.Team = team
+		return model
+	}
+	maskedBoard := domain.NewBoard(domain.DefaultBoardSize)
+
+	for _, tt := range []struct {
+		name     string
+		viewer   *domain.PlayerModel
+		revealed bool
+	}{
+		{name: "owner sees the whole board", viewer: newModel("owner", 1), revealed: true},
+		{name: "ally sees the whole board", viewer: newModel("ally", 1), revealed: true},
+		{name: "opponent sees the masked board", viewer: newModel("opponent", 2)},
+		{name: "nobody sees the masked board", viewer: nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. Arrange
+			model := newModel("owner", 1)
+
+			// 2. Act
+			state := NewPlayerStateFor(tt.viewer, model, maskedBoard)
+
+			// 3. Assert
+			require.Equal(t, tt.revealed, state.Board.GetCellType(0, 0) == domain.Ship)
+		})
+	}
+}
+
+func TestPlayerStateJSON(t *testing.T) {
+	t.Run("keys are in snake case", func(t *testing.T) {
+		// 1. Arrange
+		model := domain.NewPlayerModel(domain.NewBoard(domain.DefaultBoardSize), domain.ClientMetadata{ClientID: "1", Nickname: "player"})
+		gameState := NewRevealedGameState(3, model)
+
+		// 2. Act
+		data, err := json.Marshal(gameState)
+
+		// 3. Assert
+		require.NoError(t, err)
+
+		var raw map[string]any
+		require.NoError(t, json.Unmarshal(data, &raw))
+		require.Equal(t, float64(3), raw["turn_count"])
+
+		player := raw["players"].(map[string]any)["1"].(map[string]any)
+		for _, key := range []string{"id", "nickname", "team", "is_eliminated", "board", "ships"} {
+			require.Contains(t, player, key)
+		}
+	})
+}